@@ -16,6 +16,12 @@ type Enviornment struct {
 	SecretKey []byte
 	ExpiresAt time.Duration
 
+	// Services that issue tokens sign them with the first of
+	// SigningKeyFiles, services that verify tokens fetch the public keys
+	// from JWKSURL. Both fall back to SecretKey when unset.
+	SigningKeyFiles []string
+	JWKSURL         string
+
 	Dbhost string
 	Dbport string
 	Dbuser string
@@ -1,20 +1,27 @@
 package auth
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-func GenerateJWT(secretKey []byte, expiresAt time.Duration, subject int) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Subject:   strconv.FormatInt(int64(subject), 10),
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+func GenerateJWT(signer Signer, expiresAt time.Duration, subject int, role Role) (string, error) {
+	tokenString, err := signer.Sign(Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    Issuer(role),
+			Audience:  jwt.ClaimStrings{Audience},
+			Subject:   strconv.FormatInt(int64(subject), 10),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	})
 
-	tokenString, err := token.SignedString(secretKey)
-
 	if err != nil {
 		return "", err
 	}
@@ -22,14 +29,36 @@ func GenerateJWT(secretKey []byte, expiresAt time.Duration, subject int) (string
 	return tokenString, nil
 }
 
-func VerifyJWT(jwtString string, secretKey []byte) (*jwt.Token, error) {
-	token, err := jwt.Parse(jwtString, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+func VerifyJWT(jwtString string, keys KeySet, role Role) (*jwt.Token, error) {
+	token, err := jwt.ParseWithClaims(jwtString, &Claims{}, keys.Keyfunc,
+		jwt.WithValidMethods(keys.ValidMethods()),
+		jwt.WithAudience(Audience),
+		jwt.WithIssuer(Issuer(role)),
+	)
 
-	if err != nil {
+	if errors.Is(err, jwt.ErrTokenInvalidIssuer) {
+		return nil, ErrInvalidRole
+	} else if errors.Is(err, ErrMissingKeyID) {
+		return nil, ErrMissingKeyID
+	} else if errors.Is(err, ErrUnknownKeyID) {
+		return nil, ErrUnknownKeyID
+	} else if err != nil {
 		return nil, err
 	}
 
+	claims := token.Claims.(*Claims)
+	if claims.Role != role {
+		return nil, ErrInvalidRole
+	}
+
 	return token, nil
 }
+
+func getTokenID(token *jwt.Token) (string, error) {
+	claims, ok := token.Claims.(*Claims)
+	if !ok || claims.ID == "" {
+		return "", ErrMissingTokenID
+	}
+
+	return claims.ID, nil
+}
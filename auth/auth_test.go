@@ -16,7 +16,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var secretKey = []byte("very-secret-key")
+var secretKey = auth.HMACKey("very-secret-key")
 var expiresAt = time.Second
 
 func DummyHandler(w http.ResponseWriter, r *http.Request) {
@@ -26,16 +26,16 @@ func DummyHandler(w http.ResponseWriter, r *http.Request) {
 func TestJWTVerification(t *testing.T) {
 
 	t.Run("returns Token on valid JWT ", func(t *testing.T) {
-		jwtString, _ := auth.GenerateJWT(secretKey, expiresAt, 0)
+		jwtString, _ := auth.GenerateJWT(secretKey, expiresAt, 0, auth.CUSTOMER)
 
-		_, err := auth.VerifyJWT(jwtString, secretKey)
+		_, err := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
 		if err != nil {
 			t.Errorf("did not expect error, got %v", err)
 		}
 	})
 
 	t.Run("returns error on invalid JWT", func(t *testing.T) {
-		jwtString, _ := auth.GenerateJWT(secretKey, expiresAt, 0)
+		jwtString, _ := auth.GenerateJWT(secretKey, expiresAt, 0, auth.CUSTOMER)
 
 		jwtByteArr := []byte(jwtString)
 		if jwtByteArr[10] == 'A' {
@@ -45,7 +45,47 @@ func TestJWTVerification(t *testing.T) {
 		}
 		jwtString = string(jwtByteArr)
 
-		_, err := auth.VerifyJWT(jwtString, secretKey)
+		_, err := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+
+	t.Run("returns ErrInvalidRole on JWT of another role", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, expiresAt, 0, auth.COURIER)
+
+		_, err := auth.VerifyJWT(jwtString, secretKey, auth.RESTAURANT)
+		testutil.AssertError(t, err, auth.ErrInvalidRole)
+	})
+
+	t.Run("returns ErrInvalidRole on role that doesn't match issuer", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			Role: auth.COURIER,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.Issuer(auth.RESTAURANT),
+				Audience:  jwt.ClaimStrings{auth.Audience},
+				Subject:   "0",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+			},
+		})
+		jwtString, _ := token.SignedString([]byte(secretKey))
+
+		_, err := auth.VerifyJWT(jwtString, secretKey, auth.RESTAURANT)
+		testutil.AssertError(t, err, auth.ErrInvalidRole)
+	})
+
+	t.Run("returns error on JWT without audience", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			Role: auth.CUSTOMER,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.Issuer(auth.CUSTOMER),
+				Subject:   "0",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+			},
+		})
+		jwtString, _ := token.SignedString([]byte(secretKey))
+
+		_, err := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
 		if err == nil {
 			t.Errorf("did not get error but expected one")
 		}
@@ -71,7 +111,7 @@ func (d *DummyVerifier) DoesSubjectExist(id int) (bool, error) {
 
 func TestAuthenticationMW(t *testing.T) {
 	dummyVerifier := &DummyVerifier{false, false}
-	dummyHandler := auth.AuthenticationMW(DummyHandler, dummyVerifier, secretKey)
+	dummyHandler := auth.AuthenticationMW(DummyHandler, dummyVerifier, secretKey, auth.CUSTOMER)
 
 	t.Run("returns Unauthorized on missing JWT", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/", nil)
@@ -106,11 +146,16 @@ func TestAuthenticationMW(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/", nil)
 		response := httptest.NewRecorder()
 
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			Role: auth.CUSTOMER,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.Issuer(auth.CUSTOMER),
+				Audience:  jwt.ClaimStrings{auth.Audience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+			},
 		})
 
-		tokenString, _ := token.SignedString(secretKey)
+		tokenString, _ := token.SignedString([]byte(secretKey))
 		request.Header.Add("Token", tokenString)
 
 		dummyHandler(response, request)
@@ -123,12 +168,17 @@ func TestAuthenticationMW(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/", nil)
 		response := httptest.NewRecorder()
 
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-			Subject:   "notAnIntegerSubject",
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			Role: auth.CUSTOMER,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.Issuer(auth.CUSTOMER),
+				Audience:  jwt.ClaimStrings{auth.Audience},
+				Subject:   "notAnIntegerSubject",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+			},
 		})
 
-		tokenString, _ := token.SignedString(secretKey)
+		tokenString, _ := token.SignedString([]byte(secretKey))
 		request.Header.Add("Token", tokenString)
 
 		dummyHandler(response, request)
@@ -137,12 +187,25 @@ func TestAuthenticationMW(t *testing.T) {
 		assertErrorResponse(t, response.Body, auth.ErrNonIntegerSubject)
 	})
 
+	t.Run("returns Unauthorized on JWT of another role", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		response := httptest.NewRecorder()
+
+		courierJWT, _ := auth.GenerateJWT(secretKey, expiresAt, 10, auth.COURIER)
+		request.Header.Add("Token", courierJWT)
+
+		dummyHandler(response, request)
+
+		assertStatus(t, response.Code, http.StatusUnauthorized)
+		assertErrorResponse(t, response.Body, auth.ErrInvalidRole)
+	})
+
 	t.Run("returns Internal Server Error on error from verifier", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/", nil)
 		response := httptest.NewRecorder()
 
 		want := 10
-		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want)
+		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want, auth.CUSTOMER)
 		request.Header.Add("Token", dummyJWT)
 
 		dummyVerifier.shouldError = true
@@ -157,7 +220,7 @@ func TestAuthenticationMW(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		want := 10
-		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want)
+		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want, auth.CUSTOMER)
 		request.Header.Add("Token", dummyJWT)
 
 		dummyVerifier.shouldFail = true
@@ -173,7 +236,7 @@ func TestAuthenticationMW(t *testing.T) {
 		response := httptest.NewRecorder()
 
 		want := 10
-		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want)
+		dummyJWT, _ := auth.GenerateJWT(secretKey, expiresAt, want, auth.CUSTOMER)
 		request.Header.Add("Token", dummyJWT)
 
 		dummyHandler(response, request)
@@ -201,14 +264,16 @@ func StubVerifyJWT(jwt string) (msgtypes.AuthResponse, error) {
 		return msgtypes.AuthResponse{Status: msgtypes.INVALID, ID: 0}, nil
 	} else if jwt == "10" {
 		return msgtypes.AuthResponse{Status: msgtypes.NOT_FOUND, ID: 0}, nil
+	} else if jwt == "courierJWT" {
+		return msgtypes.AuthResponse{Status: msgtypes.OK, ID: 1, Role: string(auth.COURIER)}, nil
 	} else {
 		id, _ := strconv.Atoi(jwt)
-		return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id}, nil
+		return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id, Role: string(auth.CUSTOMER)}, nil
 	}
 }
 
 func TestAuthMiddleware(t *testing.T) {
-	handler := auth.RemoteAuthenticationMW(DummyHandler, StubVerifyJWT)
+	handler := auth.RemoteAuthenticationMW(DummyHandler, StubVerifyJWT, auth.CUSTOMER)
 
 	t.Run("returns Unauthorized on missing JWT", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/", nil)
@@ -243,6 +308,17 @@ func TestAuthMiddleware(t *testing.T) {
 		testutil.AssertErrorResponse(t, response.Body, auth.ErrSubjectNotFound)
 	})
 
+	t.Run("returns Unauthorized on subject of another role", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Add("Token", "courierJWT")
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRole)
+	})
+
 	t.Run("returns Accepted on authentic customer", func(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodGet, "/", nil)
 		request.Header.Add("Token", strconv.Itoa(1))
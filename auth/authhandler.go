@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/VitoNaychev/food-app/msgtypes"
+)
+
+// AuthHandler answers the auth requests of services that verify tokens of
+// the given role through RemoteAuthClient.
+func AuthHandler(verifier Verifier, keys KeySet, role Role) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authResponse := msgtypes.AuthResponse{Status: msgtypes.INVALID}
+
+		if tokenHeader := r.Header.Get("Token"); tokenHeader == "" {
+			writeAuthResponse(w, authResponse, msgtypes.MISSING_TOKEN)
+			return
+		}
+
+		token, err := VerifyJWT(r.Header["Token"][0], keys, role)
+		if err != nil {
+			writeAuthResponse(w, authResponse, msgtypes.INVALID)
+			return
+		}
+
+		id, err := getIDFromToken(token)
+		if err != nil {
+			writeAuthResponse(w, authResponse, msgtypes.INVALID)
+			return
+		}
+
+		if revocationVerifier, ok := verifier.(RevocationVerifier); ok {
+			revoked, err := revocationVerifier.IsJWTRevoked(token)
+			if err != nil {
+				writeAuthResponse(w, authResponse, msgtypes.INVALID)
+				return
+			}
+
+			if revoked {
+				writeAuthResponse(w, authResponse, msgtypes.REVOKED)
+				return
+			}
+		}
+
+		exists, err := verifier.DoesSubjectExist(id)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !exists {
+			writeAuthResponse(w, authResponse, msgtypes.NOT_FOUND)
+			return
+		}
+
+		authResponse.ID = id
+		authResponse.Role = string(role)
+		writeAuthResponse(w, authResponse, msgtypes.OK)
+	})
+}
+
+func writeAuthResponse(w http.ResponseWriter, authResponse msgtypes.AuthResponse, status msgtypes.AuthStatus) {
+	authResponse.Status = status
+	json.NewEncoder(w).Encode(authResponse)
+}
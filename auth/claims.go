@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Role string
+
+const (
+	CUSTOMER   Role = "customer"
+	RESTAURANT Role = "restaurant"
+	COURIER    Role = "courier"
+)
+
+// Each account service signs with the keys of its own KeyRing, which other
+// services fetch from its JWKS endpoint. The role and issuer still have to be
+// checked, since services that fall back to the shared secret can't tell the
+// signers apart, and every service accepts the same audience.
+const Audience = "food-app"
+
+type Claims struct {
+	Role Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func Issuer(role Role) string {
+	return string(role) + "-svc"
+}
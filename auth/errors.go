@@ -3,10 +3,20 @@ package auth
 import "errors"
 
 var (
-	ErrMissingToken       = errors.New("missing token")
-	ErrInvalidToken       = errors.New("token is invalid")
-	ErrInvalidCredentials = errors.New("invalid user credentials")
-	ErrMissingSubject     = errors.New("token does not contain subject field")
-	ErrNonIntegerSubject  = errors.New("token subject field is not an integer")
-	ErrSubjectNotFound    = errors.New("subject with this ID doesn't exist")
+	ErrMissingToken        = errors.New("missing token")
+	ErrInvalidToken        = errors.New("token is invalid")
+	ErrInvalidCredentials  = errors.New("invalid user credentials")
+	ErrMissingSubject      = errors.New("token does not contain subject field")
+	ErrNonIntegerSubject   = errors.New("token subject field is not an integer")
+	ErrSubjectNotFound     = errors.New("subject with this ID doesn't exist")
+	ErrMissingTokenID      = errors.New("token does not contain jti field")
+	ErrRevokedToken        = errors.New("token has been revoked")
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid or expired")
+	ErrInvalidRole         = errors.New("token role is not allowed for this endpoint")
+	ErrMissingKeyID        = errors.New("token does not contain kid header")
+	ErrUnknownKeyID        = errors.New("token is signed with an unknown key")
+	ErrUnsupportedKey      = errors.New("key type is not supported")
+	ErrInvalidSigningKey   = errors.New("signing key is not a valid PEM encoded private key")
+	ErrInvalidJWK          = errors.New("JWK is invalid")
+	ErrCircuitOpen         = errors.New("auth service is unavailable")
 )
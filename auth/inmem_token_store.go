@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type InMemoryTokenStore struct {
+	mu            sync.Mutex
+	refreshTokens map[string]RefreshToken
+	revokedTokens map[string]time.Time
+
+	revokedSubjects map[int]time.Time
+}
+
+func NewInMemoryTokenStore() *InMemoryTokenStore {
+	return &InMemoryTokenStore{
+		refreshTokens: map[string]RefreshToken{},
+		revokedTokens: map[string]time.Time{},
+
+		revokedSubjects: map[int]time.Time{},
+	}
+}
+
+func (i *InMemoryTokenStore) CreateRefreshToken(refreshToken RefreshToken) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.refreshTokens[refreshToken.Hash] = refreshToken
+
+	return nil
+}
+
+func (i *InMemoryTokenStore) TakeRefreshToken(hash string) (RefreshToken, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	refreshToken, ok := i.refreshTokens[hash]
+	if !ok {
+		return RefreshToken{}, storeerrors.ErrNotFound
+	}
+
+	delete(i.refreshTokens, hash)
+
+	return refreshToken, nil
+}
+
+func (i *InMemoryTokenStore) DeleteRefreshTokensBySubject(subject int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for hash, refreshToken := range i.refreshTokens {
+		if refreshToken.Subject == subject {
+			delete(i.refreshTokens, hash)
+		}
+	}
+
+	return nil
+}
+
+// Revoked tokens only have to be remembered until they expire, after that
+// they are rejected anyway, so expired entries are dropped on every
+// revocation.
+func (i *InMemoryTokenStore) RevokeToken(id string, expiresAt time.Time) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	for revokedID, revokedExpiresAt := range i.revokedTokens {
+		if revokedExpiresAt.Before(now) {
+			delete(i.revokedTokens, revokedID)
+		}
+	}
+
+	i.revokedTokens[id] = expiresAt
+
+	return nil
+}
+
+func (i *InMemoryTokenStore) IsTokenRevoked(id string) (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	_, ok := i.revokedTokens[id]
+
+	return ok, nil
+}
+
+func (i *InMemoryTokenStore) RevokeSubject(subject int, revokedBefore time.Time) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if revokedBefore.After(i.revokedSubjects[subject]) {
+		i.revokedSubjects[subject] = revokedBefore
+	}
+
+	return nil
+}
+
+func (i *InMemoryTokenStore) GetSubjectRevokedBefore(subject int) (time.Time, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.revokedSubjects[subject], nil
+}
@@ -0,0 +1,331 @@
+package auth_test
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestKeyRing(t *testing.T) {
+	signingKeys := map[string]func() (auth.SigningKey, error){
+		"RS256": auth.GenerateRSASigningKey,
+		"EdDSA": auth.GenerateEd25519SigningKey,
+	}
+
+	for alg, generateKey := range signingKeys {
+		t.Run("verifies JWT signed with "+alg, func(t *testing.T) {
+			key, err := generateKey()
+			testutil.AssertNoErr(t, err)
+			keyRing := auth.NewKeyRing(key, time.Hour)
+
+			jwtString, err := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+			testutil.AssertNoErr(t, err)
+
+			token, err := auth.VerifyJWT(jwtString, keyRing, auth.RESTAURANT)
+			testutil.AssertNoErr(t, err)
+			testutil.AssertEqual(t, token.Method.Alg(), alg)
+			testutil.AssertEqual(t, token.Header["kid"], interface{}(key.ID))
+		})
+	}
+
+	t.Run("returns error on JWT signed with shared secret", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(key, time.Hour)
+
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.RESTAURANT)
+
+		_, err := auth.VerifyJWT(jwtString, keyRing, auth.RESTAURANT)
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+
+	t.Run("verifies JWT signed with rotated out key", func(t *testing.T) {
+		oldKey, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(oldKey, time.Hour)
+		oldJWT, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+
+		newKey, _ := auth.GenerateRSASigningKey()
+		keyRing.Rotate(newKey)
+		newJWT, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+
+		_, err := auth.VerifyJWT(oldJWT, keyRing, auth.RESTAURANT)
+		testutil.AssertNoErr(t, err)
+
+		token, err := auth.VerifyJWT(newJWT, keyRing, auth.RESTAURANT)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, token.Header["kid"], interface{}(newKey.ID))
+	})
+
+	t.Run("returns ErrUnknownKeyID on JWT signed with expired key", func(t *testing.T) {
+		oldKey, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(oldKey, 0)
+		oldJWT, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+
+		newKey, _ := auth.GenerateEd25519SigningKey()
+		keyRing.Rotate(newKey)
+
+		_, err := auth.VerifyJWT(oldJWT, keyRing, auth.RESTAURANT)
+		testutil.AssertError(t, err, auth.ErrUnknownKeyID)
+	})
+
+	t.Run("publishes current and rotated out keys", func(t *testing.T) {
+		oldKey, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(oldKey, time.Hour)
+
+		newKey, _ := auth.GenerateRSASigningKey()
+		keyRing.Rotate(newKey)
+
+		gotKeys := map[string]string{}
+		for _, jwk := range keyRing.JWKS().Keys {
+			gotKeys[jwk.Kid] = jwk.Alg
+		}
+
+		wantKeys := map[string]string{oldKey.ID: "EdDSA", newKey.ID: "RS256"}
+		testutil.AssertEqual(t, gotKeys, wantKeys)
+	})
+}
+
+func TestJWK(t *testing.T) {
+	signingKeys := map[string]func() (auth.SigningKey, error){
+		"RS256": auth.GenerateRSASigningKey,
+		"EdDSA": auth.GenerateEd25519SigningKey,
+	}
+
+	for alg, generateKey := range signingKeys {
+		t.Run("converts "+alg+" public key to JWK and back", func(t *testing.T) {
+			key, _ := generateKey()
+
+			jwk, err := auth.NewJWK(key.ID, key.Key.Public())
+			testutil.AssertNoErr(t, err)
+			testutil.AssertEqual(t, jwk.Alg, alg)
+
+			got, err := jwk.PublicKey()
+			testutil.AssertNoErr(t, err)
+
+			if !reflect.DeepEqual(got, key.Key.Public()) {
+				t.Errorf("got public key %v want %v", got, key.Key.Public())
+			}
+		})
+	}
+
+	t.Run("returns ErrUnsupportedKey on unsupported key type", func(t *testing.T) {
+		_, err := auth.NewJWK("kid", []byte("not-a-public-key"))
+		testutil.AssertError(t, err, auth.ErrUnsupportedKey)
+	})
+}
+
+func TestNewSigner(t *testing.T) {
+	t.Run("falls back to shared secret without key files", func(t *testing.T) {
+		signer, err := auth.NewSigner(secretKey, []string{""}, time.Hour)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual[auth.Signer](t, signer, secretKey)
+	})
+
+	t.Run("signs with first key file and verifies with the rest", func(t *testing.T) {
+		currentKey, _ := auth.GenerateRSASigningKey()
+		previousKey, _ := auth.GenerateEd25519SigningKey()
+
+		currentKeyFile := writeSigningKey(t, currentKey)
+		previousKeyFile := writeSigningKey(t, previousKey)
+
+		signer, err := auth.NewSigner(secretKey, []string{currentKeyFile, previousKeyFile}, time.Hour)
+		testutil.AssertNoErr(t, err)
+
+		previousJWT, _ := auth.GenerateJWT(auth.NewKeyRing(previousKey, time.Hour), time.Minute, 10, auth.COURIER)
+		_, err = auth.VerifyJWT(previousJWT, signer, auth.COURIER)
+		testutil.AssertNoErr(t, err)
+
+		currentJWT, _ := auth.GenerateJWT(signer, time.Minute, 10, auth.COURIER)
+		token, err := auth.VerifyJWT(currentJWT, signer, auth.COURIER)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, token.Header["kid"], interface{}(currentKey.ID))
+	})
+
+	t.Run("returns ErrInvalidSigningKey on invalid key file", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "key.pem")
+		os.WriteFile(keyFile, []byte("not a key"), 0600)
+
+		_, err := auth.NewSigner(secretKey, []string{keyFile}, time.Hour)
+		testutil.AssertError(t, err, auth.ErrInvalidSigningKey)
+	})
+}
+
+func TestJWKSHandler(t *testing.T) {
+	t.Run("returns public keys of key ring", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(key, time.Hour)
+
+		request, _ := http.NewRequest(http.MethodGet, auth.JWKSPath, nil)
+		response := httptest.NewRecorder()
+
+		auth.JWKSHandler(keyRing)(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		var got auth.JWKS
+		json.NewDecoder(response.Body).Decode(&got)
+
+		testutil.AssertEqual(t, got, keyRing.JWKS())
+	})
+
+	t.Run("returns no keys for shared secret", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, auth.JWKSPath, nil)
+		response := httptest.NewRecorder()
+
+		auth.JWKSHandler(secretKey)(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		var got auth.JWKS
+		json.NewDecoder(response.Body).Decode(&got)
+
+		testutil.AssertEqual(t, len(got.Keys), 0)
+	})
+}
+
+func TestJWKSVerifier(t *testing.T) {
+	t.Run("verifies JWTs across rotated keys", func(t *testing.T) {
+		oldKey, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(oldKey, time.Hour)
+		server := httptest.NewServer(auth.JWKSHandler(keyRing))
+		defer server.Close()
+
+		verifier := auth.NewJWKSVerifier(auth.JWKSVerifierConfig{
+			URL:      server.URL,
+			CacheTTL: time.Hour,
+		})
+
+		oldJWT, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+		_, err := auth.VerifyJWT(oldJWT, verifier, auth.RESTAURANT)
+		testutil.AssertNoErr(t, err)
+
+		newKey, _ := auth.GenerateRSASigningKey()
+		keyRing.Rotate(newKey)
+
+		newJWT, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+		_, err = auth.VerifyJWT(newJWT, verifier, auth.RESTAURANT)
+		testutil.AssertNoErr(t, err)
+
+		_, err = auth.VerifyJWT(oldJWT, verifier, auth.RESTAURANT)
+		testutil.AssertNoErr(t, err)
+	})
+
+	t.Run("caches keys", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(key, time.Hour)
+		server, fetches := newCountingJWKSServer(keyRing)
+		defer server.Close()
+
+		verifier := auth.NewJWKSVerifier(auth.JWKSVerifierConfig{
+			URL:      server.URL,
+			CacheTTL: time.Hour,
+		})
+
+		jwtString, _ := auth.GenerateJWT(keyRing, time.Minute, 10, auth.RESTAURANT)
+		for i := 0; i < 3; i++ {
+			_, err := auth.VerifyJWT(jwtString, verifier, auth.RESTAURANT)
+			testutil.AssertNoErr(t, err)
+		}
+
+		testutil.AssertEqual(t, fetches.Load(), int32(1))
+	})
+
+	t.Run("doesn't fetch keys more often than the minimum refresh interval", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		keyRing := auth.NewKeyRing(key, time.Hour)
+		server, fetches := newCountingJWKSServer(keyRing)
+		defer server.Close()
+
+		verifier := auth.NewJWKSVerifier(auth.JWKSVerifierConfig{
+			URL:                server.URL,
+			CacheTTL:           time.Hour,
+			MinRefreshInterval: time.Hour,
+		})
+
+		unknownKey, _ := auth.GenerateEd25519SigningKey()
+		unknownJWT, _ := auth.GenerateJWT(auth.NewKeyRing(unknownKey, time.Hour), time.Minute, 10, auth.RESTAURANT)
+
+		for i := 0; i < 3; i++ {
+			_, err := auth.VerifyJWT(unknownJWT, verifier, auth.RESTAURANT)
+			testutil.AssertError(t, err, auth.ErrUnknownKeyID)
+		}
+
+		testutil.AssertEqual(t, fetches.Load(), int32(1))
+	})
+
+	t.Run("returns error on JWT signed with shared secret", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		server := httptest.NewServer(auth.JWKSHandler(auth.NewKeyRing(key, time.Hour)))
+		defer server.Close()
+
+		verifier := auth.NewJWKSVerifier(auth.JWKSVerifierConfig{URL: server.URL, CacheTTL: time.Hour})
+
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.RESTAURANT)
+
+		_, err := auth.VerifyJWT(jwtString, verifier, auth.RESTAURANT)
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+
+	t.Run("returns ErrMissingKeyID on JWT without kid", func(t *testing.T) {
+		key, _ := auth.GenerateEd25519SigningKey()
+		server := httptest.NewServer(auth.JWKSHandler(auth.NewKeyRing(key, time.Hour)))
+		defer server.Close()
+
+		verifier := auth.NewJWKSVerifier(auth.JWKSVerifierConfig{URL: server.URL, CacheTTL: time.Hour})
+
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, auth.Claims{
+			Role: auth.RESTAURANT,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    auth.Issuer(auth.RESTAURANT),
+				Audience:  jwt.ClaimStrings{auth.Audience},
+				Subject:   "10",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		})
+		jwtString, _ := token.SignedString(key.Key)
+
+		_, err := auth.VerifyJWT(jwtString, verifier, auth.RESTAURANT)
+		testutil.AssertError(t, err, auth.ErrMissingKeyID)
+	})
+}
+
+func newCountingJWKSServer(keys auth.KeySet) (*httptest.Server, *atomic.Int32) {
+	fetches := &atomic.Int32{}
+	handler := auth.JWKSHandler(keys)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		handler(w, r)
+	}))
+
+	return server, fetches
+}
+
+func writeSigningKey(t testing.TB, key auth.SigningKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key.Key)
+	testutil.AssertNoErr(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), key.ID+".pem")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	testutil.AssertNoErr(t, err)
+
+	return keyFile
+}
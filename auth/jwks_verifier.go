@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type JWKSVerifierConfig struct {
+	URL string
+
+	// CacheTTL is how long fetched keys are used before they are fetched
+	// again.
+	CacheTTL time.Duration
+	// MinRefreshInterval limits how often tokens with an unknown key ID
+	// can trigger a fetch, so they can't be used to flood the issuer.
+	MinRefreshInterval time.Duration
+
+	Client *http.Client
+}
+
+var DefaultJWKSVerifierConfig = JWKSVerifierConfig{
+	CacheTTL:           time.Hour,
+	MinRefreshInterval: 10 * time.Second,
+	Client:             &http.Client{Timeout: 5 * time.Second},
+}
+
+type jwksKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// JWKSVerifier verifies tokens with the public keys an issuing service
+// publishes on its JWKS endpoint. Keys are cached and fetched again when
+// the cache expires or a token is signed with a key that isn't cached,
+// which is how keys rotated in by the issuer are picked up.
+type JWKSVerifier struct {
+	config JWKSVerifierConfig
+
+	mu        sync.Mutex
+	keys      map[string]jwksKey
+	fetchedAt time.Time
+}
+
+func NewJWKSVerifier(config JWKSVerifierConfig) *JWKSVerifier {
+	if config.Client == nil {
+		config.Client = DefaultJWKSVerifierConfig.Client
+	}
+
+	return &JWKSVerifier{
+		config: config,
+		keys:   make(map[string]jwksKey),
+	}
+}
+
+func (j *JWKSVerifier) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, ErrMissingKeyID
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key, ok := j.keys[kid]
+	if !ok || time.Since(j.fetchedAt) > j.config.CacheTTL {
+		if time.Since(j.fetchedAt) > j.config.MinRefreshInterval {
+			err := j.refresh()
+			if err != nil && len(j.keys) == 0 {
+				return nil, err
+			}
+		}
+
+		key, ok = j.keys[kid]
+	}
+
+	if !ok || key.alg != token.Method.Alg() {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key.key, nil
+}
+
+func (j *JWKSVerifier) ValidMethods() []string {
+	return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+}
+
+func (j *JWKSVerifier) Refresh() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.refresh()
+}
+
+func (j *JWKSVerifier) refresh() error {
+	// A failed fetch also counts towards the refresh interval, so an
+	// unavailable issuer isn't retried on every request.
+	j.fetchedAt = time.Now()
+
+	response, err := j.config.Client.Get(j.config.URL)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching JWKS: unexpected status %d", response.StatusCode)
+	}
+
+	var jwks JWKS
+	err = json.NewDecoder(response.Body).Decode(&jwks)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]jwksKey)
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = jwksKey{alg: jwk.Alg, key: key}
+	}
+
+	j.keys = keys
+	return nil
+}
+
+// NewKeySet verifies tokens with the keys published at jwksURL when it is
+// set and falls back to the shared secret otherwise.
+func NewKeySet(secretKey []byte, jwksURL string) KeySet {
+	if jwksURL == "" {
+		return HMACKey(secretKey)
+	}
+
+	config := DefaultJWKSVerifierConfig
+	config.URL = jwksURL
+
+	return NewJWKSVerifier(config)
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Key    crypto.Signer
+}
+
+func NewSigningKey(key crypto.Signer) (SigningKey, error) {
+	var method jwt.SigningMethod
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return SigningKey{}, ErrUnsupportedKey
+	}
+
+	id, err := Thumbprint(key.Public())
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{ID: id, Method: method, Key: key}, nil
+}
+
+func GenerateRSASigningKey() (SigningKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return NewSigningKey(key)
+}
+
+func GenerateEd25519SigningKey() (SigningKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	return NewSigningKey(key)
+}
+
+// ParseSigningKey parses a PEM encoded PKCS #8 RSA or Ed25519 private key,
+// or a PKCS #1 RSA private key.
+func ParseSigningKey(pemBytes []byte) (SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return SigningKey{}, ErrInvalidSigningKey
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return SigningKey{}, ErrInvalidSigningKey
+		}
+
+		return NewSigningKey(key)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return SigningKey{}, ErrInvalidSigningKey
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return SigningKey{}, ErrUnsupportedKey
+	}
+
+	return NewSigningKey(signer)
+}
+
+type verificationKey struct {
+	method    jwt.SigningMethod
+	key       crypto.PublicKey
+	retiredAt time.Time
+}
+
+// KeyRing signs tokens with its current key and keeps verifying tokens
+// signed with previous keys until they have expired.
+type KeyRing struct {
+	mu        sync.RWMutex
+	current   SigningKey
+	keys      map[string]verificationKey
+	retention time.Duration
+}
+
+// NewKeyRing creates a KeyRing signing with key. Retention is how long a
+// rotated out key is still accepted and should be at least the lifetime
+// of the tokens signed with it.
+func NewKeyRing(key SigningKey, retention time.Duration) *KeyRing {
+	k := &KeyRing{
+		keys:      make(map[string]verificationKey),
+		retention: retention,
+	}
+	k.setCurrent(key)
+
+	return k
+}
+
+func (k *KeyRing) Rotate(key SigningKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if key.ID == k.current.ID {
+		return
+	}
+
+	retired := k.keys[k.current.ID]
+	retired.retiredAt = time.Now()
+	k.keys[k.current.ID] = retired
+
+	k.setCurrent(key)
+}
+
+func (k *KeyRing) setCurrent(key SigningKey) {
+	k.current = key
+	k.keys[key.ID] = verificationKey{
+		method: key.Method,
+		key:    key.Key.Public(),
+	}
+}
+
+func (k *KeyRing) Sign(claims Claims) (string, error) {
+	k.mu.RLock()
+	key := k.current
+	k.mu.RUnlock()
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.Key)
+}
+
+func (k *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, ErrMissingKeyID
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[kid]
+	if !ok || k.isExpired(key) {
+		return nil, ErrUnknownKeyID
+	}
+
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key.key, nil
+}
+
+func (k *KeyRing) ValidMethods() []string {
+	return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+}
+
+func (k *KeyRing) JWKS() JWKS {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks := JWKS{Keys: []JWK{}}
+	for kid, key := range k.keys {
+		if k.isExpired(key) {
+			continue
+		}
+
+		jwk, err := NewJWK(kid, key.key)
+		if err != nil {
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func (k *KeyRing) isExpired(key verificationKey) bool {
+	return !key.retiredAt.IsZero() && time.Since(key.retiredAt) > k.retention
+}
+
+// NewSigner signs with the keys in keyFiles when they are set and falls
+// back to the shared secret otherwise. The first key file is the current
+// key, the rest are previous keys that are still published so tokens
+// signed with them stay valid for retention.
+func NewSigner(secretKey []byte, keyFiles []string, retention time.Duration) (Signer, error) {
+	keys := []SigningKey{}
+	for _, keyFile := range keyFiles {
+		if keyFile == "" {
+			continue
+		}
+
+		pemBytes, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParseSigningKey(pemBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return HMACKey(secretKey), nil
+	}
+
+	keyRing := NewKeyRing(keys[len(keys)-1], retention)
+	for i := len(keys) - 2; i >= 0; i-- {
+		keyRing.Rotate(keys[i])
+	}
+
+	return keyRing, nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySet resolves the key a token was signed with. Services that only
+// verify tokens use a KeySet, so they don't need to hold a signing key.
+type KeySet interface {
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	ValidMethods() []string
+}
+
+type Signer interface {
+	KeySet
+	Sign(claims Claims) (string, error)
+}
+
+// HMACKey signs and verifies tokens with a secret shared between every
+// service that handles them.
+type HMACKey []byte
+
+func (h HMACKey) Sign(claims Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(h))
+}
+
+func (h HMACKey) Keyfunc(token *jwt.Token) (interface{}, error) {
+	return []byte(h), nil
+}
+
+func (h HMACKey) ValidMethods() []string {
+	return []string{jwt.SigningMethodHS256.Name}
+}
@@ -12,16 +12,24 @@ type Verifier interface {
 	DoesSubjectExist(id int) (bool, error)
 }
 
+// Verifiers of services that issue tokens know which of them were revoked.
+// Services that only verify tokens issued elsewhere ask the issuer, see
+// IsRemoteJWTRevoked.
+type RevocationVerifier interface {
+	IsJWTRevoked(token *jwt.Token) (bool, error)
+}
+
 func AuthenticationMW(endpointHandler func(w http.ResponseWriter, r *http.Request),
 	verifier Verifier,
-	secretKey []byte) http.HandlerFunc {
+	keys KeySet,
+	role Role) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Header["Token"] == nil {
 			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrMissingToken)
 			return
 		}
 
-		token, err := VerifyJWT(r.Header["Token"][0], secretKey)
+		token, err := VerifyJWT(r.Header["Token"][0], keys, role)
 		if err != nil {
 			httperrors.WriteJSONError(w, http.StatusUnauthorized, err)
 			return
@@ -33,6 +41,19 @@ func AuthenticationMW(endpointHandler func(w http.ResponseWriter, r *http.Reques
 			return
 		}
 
+		if revocationVerifier, ok := verifier.(RevocationVerifier); ok {
+			revoked, err := revocationVerifier.IsJWTRevoked(token)
+			if err != nil {
+				httperrors.WriteJSONError(w, http.StatusUnauthorized, err)
+				return
+			}
+
+			if revoked {
+				httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrRevokedToken)
+				return
+			}
+		}
+
 		exists, err := verifier.DoesSubjectExist(id)
 		if err != nil {
 			httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
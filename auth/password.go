@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var PasswordCost = bcrypt.DefaultCost
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// VerifyPassword checks the password against the stored hash and reports
+// whether the hash should be replaced with a fresh one. Passwords stored
+// before hashing was introduced are kept in plaintext, so anything that
+// isn't a bcrypt hash is compared as a legacy plaintext password.
+func VerifyPassword(hash, password string) (bool, error) {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(password)) != 1 {
+			return false, ErrInvalidCredentials
+		}
+
+		return true, nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, ErrInvalidCredentials
+	} else if err != nil {
+		return false, err
+	}
+
+	return cost < PasswordCost, nil
+}
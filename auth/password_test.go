@@ -0,0 +1,54 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/testutil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordVerification(t *testing.T) {
+	t.Run("verifies hashed password", func(t *testing.T) {
+		hash, err := auth.HashPassword("secretpass123")
+		testutil.AssertNoErr(t, err)
+
+		if hash == "secretpass123" {
+			t.Fatalf("password wasn't hashed")
+		}
+
+		needsRehash, err := auth.VerifyPassword(hash, "secretpass123")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, needsRehash, false)
+	})
+
+	t.Run("returns ErrInvalidCredentials on wrong password", func(t *testing.T) {
+		hash, _ := auth.HashPassword("secretpass123")
+
+		_, err := auth.VerifyPassword(hash, "secretpass321")
+
+		testutil.AssertError(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("verifies legacy plaintext password and asks for rehash", func(t *testing.T) {
+		needsRehash, err := auth.VerifyPassword("secretpass123", "secretpass123")
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, needsRehash, true)
+	})
+
+	t.Run("returns ErrInvalidCredentials on wrong legacy plaintext password", func(t *testing.T) {
+		_, err := auth.VerifyPassword("secretpass123", "secretpass321")
+
+		testutil.AssertError(t, err, auth.ErrInvalidCredentials)
+	})
+
+	t.Run("asks for rehash of hash with outdated cost", func(t *testing.T) {
+		hash, _ := bcrypt.GenerateFromPassword([]byte("secretpass123"), bcrypt.MinCost)
+
+		needsRehash, err := auth.VerifyPassword(string(hash), "secretpass123")
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, needsRehash, true)
+	})
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgTokenStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgTokenStore(ctx context.Context, connString string) (*PgTokenStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgTokenStore{conn}, nil
+}
+
+func (p *PgTokenStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgTokenStore) WithTx(tx pgx.Tx) TokenStore {
+	return &PgTokenStore{tx}
+}
+
+func (p *PgTokenStore) CreateRefreshToken(refreshToken RefreshToken) error {
+	query := `insert into refresh_tokens(hash, subject, expires_at) values (@hash, @subject, @expires_at)`
+	args := pgx.NamedArgs{
+		"hash":       refreshToken.Hash,
+		"subject":    refreshToken.Subject,
+		"expires_at": refreshToken.ExpiresAt,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgTokenStore) TakeRefreshToken(hash string) (RefreshToken, error) {
+	query := `delete from refresh_tokens where hash=@hash returning hash, subject, expires_at`
+	args := pgx.NamedArgs{
+		"hash": hash,
+	}
+
+	var refreshToken RefreshToken
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&refreshToken.Hash, &refreshToken.Subject, &refreshToken.ExpiresAt)
+	if err != nil {
+		return RefreshToken{}, storeerrors.FromPgxError(err)
+	}
+
+	return refreshToken, nil
+}
+
+func (p *PgTokenStore) DeleteRefreshTokensBySubject(subject int) error {
+	query := `delete from refresh_tokens where subject=@subject`
+	args := pgx.NamedArgs{
+		"subject": subject,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+// Like the in-memory store, expired revocations are dropped on every
+// revocation, since expired tokens are rejected anyway.
+func (p *PgTokenStore) RevokeToken(id string, expiresAt time.Time) error {
+	_, err := p.conn.Exec(context.Background(), `delete from revoked_tokens where expires_at < now()`)
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	query := `insert into revoked_tokens(id, expires_at) values (@id, @expires_at) on conflict do nothing`
+	args := pgx.NamedArgs{
+		"id":         id,
+		"expires_at": expiresAt,
+	}
+
+	_, err = p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgTokenStore) IsTokenRevoked(id string) (bool, error) {
+	query := `select exists(select 1 from revoked_tokens where id=@id)`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	var revoked bool
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&revoked)
+
+	return revoked, storeerrors.FromPgxError(err)
+}
+
+func (p *PgTokenStore) RevokeSubject(subject int, revokedBefore time.Time) error {
+	query := `insert into revoked_subjects(subject, revoked_before) values (@subject, @revoked_before)
+		on conflict (subject) do update set revoked_before=greatest(revoked_subjects.revoked_before, excluded.revoked_before)`
+	args := pgx.NamedArgs{
+		"subject":        subject,
+		"revoked_before": revokedBefore,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgTokenStore) GetSubjectRevokedBefore(subject int) (time.Time, error) {
+	query := `select revoked_before from revoked_subjects where subject=@subject`
+	args := pgx.NamedArgs{
+		"subject": subject,
+	}
+
+	var revokedBefore time.Time
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&revokedBefore)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+
+	return revokedBefore, storeerrors.FromPgxError(err)
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var RefreshTokenExpiresAt = 30 * 24 * time.Hour
+
+func hashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func GenerateRefreshToken(tokenStore TokenStore, subject int) (string, error) {
+	buf := make([]byte, 32)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	err = tokenStore.CreateRefreshToken(RefreshToken{
+		Hash:      hashRefreshToken(refreshToken),
+		Subject:   subject,
+		ExpiresAt: time.Now().Add(RefreshTokenExpiresAt),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
+
+// RotateRefreshToken consumes the refresh token and issues a new one for the
+// same subject, so every refresh token can be used only once.
+func RotateRefreshToken(tokenStore TokenStore, refreshToken string) (int, string, error) {
+	hash := hashRefreshToken(refreshToken)
+
+	storedToken, err := tokenStore.TakeRefreshToken(hash)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		return 0, "", ErrInvalidRefreshToken
+	} else if err != nil {
+		return 0, "", err
+	}
+
+	if storedToken.ExpiresAt.Before(time.Now()) {
+		return 0, "", ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := GenerateRefreshToken(tokenStore, storedToken.Subject)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return storedToken.Subject, newRefreshToken, nil
+}
+
+// RevokeJWT logs the token's subject out of all of its sessions. The token
+// itself is revoked by its ID, the subject's other access tokens by the time
+// they were issued at and its refresh tokens are deleted.
+//
+// Issue times only have a precision of a second, so the subject is revoked
+// from the start of the current second. Otherwise a token issued right after
+// logging out would be revoked too.
+func RevokeJWT(tokenStore TokenStore, token *jwt.Token) error {
+	id, err := getTokenID(token)
+	if err != nil {
+		return err
+	}
+
+	subject, err := getIDFromToken(token)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, err := token.Claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return ErrInvalidToken
+	}
+
+	err = tokenStore.RevokeToken(id, expiresAt.Time)
+	if err != nil {
+		return err
+	}
+
+	err = tokenStore.RevokeSubject(subject, time.Now().Truncate(time.Second))
+	if err != nil {
+		return err
+	}
+
+	return tokenStore.DeleteRefreshTokensBySubject(subject)
+}
+
+func IsJWTRevoked(tokenStore TokenStore, token *jwt.Token) (bool, error) {
+	id, err := getTokenID(token)
+	if err != nil {
+		return false, err
+	}
+
+	revoked, err := tokenStore.IsTokenRevoked(id)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	subject, err := getIDFromToken(token)
+	if err != nil {
+		return false, err
+	}
+
+	revokedBefore, err := tokenStore.GetSubjectRevokedBefore(subject)
+	if err != nil || revokedBefore.IsZero() {
+		return false, err
+	}
+
+	issuedAt, err := token.Claims.GetIssuedAt()
+	if err != nil {
+		return false, err
+	}
+
+	// Tokens issued before they carried their issue time are older than any
+	// revocation.
+	if issuedAt == nil {
+		return true, nil
+	}
+
+	return issuedAt.Before(revokedBefore), nil
+}
@@ -0,0 +1,322 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/msgtypes"
+	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type RevocationDummyVerifier struct {
+	DummyVerifier
+	tokenStore auth.TokenStore
+}
+
+func (r *RevocationDummyVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsJWTRevoked(r.tokenStore, token)
+}
+
+func TestRefreshTokens(t *testing.T) {
+	t.Run("rotates refresh token", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		refreshToken, err := auth.GenerateRefreshToken(tokenStore, 10)
+		testutil.AssertNoErr(t, err)
+
+		subject, newRefreshToken, err := auth.RotateRefreshToken(tokenStore, refreshToken)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, subject, 10)
+
+		if newRefreshToken == refreshToken {
+			t.Errorf("expected new refresh token, got the old one")
+		}
+	})
+
+	t.Run("returns ErrInvalidRefreshToken on reused refresh token", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		refreshToken, _ := auth.GenerateRefreshToken(tokenStore, 10)
+		auth.RotateRefreshToken(tokenStore, refreshToken)
+
+		_, _, err := auth.RotateRefreshToken(tokenStore, refreshToken)
+		testutil.AssertError(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("rotates refresh token only once on concurrent rotations", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		refreshToken, _ := auth.GenerateRefreshToken(tokenStore, 10)
+
+		var wg sync.WaitGroup
+		var rotations atomic.Int32
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, _, err := auth.RotateRefreshToken(tokenStore, refreshToken)
+				if err == nil {
+					rotations.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		testutil.AssertEqual(t, rotations.Load(), int32(1))
+	})
+
+	t.Run("returns ErrInvalidRefreshToken on unknown refresh token", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		_, _, err := auth.RotateRefreshToken(tokenStore, "unknownRefreshToken")
+		testutil.AssertError(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns ErrInvalidRefreshToken on expired refresh token", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		refreshTokenExpiresAt := auth.RefreshTokenExpiresAt
+		auth.RefreshTokenExpiresAt = -time.Second
+		refreshToken, _ := auth.GenerateRefreshToken(tokenStore, 10)
+		auth.RefreshTokenExpiresAt = refreshTokenExpiresAt
+
+		_, _, err := auth.RotateRefreshToken(tokenStore, refreshToken)
+		testutil.AssertError(t, err, auth.ErrInvalidRefreshToken)
+	})
+}
+
+func TestRevokeJWT(t *testing.T) {
+	t.Run("revokes JWT and its subject's refresh tokens", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		token, _ := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+		refreshToken, _ := auth.GenerateRefreshToken(tokenStore, 10)
+
+		err := auth.RevokeJWT(tokenStore, token)
+		testutil.AssertNoErr(t, err)
+
+		revoked, err := auth.IsJWTRevoked(tokenStore, token)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, true)
+
+		_, _, err = auth.RotateRefreshToken(tokenStore, refreshToken)
+		testutil.AssertError(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("revokes JWTs of the same subject issued before logout", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		token, _ := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+		otherToken := newCustomerJWTIssuedAt(t, 10, time.Now().Add(-time.Hour))
+
+		auth.RevokeJWT(tokenStore, token)
+
+		revoked, err := auth.IsJWTRevoked(tokenStore, otherToken)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, true)
+	})
+
+	t.Run("doesn't revoke JWTs issued after logout or of other subjects", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		token, _ := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+
+		auth.RevokeJWT(tokenStore, token)
+
+		newJWTString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		newToken, _ := auth.VerifyJWT(newJWTString, secretKey, auth.CUSTOMER)
+		otherSubjectToken := newCustomerJWTIssuedAt(t, 11, time.Now().Add(-time.Hour))
+
+		revoked, err := auth.IsJWTRevoked(tokenStore, newToken)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, false)
+
+		revoked, err = auth.IsJWTRevoked(tokenStore, otherSubjectToken)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, false)
+	})
+
+	t.Run("returns ErrMissingTokenID on JWT without ID", func(t *testing.T) {
+		tokenStore := auth.NewInMemoryTokenStore()
+
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			Subject:   "10",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		})
+
+		_, err := auth.IsJWTRevoked(tokenStore, token)
+		testutil.AssertError(t, err, auth.ErrMissingTokenID)
+	})
+}
+
+func newCustomerJWTIssuedAt(t testing.TB, subject int, issuedAt time.Time) *jwt.Token {
+	t.Helper()
+
+	jwtString, err := secretKey.Sign(auth.Claims{
+		Role: auth.CUSTOMER,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    auth.Issuer(auth.CUSTOMER),
+			Audience:  jwt.ClaimStrings{auth.Audience},
+			Subject:   strconv.Itoa(subject),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+		},
+	})
+	testutil.AssertNoErr(t, err)
+
+	token, err := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+	testutil.AssertNoErr(t, err)
+
+	return token
+}
+
+func TestAuthenticationMWRevocation(t *testing.T) {
+	tokenStore := auth.NewInMemoryTokenStore()
+	verifier := &RevocationDummyVerifier{DummyVerifier{false, false}, tokenStore}
+	handler := auth.AuthenticationMW(DummyHandler, verifier, secretKey, auth.CUSTOMER)
+
+	t.Run("returns Unauthorized on revoked JWT", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		token, _ := auth.VerifyJWT(jwtString, secretKey, auth.CUSTOMER)
+		auth.RevokeJWT(tokenStore, token)
+
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Add("Token", jwtString)
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		assertStatus(t, response.Code, http.StatusUnauthorized)
+		assertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+
+	t.Run("returns Accepted on not revoked JWT", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Add("Token", jwtString)
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		assertStatus(t, response.Code, http.StatusAccepted)
+	})
+}
+
+func TestRemoteAuthenticationMWRevocation(t *testing.T) {
+	verifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+		return msgtypes.AuthResponse{Status: msgtypes.REVOKED, ID: 0}, nil
+	}
+	handler := auth.RemoteAuthenticationMW(DummyHandler, verifyJWT, auth.CUSTOMER)
+
+	t.Run("returns Unauthorized on revoked JWT", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Add("Token", "revokedJWT")
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+}
+
+func TestAuthHandlerRevocation(t *testing.T) {
+	tokenStore := auth.NewInMemoryTokenStore()
+	verifier := &RevocationDummyVerifier{DummyVerifier{false, false}, tokenStore}
+	handler := auth.AuthHandler(verifier, secretKey, auth.COURIER)
+
+	t.Run("returns OK status and subject on valid JWT", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.COURIER)
+
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Add("Token", jwtString)
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		want := msgtypes.AuthResponse{Status: msgtypes.OK, ID: 10, Role: string(auth.COURIER)}
+		got := decodeAuthResponse(t, response)
+		testutil.AssertEqual(t, got, want)
+	})
+
+	t.Run("returns REVOKED status on revoked JWT", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.COURIER)
+		token, _ := auth.VerifyJWT(jwtString, secretKey, auth.COURIER)
+		auth.RevokeJWT(tokenStore, token)
+
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Add("Token", jwtString)
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		got := decodeAuthResponse(t, response)
+		testutil.AssertEqual(t, got.Status, msgtypes.REVOKED)
+	})
+
+	t.Run("returns INVALID status on JWT of another role", func(t *testing.T) {
+		jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Add("Token", jwtString)
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		got := decodeAuthResponse(t, response)
+		testutil.AssertEqual(t, got.Status, msgtypes.INVALID)
+	})
+}
+
+func TestIsRemoteJWTRevoked(t *testing.T) {
+	jwtString, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.COURIER)
+	token, _ := auth.VerifyJWT(jwtString, secretKey, auth.COURIER)
+
+	t.Run("returns true on JWT revoked by its issuer", func(t *testing.T) {
+		verifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+			testutil.AssertEqual(t, jwt, jwtString)
+			return msgtypes.AuthResponse{Status: msgtypes.REVOKED}, nil
+		}
+
+		revoked, err := auth.IsRemoteJWTRevoked(verifyJWT, token)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, true)
+	})
+
+	t.Run("returns false on JWT accepted by its issuer", func(t *testing.T) {
+		verifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+			return msgtypes.AuthResponse{Status: msgtypes.OK, ID: 10, Role: string(auth.COURIER)}, nil
+		}
+
+		revoked, err := auth.IsRemoteJWTRevoked(verifyJWT, token)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, false)
+	})
+}
+
+func decodeAuthResponse(t testing.TB, response *httptest.ResponseRecorder) msgtypes.AuthResponse {
+	t.Helper()
+
+	var authResponse msgtypes.AuthResponse
+	err := json.NewDecoder(response.Body).Decode(&authResponse)
+	if err != nil {
+		t.Fatalf("couldn't decode auth response: %v", err)
+	}
+
+	return authResponse
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/VitoNaychev/food-app/msgtypes"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var errRetryableRequest = errors.New("retryable auth request failure")
+
+type RemoteAuthConfig struct {
+	// URL of the auth endpoint of the service that issued the tokens,
+	// e.g. http://customer-svc:8080/customer/auth/
+	URL string
+
+	Timeout time.Duration
+
+	MaxAttempts  int
+	RetryBackoff time.Duration
+
+	// After FailureThreshold consecutive failed requests the circuit opens
+	// and requests fail fast for OpenTimeout, after which a single request
+	// is let through to probe whether the service has recovered.
+	FailureThreshold int
+	OpenTimeout      time.Duration
+
+	// Successful authentications are cached for CacheTTL, but never past
+	// the expiry of the token. A revoked token can therefore still be
+	// accepted for up to CacheTTL.
+	CacheTTL time.Duration
+}
+
+var DefaultRemoteAuthConfig = RemoteAuthConfig{
+	Timeout:          2 * time.Second,
+	MaxAttempts:      3,
+	RetryBackoff:     100 * time.Millisecond,
+	FailureThreshold: 5,
+	OpenTimeout:      10 * time.Second,
+	CacheTTL:         30 * time.Second,
+}
+
+type cachedAuthResponse struct {
+	response  msgtypes.AuthResponse
+	expiresAt time.Time
+}
+
+type RemoteAuthClient struct {
+	config  RemoteAuthConfig
+	client  *http.Client
+	breaker *circuitBreaker
+
+	mu    sync.Mutex
+	cache map[string]cachedAuthResponse
+}
+
+func NewRemoteAuthClient(config RemoteAuthConfig) *RemoteAuthClient {
+	return &RemoteAuthClient{
+		config:  config,
+		client:  &http.Client{Timeout: config.Timeout},
+		breaker: newCircuitBreaker(config.FailureThreshold, config.OpenTimeout),
+		cache:   make(map[string]cachedAuthResponse),
+	}
+}
+
+// VerifyJWT satisfies VerifyJWTFunc, so it can be passed to
+// RemoteAuthenticationMW.
+func (r *RemoteAuthClient) VerifyJWT(token string) (msgtypes.AuthResponse, error) {
+	key := hashToken(token)
+
+	if response, ok := r.getCached(key); ok {
+		return response, nil
+	}
+
+	if !r.breaker.allow() {
+		return msgtypes.AuthResponse{}, ErrCircuitOpen
+	}
+
+	response, err := r.verifyWithRetries(token)
+	if err != nil {
+		r.breaker.failure()
+		return msgtypes.AuthResponse{}, err
+	}
+	r.breaker.success()
+
+	if response.Status == msgtypes.OK {
+		r.setCached(key, response, tokenExpiresAt(token))
+	}
+
+	return response, nil
+}
+
+func (r *RemoteAuthClient) verifyWithRetries(token string) (msgtypes.AuthResponse, error) {
+	var response msgtypes.AuthResponse
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		response, err = r.verify(token)
+		if !errors.Is(err, errRetryableRequest) || attempt >= r.config.MaxAttempts {
+			return response, err
+		}
+
+		time.Sleep(r.config.RetryBackoff * time.Duration(attempt))
+	}
+}
+
+func (r *RemoteAuthClient) verify(token string) (msgtypes.AuthResponse, error) {
+	var authResponse msgtypes.AuthResponse
+
+	request, err := http.NewRequest(http.MethodPost, r.config.URL, nil)
+	if err != nil {
+		return authResponse, err
+	}
+	request.Header.Add("Token", token)
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return authResponse, fmt.Errorf("%w: %w", errRetryableRequest, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return authResponse, fmt.Errorf("%w: unexpected status %d", errRetryableRequest, response.StatusCode)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&authResponse)
+	return authResponse, err
+}
+
+func (r *RemoteAuthClient) getCached(key string) (msgtypes.AuthResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.cache[key]
+	if !ok {
+		return msgtypes.AuthResponse{}, false
+	}
+
+	if time.Now().After(cached.expiresAt) {
+		delete(r.cache, key)
+		return msgtypes.AuthResponse{}, false
+	}
+
+	return cached.response, true
+}
+
+func (r *RemoteAuthClient) setCached(key string, response msgtypes.AuthResponse, tokenExpiresAt time.Time) {
+	expiresAt := time.Now().Add(r.config.CacheTTL)
+	if !tokenExpiresAt.IsZero() && tokenExpiresAt.Before(expiresAt) {
+		expiresAt = tokenExpiresAt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for cachedKey, cached := range r.cache {
+		if now.After(cached.expiresAt) {
+			delete(r.cache, cachedKey)
+		}
+	}
+
+	r.cache[key] = cachedAuthResponse{response: response, expiresAt: expiresAt}
+}
+
+// Tokens are cached by their hash, so the cache doesn't hold usable
+// tokens.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// The token was already verified by the remote service, parsing it
+// unverified is only used to not cache it past its expiry.
+func tokenExpiresAt(token string) time.Time {
+	parsedToken, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		return time.Time{}
+	}
+
+	expiresAt, err := parsedToken.Claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return time.Time{}
+	}
+
+	return expiresAt.Time
+}
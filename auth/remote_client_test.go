@@ -0,0 +1,209 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/msgtypes"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+type StubAuthService struct {
+	requests  atomic.Int32
+	failures  atomic.Int32
+	delay     time.Duration
+	response  msgtypes.AuthResponse
+	lastToken atomic.Value
+}
+
+func (s *StubAuthService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	s.lastToken.Store(r.Header.Get("Token"))
+
+	time.Sleep(s.delay)
+
+	if s.failures.Load() > 0 {
+		s.failures.Add(-1)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(s.response)
+}
+
+func newRemoteAuthClient(url string) *auth.RemoteAuthClient {
+	config := auth.RemoteAuthConfig{
+		URL:              url,
+		Timeout:          time.Second,
+		MaxAttempts:      1,
+		FailureThreshold: 100,
+		OpenTimeout:      time.Hour,
+		CacheTTL:         time.Hour,
+	}
+
+	return auth.NewRemoteAuthClient(config)
+}
+
+func TestRemoteAuthClient(t *testing.T) {
+	okResponse := msgtypes.AuthResponse{Status: msgtypes.OK, ID: 10, Role: string(auth.CUSTOMER)}
+
+	t.Run("returns response of auth service", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		client := newRemoteAuthClient(server.URL)
+
+		customerJWT, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		got, err := client.VerifyJWT(customerJWT)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, okResponse)
+		testutil.AssertEqual(t, authService.lastToken.Load(), interface{}(customerJWT))
+	})
+
+	t.Run("caches successful authentication", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		client := newRemoteAuthClient(server.URL)
+
+		customerJWT, _ := auth.GenerateJWT(secretKey, time.Minute, 10, auth.CUSTOMER)
+		client.VerifyJWT(customerJWT)
+		got, err := client.VerifyJWT(customerJWT)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, okResponse)
+		testutil.AssertEqual(t, authService.requests.Load(), int32(1))
+	})
+
+	t.Run("doesn't cache failed authentication", func(t *testing.T) {
+		authService := &StubAuthService{response: msgtypes.AuthResponse{Status: msgtypes.INVALID}}
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		client := newRemoteAuthClient(server.URL)
+
+		client.VerifyJWT("invalidJWT")
+		client.VerifyJWT("invalidJWT")
+
+		testutil.AssertEqual(t, authService.requests.Load(), int32(2))
+	})
+
+	t.Run("doesn't cache past token expiry", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		client := newRemoteAuthClient(server.URL)
+
+		// JWT expiry has a resolution of a second.
+		customerJWT, _ := auth.GenerateJWT(secretKey, time.Second, 10, auth.CUSTOMER)
+		client.VerifyJWT(customerJWT)
+		time.Sleep(1100 * time.Millisecond)
+		client.VerifyJWT(customerJWT)
+
+		testutil.AssertEqual(t, authService.requests.Load(), int32(2))
+	})
+
+	t.Run("retries on auth service error", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		authService.failures.Store(2)
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		config := auth.DefaultRemoteAuthConfig
+		config.URL = server.URL
+		config.RetryBackoff = time.Millisecond
+		client := auth.NewRemoteAuthClient(config)
+
+		got, err := client.VerifyJWT("customerJWT")
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, okResponse)
+		testutil.AssertEqual(t, authService.requests.Load(), int32(3))
+	})
+
+	t.Run("returns error on auth service timeout", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse, delay: 100 * time.Millisecond}
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		config := auth.DefaultRemoteAuthConfig
+		config.URL = server.URL
+		config.Timeout = 10 * time.Millisecond
+		config.MaxAttempts = 1
+		client := auth.NewRemoteAuthClient(config)
+
+		_, err := client.VerifyJWT("customerJWT")
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+
+	t.Run("returns ErrCircuitOpen after consecutive failures", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		authService.failures.Store(100)
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		config := auth.DefaultRemoteAuthConfig
+		config.URL = server.URL
+		config.MaxAttempts = 1
+		config.FailureThreshold = 2
+		config.OpenTimeout = time.Hour
+		client := auth.NewRemoteAuthClient(config)
+
+		client.VerifyJWT("customerJWT")
+		client.VerifyJWT("customerJWT")
+
+		_, err := client.VerifyJWT("customerJWT")
+		testutil.AssertError(t, err, auth.ErrCircuitOpen)
+		testutil.AssertEqual(t, authService.requests.Load(), int32(2))
+	})
+
+	t.Run("closes circuit once auth service recovers", func(t *testing.T) {
+		authService := &StubAuthService{response: okResponse}
+		authService.failures.Store(1)
+		server := httptest.NewServer(authService)
+		defer server.Close()
+
+		config := auth.DefaultRemoteAuthConfig
+		config.URL = server.URL
+		config.MaxAttempts = 1
+		config.FailureThreshold = 1
+		config.OpenTimeout = 10 * time.Millisecond
+		client := auth.NewRemoteAuthClient(config)
+
+		client.VerifyJWT("customerJWT")
+		time.Sleep(20 * time.Millisecond)
+
+		got, err := client.VerifyJWT("customerJWT")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, okResponse)
+	})
+}
+
+func TestRemoteAuthenticationMWUnavailable(t *testing.T) {
+	verifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+		return msgtypes.AuthResponse{}, auth.ErrCircuitOpen
+	}
+	handler := auth.RemoteAuthenticationMW(DummyHandler, verifyJWT, auth.CUSTOMER)
+
+	t.Run("returns Service Unavailable on open circuit", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Add("Token", "customerJWT")
+		response := httptest.NewRecorder()
+
+		handler(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusServiceUnavailable)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrCircuitOpen)
+	})
+}
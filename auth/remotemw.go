@@ -1,16 +1,18 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/msgtypes"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type VerifyJWTFunc func(token string) (msgtypes.AuthResponse, error)
 
-func RemoteAuthenticationMW(handler func(w http.ResponseWriter, r *http.Request), verifyJWT VerifyJWTFunc) http.HandlerFunc {
+func RemoteAuthenticationMW(handler func(w http.ResponseWriter, r *http.Request), verifyJWT VerifyJWTFunc, role Role) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if tokenHeader := r.Header.Get("Token"); tokenHeader == "" {
 			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrMissingToken)
@@ -18,7 +20,10 @@ func RemoteAuthenticationMW(handler func(w http.ResponseWriter, r *http.Request)
 		}
 
 		authResponse, err := verifyJWT(r.Header["Token"][0])
-		if err != nil {
+		if errors.Is(err, ErrCircuitOpen) {
+			httperrors.WriteJSONError(w, http.StatusServiceUnavailable, err)
+			return
+		} else if err != nil {
 			httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
 			return
 		}
@@ -33,13 +38,35 @@ func RemoteAuthenticationMW(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if authResponse.Status == msgtypes.REVOKED {
+			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrRevokedToken)
+			return
+		}
+
 		if authResponse.Status == msgtypes.NOT_FOUND {
 			httperrors.WriteJSONError(w, http.StatusNotFound, ErrSubjectNotFound)
 			return
 		}
 
+		if authResponse.Role != string(role) {
+			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrInvalidRole)
+			return
+		}
+
 		r.Header.Add("Subject", strconv.Itoa(authResponse.ID))
 
 		handler(w, r)
 	})
 }
+
+// IsRemoteJWTRevoked asks the service that issued the already verified token
+// whether it was revoked, so services that verify tokens locally can still
+// reject them after a logout.
+func IsRemoteJWTRevoked(verifyJWT VerifyJWTFunc, token *jwt.Token) (bool, error) {
+	authResponse, err := verifyJWT(token.Raw)
+	if err != nil {
+		return false, err
+	}
+
+	return authResponse.Status == msgtypes.REVOKED, nil
+}
@@ -0,0 +1,28 @@
+package auth
+
+import "time"
+
+// Refresh tokens are only stored as hashes, so a leaked store can't be
+// used to mint access tokens.
+type RefreshToken struct {
+	Hash      string
+	Subject   int
+	ExpiresAt time.Time
+}
+
+type TokenStore interface {
+	CreateRefreshToken(RefreshToken) error
+	// TakeRefreshToken deletes the refresh token and returns it, so of two
+	// concurrent rotations only one gets the token.
+	TakeRefreshToken(hash string) (RefreshToken, error)
+	DeleteRefreshTokensBySubject(subject int) error
+
+	RevokeToken(id string, expiresAt time.Time) error
+	IsTokenRevoked(id string) (bool, error)
+
+	// RevokeSubject revokes every token of the subject issued before
+	// revokedBefore. GetSubjectRevokedBefore returns the zero time for a
+	// subject that was never revoked.
+	RevokeSubject(subject int, revokedBefore time.Time) error
+	GetSubjectRevokedBefore(subject int) (time.Time, error)
+}
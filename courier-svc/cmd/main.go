@@ -10,10 +10,13 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/courier-svc/handlers"
 	"github.com/VitoNaychev/food-app/courier-svc/models"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func main() {
@@ -21,6 +24,8 @@ func main() {
 		SecretKey: []byte(os.Getenv("SECRET")),
 		ExpiresAt: 24 * time.Hour,
 
+		SigningKeyFiles: strings.Split(os.Getenv("SIGNING_KEY_FILES"), ","),
+
 		Dbhost: "courier-db",
 		Dbport: "5432",
 		Dbuser: os.Getenv("POSTGRES_USER"),
@@ -30,6 +35,12 @@ func main() {
 		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "courier-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -38,13 +49,41 @@ func main() {
 		log.Fatalf("Courier Store error: %v", err)
 	}
 
-	eventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
 	if err != nil {
 		log.Fatalf("Kafka Event Publisher error: %v\n", err)
 	}
+	kafkaEventPublisher.SetProducer("courier-svc")
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(context.Background())
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
+
+	tokenStore, err := auth.NewPgTokenStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Token Store error: %v\n", err)
+	}
+
+	signer, err := auth.NewSigner(env.SecretKey, env.SigningKeyFiles, env.ExpiresAt)
+	if err != nil {
+		log.Fatalf("Signer error: %v\n", err)
+	}
 
-	server := handlers.NewCourierServer(env.SecretKey, env.ExpiresAt, &courierStore, eventPublisher)
+	server := handlers.NewCourierServer(signer, env.ExpiresAt, &courierStore, eventPublisher, tokenStore)
 
 	fmt.Println("courier service listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", server))
+	log.Fatal(http.ListenAndServe(":8080", tracing.Middleware(metrics.Middleware(server), "courier-svc")))
 }
@@ -7,7 +7,6 @@ import (
 	"strconv"
 
 	"github.com/VitoNaychev/food-app/auth"
-	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/storeerrors"
@@ -32,29 +31,100 @@ func (s *CourierServer) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if courier.Password != loginCourierRequest.Password {
+	needsRehash, err := auth.VerifyPassword(courier.Password, loginCourierRequest.Password)
+	if err != nil {
 		httperrors.HandleUnauthorized(w, ErrInvalidCredentials)
 		return
 	}
 
-	jwtToken, _ := auth.GenerateJWT(s.secretKey, s.expiresAt, courier.ID)
-	jwtResponse := JWTResponse{Token: jwtToken}
+	// A failed rehash doesn't fail the login, the password is rehashed again
+	// on the next successful one.
+	if needsRehash {
+		courier.Password, err = auth.HashPassword(loginCourierRequest.Password)
+		if err == nil {
+			s.store.UpdateCourier(&courier)
+		}
+	}
+
+	jwtResponse, err := s.generateTokens(courier.ID)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
 
 	json.NewEncoder(w).Encode(jwtResponse)
 }
 
-func (s *CourierServer) deleteCourier(w http.ResponseWriter, r *http.Request) {
-	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
+func (s *CourierServer) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	refreshTokenRequest, err := validation.ValidateBody[RefreshTokenRequest](r.Body)
+	if err != nil {
+		httperrors.HandleBadRequest(w, err)
+		return
+	}
+
+	courierID, refreshToken, err := auth.RotateRefreshToken(s.tokenStore, refreshTokenRequest.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		httperrors.HandleUnauthorized(w, err)
+		return
+	} else if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	_, err = s.store.GetCourierByID(courierID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		s.tokenStore.DeleteRefreshTokensBySubject(courierID)
+		httperrors.HandleUnauthorized(w, ErrCourierNotFound)
+		return
+	} else if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	jwtToken, _ := auth.GenerateJWT(s.signer, s.expiresAt, courierID, auth.COURIER)
+
+	json.NewEncoder(w).Encode(JWTResponse{Token: jwtToken, RefreshToken: refreshToken})
+}
 
-	err := s.store.DeleteCourier(courierID)
+func (s *CourierServer) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	// The token was already verified by the authentication middleware.
+	token, _ := auth.VerifyJWT(r.Header.Get("Token"), s.signer, auth.COURIER)
+
+	err := auth.RevokeJWT(s.tokenStore, token)
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
+		return
 	}
+}
 
-	payload := svcevents.CourierDeletedEvent{ID: courierID}
-	event := events.NewEvent(svcevents.COURIER_CREATED_EVENT_ID, courierID, payload)
+func (s *CourierServer) generateTokens(courierID int) (JWTResponse, error) {
+	jwtToken, err := auth.GenerateJWT(s.signer, s.expiresAt, courierID, auth.COURIER)
+	if err != nil {
+		return JWTResponse{}, err
+	}
 
-	err = s.publisher.Publish(svcevents.COURIER_EVENTS_TOPIC, event)
+	refreshToken, err := auth.GenerateRefreshToken(s.tokenStore, courierID)
+	if err != nil {
+		return JWTResponse{}, err
+	}
+
+	return JWTResponse{Token: jwtToken, RefreshToken: refreshToken}, nil
+}
+
+func (s *CourierServer) deleteCourier(w http.ResponseWriter, r *http.Request) {
+	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
+
+	err := s.inTx(r.Context(), func(s *CourierServer) error {
+		err := s.store.DeleteCourier(courierID)
+		if err != nil {
+			return err
+		}
+
+		payload := svcevents.CourierDeletedEvent{ID: courierID}
+		event := svcevents.CourierDeleted.New(courierID, payload).WithTraceContext(r.Context())
+
+		return s.publisher.Publish(svcevents.COURIER_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 	}
@@ -72,9 +142,29 @@ func (s *CourierServer) updateCourier(w http.ResponseWriter, r *http.Request) {
 	newCourier := UpdateCourierRequestToCourier(updateCourierRequest, courierID)
 	newCourier.ID = courierID
 
-	err = s.store.UpdateCourier(&newCourier)
+	newCourier.Password, err = auth.HashPassword(newCourier.Password)
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	err = s.inTx(r.Context(), func(s *CourierServer) error {
+		err := s.store.UpdateCourier(&newCourier)
+		if err != nil {
+			return err
+		}
+
+		payload := svcevents.CourierUpdatedEvent{
+			ID:   newCourier.ID,
+			Name: newCourier.FirstName,
+			IBAN: newCourier.IBAN,
+		}
+		event := svcevents.CourierUpdated.New(newCourier.ID, payload).WithTraceContext(r.Context())
+
+		return s.publisher.Publish(svcevents.COURIER_EVENTS_TOPIC, event)
+	})
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
@@ -107,28 +197,41 @@ func (s *CourierServer) createCourier(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = s.store.CreateCourier(&courier)
+	courier.Password, err = auth.HashPassword(courier.Password)
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
-	jwtToken, _ := auth.GenerateJWT(s.secretKey, s.expiresAt, courier.ID)
+	err = s.inTx(r.Context(), func(s *CourierServer) error {
+		err := s.store.CreateCourier(&courier)
+		if err != nil {
+			return err
+		}
 
-	response := CreateCourierResponse{
-		JWT:     JWTResponse{jwtToken},
-		Courier: CourierToCourierResponse(courier),
-	}
-	json.NewEncoder(w).Encode(response)
+		payload := svcevents.CourierCreatedEvent{
+			ID:   courier.ID,
+			Name: courier.FirstName,
+			IBAN: courier.IBAN,
+		}
+		event := svcevents.CourierCreated.New(courier.ID, payload).WithTraceContext(r.Context())
 
-	payload := svcevents.CourierCreatedEvent{
-		ID:   courier.ID,
-		Name: courier.FirstName,
+		return s.publisher.Publish(svcevents.COURIER_EVENTS_TOPIC, event)
+	})
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
 	}
-	event := events.NewEvent(svcevents.COURIER_CREATED_EVENT_ID, courier.ID, payload)
 
-	err = s.publisher.Publish(svcevents.COURIER_EVENTS_TOPIC, event)
+	jwtResponse, err := s.generateTokens(courier.ID)
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	response := CreateCourierResponse{
+		JWT:     jwtResponse,
+		Courier: CourierToCourierResponse(courier),
 	}
+	json.NewEncoder(w).Encode(response)
 }
@@ -50,3 +50,25 @@ func NewGetCourierRequest(jwt string) *http.Request {
 
 	return request
 }
+
+func NewRefreshCourierRequest(refreshToken string) *http.Request {
+	requestBody := RefreshTokenRequest{RefreshToken: refreshToken}
+	request := reqbuilder.NewRequestWithBody[RefreshTokenRequest](
+		http.MethodPost, "/courier/login/refresh/", requestBody)
+
+	return request
+}
+
+func NewLogoutCourierRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/courier/logout/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
+
+func NewAuthCourierRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/courier/auth/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
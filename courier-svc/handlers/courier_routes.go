@@ -1,39 +1,49 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/courier-svc/models"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
 )
 
 type CourierServer struct {
-	secretKey []byte
-	expiresAt time.Duration
-	store     models.CourierStore
-	publisher events.EventPublisher
+	signer     auth.Signer
+	expiresAt  time.Duration
+	store      models.CourierStore
+	publisher  events.EventPublisher
+	tokenStore auth.TokenStore
 
 	verifier auth.Verifier
-	http.Handler
+	*http.ServeMux
 }
 
-func NewCourierServer(secretKey []byte, expiresAt time.Duration, store models.CourierStore, publisher events.EventPublisher) *CourierServer {
+func NewCourierServer(signer auth.Signer, expiresAt time.Duration, store models.CourierStore, publisher events.EventPublisher,
+	tokenStore auth.TokenStore) *CourierServer {
 	s := CourierServer{
-		secretKey: secretKey,
-		expiresAt: expiresAt,
-		store:     store,
-		publisher: publisher,
+		signer:     signer,
+		expiresAt:  expiresAt,
+		store:      store,
+		publisher:  publisher,
+		tokenStore: tokenStore,
 
-		verifier: NewCourierVerifier(store),
+		verifier: NewCourierVerifier(store, tokenStore),
 	}
 
 	router := http.NewServeMux()
 	router.HandleFunc("/courier/", s.CourierHandler)
 	router.HandleFunc("/courier/login/", s.LoginHandler)
+	router.HandleFunc("/courier/login/refresh/", s.RefreshHandler)
+	router.HandleFunc("/courier/logout/", auth.AuthenticationMW(s.LogoutHandler, s.verifier, s.signer, auth.COURIER))
+	router.HandleFunc("/courier/auth/", auth.AuthHandler(s.verifier, s.signer, auth.COURIER))
+	router.HandleFunc(auth.JWKSPath, auth.JWKSHandler(s.signer))
 
-	s.Handler = router
+	s.ServeMux = router
 
 	return &s
 }
@@ -43,10 +53,23 @@ func (s *CourierServer) CourierHandler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		s.createCourier(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(s.getCourier, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.getCourier, s.verifier, s.signer, auth.COURIER)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(s.updateCourier, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.updateCourier, s.verifier, s.signer, auth.COURIER)(w, r)
 	case http.MethodDelete:
-		auth.AuthenticationMW(s.deleteCourier, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.deleteCourier, s.verifier, s.signer, auth.COURIER)(w, r)
 	}
 }
+
+// inTx runs fn with a copy of the server whose store and publisher write
+// through a single transaction, so a courier and its events are committed
+// together.
+func (s *CourierServer) inTx(ctx context.Context, fn func(s *CourierServer) error) error {
+	return pgconfig.RunInTx(ctx, s.store, func(tx pgx.Tx) error {
+		txServer := *s
+		txServer.store = pgconfig.WithTx(s.store, tx)
+		txServer.publisher = pgconfig.WithTx(s.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/VitoNaychev/food-app/auth"
@@ -12,6 +13,7 @@ import (
 	"github.com/VitoNaychev/food-app/courier-svc/testdata"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/VitoNaychev/food-app/testutil"
 	"github.com/VitoNaychev/food-app/testutil/tabletests"
@@ -79,9 +81,9 @@ func TestCourierRequestValidation(t *testing.T) {
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
-	jimJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.JimCourier.ID)
+	jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
 	cases := map[string]*http.Request{
 		"login courier":  handlers.NewLoginCourierRequest(models.Courier{}),
 		"create courier": handlers.NewCreateCourierRequest(models.Courier{}),
@@ -97,9 +99,9 @@ func TestCourierResponseValidity(t *testing.T) {
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
-	jimJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.JimCourier.ID)
+	jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
 	cases := []tabletests.ResponseValidationTestcase{
 		{
 			Name:    "get courier",
@@ -131,7 +133,7 @@ func TestCourierResponseValidity(t *testing.T) {
 }
 
 func TestCourierEnpointAuthentication(t *testing.T) {
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, nil, nil)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, nil, nil, auth.NewInMemoryTokenStore())
 
 	invalidJWT := "invalidJWT"
 	cases := map[string]*http.Request{
@@ -149,7 +151,7 @@ func TestLoginCourier(t *testing.T) {
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("returns JWT on correct credentials", func(t *testing.T) {
 		request := handlers.NewLoginCourierRequest(testdata.MichaelCourier)
@@ -162,7 +164,42 @@ func TestLoginCourier(t *testing.T) {
 		jwtResponse, err := validation.ValidateBody[handlers.JWTResponse](response.Body)
 		testutil.AssertValidResponse(t, err)
 
-		testutil.AssertJWT(t, jwtResponse.Token, testEnv.SecretKey, testdata.MichaelCourier.ID)
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), testdata.MichaelCourier.ID, auth.COURIER)
+	})
+
+	t.Run("rehashes legacy plaintext password on login", func(t *testing.T) {
+		store := &StubCourierStore{
+			couriers: []models.Courier{testdata.MichaelCourier},
+		}
+		server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginCourierRequest(testdata.MichaelCourier)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		assertHashedPassword(t, &store.updatedCourier, testdata.MichaelCourier.Password)
+		testutil.AssertEqual(t, store.updatedCourier, testdata.MichaelCourier)
+	})
+
+	t.Run("returns JWT on hashed password", func(t *testing.T) {
+		hashedCourier := testdata.MichaelCourier
+		hashedCourier.Password, _ = auth.HashPassword(testdata.MichaelCourier.Password)
+
+		store := &StubCourierStore{
+			couriers: []models.Courier{hashedCourier},
+		}
+		server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginCourierRequest(testdata.MichaelCourier)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, store.updatedCourier, models.Courier{})
 	})
 
 	t.Run("returns Unauthorized on incorrect email", func(t *testing.T) {
@@ -192,16 +229,137 @@ func TestLoginCourier(t *testing.T) {
 	})
 }
 
+func TestRefreshCourierToken(t *testing.T) {
+	store := &StubCourierStore{
+		couriers: []models.Courier{testdata.MichaelCourier, testdata.JimCourier},
+	}
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
+
+	t.Run("returns new JWT and refresh token on valid refresh token", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.MichaelCourier)
+
+		request := handlers.NewRefreshCourierRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		jwtResponse, err := validation.ValidateBody[handlers.JWTResponse](response.Body)
+		testutil.AssertValidResponse(t, err)
+
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), testdata.MichaelCourier.ID, auth.COURIER)
+		if jwtResponse.RefreshToken == loginResponse.RefreshToken {
+			t.Errorf("expected new refresh token, got the old one")
+		}
+	})
+
+	t.Run("returns Unauthorized on reused refresh token", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.MichaelCourier)
+
+		request := handlers.NewRefreshCourierRequest(loginResponse.RefreshToken)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshCourierRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns Unauthorized on refresh token of deleted courier", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.JimCourier)
+		store.couriers = []models.Courier{testdata.MichaelCourier}
+
+		request := handlers.NewRefreshCourierRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrCourierNotFound)
+	})
+}
+
+func TestLogoutCourier(t *testing.T) {
+	store := &StubCourierStore{
+		couriers: []models.Courier{testdata.MichaelCourier},
+	}
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
+
+	t.Run("revokes JWT on logout", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.MichaelCourier)
+
+		request := handlers.NewLogoutCourierRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		request = handlers.NewGetCourierRequest(loginResponse.Token)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+
+	t.Run("invalidates refresh token on logout", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.MichaelCourier)
+
+		request := handlers.NewLogoutCourierRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshCourierRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns REVOKED status on revoked JWT", func(t *testing.T) {
+		loginResponse := loginCourier(server, testdata.MichaelCourier)
+
+		request := handlers.NewLogoutCourierRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewAuthCourierRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		got, err := validation.ValidateBody[msgtypes.AuthResponse](response.Body)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.Status, msgtypes.REVOKED)
+	})
+}
+
+func loginCourier(server http.Handler, courier models.Courier) handlers.JWTResponse {
+	request := handlers.NewLoginCourierRequest(courier)
+	response := httptest.NewRecorder()
+
+	server.ServeHTTP(response, request)
+
+	jwtResponse, _ := validation.ValidateBody[handlers.JWTResponse](response.Body)
+	return jwtResponse
+}
+
 func TestDeleteCourier(t *testing.T) {
 	store := &StubCourierStore{
 		couriers: []models.Courier{testdata.MichaelCourier, testdata.JimCourier},
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("deletes courier on DELETE", func(t *testing.T) {
-		jimJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.JimCourier.ID)
+		jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
 
 		request := handlers.NewDeleteCourierRequest(jimJWT)
 		response := httptest.NewRecorder()
@@ -214,7 +372,7 @@ func TestDeleteCourier(t *testing.T) {
 	})
 
 	t.Run("sends COURIER_DELETED event on DELETE", func(t *testing.T) {
-		jimJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.JimCourier.ID)
+		jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
 
 		request := handlers.NewDeleteCourierRequest(jimJWT)
 		response := httptest.NewRecorder()
@@ -223,7 +381,7 @@ func TestDeleteCourier(t *testing.T) {
 
 		wantTopic := svcevents.COURIER_EVENTS_TOPIC
 		wantEvent := events.InterfaceEvent{
-			EventID:     svcevents.COURIER_CREATED_EVENT_ID,
+			EventID:     svcevents.COURIER_DELETED_EVENT_ID,
 			AggregateID: testdata.JimCourier.ID,
 			Payload: svcevents.CourierDeletedEvent{
 				ID: testdata.JimCourier.ID,
@@ -241,13 +399,13 @@ func TestUpdateCourier(t *testing.T) {
 	}
 
 	publisher := &StubEventPublisher{}
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("updates courier on PUT", func(t *testing.T) {
 		updatedCourier := testdata.JimCourier
 		updatedCourier.Email = "prisonmike@gmail.com"
 
-		jimJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.JimCourier.ID)
+		jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
 
 		request := handlers.NewUpdateCourierRequest(jimJWT, updatedCourier)
 		response := httptest.NewRecorder()
@@ -256,8 +414,35 @@ func TestUpdateCourier(t *testing.T) {
 
 		testutil.AssertStatus(t, response.Code, http.StatusOK)
 
+		assertHashedPassword(t, &store.updatedCourier, updatedCourier.Password)
 		testutil.AssertEqual(t, store.updatedCourier, updatedCourier)
 	})
+
+	t.Run("sends COURIER_UPDATED event on PUT", func(t *testing.T) {
+		updatedCourier := testdata.JimCourier
+		updatedCourier.IBAN = "BG80BNBG96611020345678"
+
+		jimJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.JimCourier.ID, auth.COURIER)
+
+		request := handlers.NewUpdateCourierRequest(jimJWT, updatedCourier)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		wantTopic := svcevents.COURIER_EVENTS_TOPIC
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.COURIER_UPDATED_EVENT_ID,
+			AggregateID: testdata.JimCourier.ID,
+			Payload: svcevents.CourierUpdatedEvent{
+				ID:   testdata.JimCourier.ID,
+				Name: testdata.JimCourier.FirstName,
+				IBAN: updatedCourier.IBAN,
+			},
+		}
+
+		testutil.AssertEqual(t, publisher.topic, wantTopic)
+		testutil.AssertEvent(t, publisher.event, wantEvent)
+	})
 }
 
 func TestGetCourier(t *testing.T) {
@@ -266,10 +451,10 @@ func TestGetCourier(t *testing.T) {
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("returns courier on GET", func(t *testing.T) {
-		michaelJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.MichaelCourier.ID)
+		michaelJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.MichaelCourier.ID, auth.COURIER)
 		request := handlers.NewGetCourierRequest(michaelJWT)
 		response := httptest.NewRecorder()
 
@@ -292,7 +477,7 @@ func TestCreateCourier(t *testing.T) {
 	}
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewCourierServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewCourierServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("creates courier on POST", func(t *testing.T) {
 		request := handlers.NewCreateCourierRequest(testdata.MichaelCourier)
@@ -302,6 +487,7 @@ func TestCreateCourier(t *testing.T) {
 
 		testutil.AssertStatus(t, response.Code, http.StatusOK)
 
+		assertHashedPassword(t, &store.createdCourier, testdata.MichaelCourier.Password)
 		testutil.AssertEqual(t, store.createdCourier, testdata.MichaelCourier)
 	})
 
@@ -315,7 +501,7 @@ func TestCreateCourier(t *testing.T) {
 		testutil.AssertValidResponse(t, err)
 
 		token := createCourierResponse.JWT.Token
-		testutil.AssertJWT(t, token, testEnv.SecretKey, testdata.MichaelCourier.ID)
+		testutil.AssertJWT(t, token, auth.HMACKey(testEnv.SecretKey), testdata.MichaelCourier.ID, auth.COURIER)
 	})
 
 	t.Run("returns the created courier on POST", func(t *testing.T) {
@@ -345,6 +531,7 @@ func TestCreateCourier(t *testing.T) {
 			Payload: svcevents.CourierCreatedEvent{
 				ID:   testdata.MichaelCourier.ID,
 				Name: testdata.MichaelCourier.FirstName,
+				IBAN: testdata.MichaelCourier.IBAN,
 			},
 		}
 
@@ -352,6 +539,18 @@ func TestCreateCourier(t *testing.T) {
 		testutil.AssertEvent(t, publisher.event, wantEvent)
 	})
 
+	t.Run("returns Bad Request on password longer than 72 bytes", func(t *testing.T) {
+		courier := testdata.MichaelCourier
+		courier.Password = strings.Repeat("ж", 37)
+
+		request := handlers.NewCreateCourierRequest(courier)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+
 	t.Run("returns Bad Request on courier with same email", func(t *testing.T) {
 		request := handlers.NewCreateCourierRequest(testdata.JimCourier)
 		response := httptest.NewRecorder()
@@ -362,3 +561,17 @@ func TestCreateCourier(t *testing.T) {
 		testutil.AssertErrorResponse(t, response.Body, handlers.ErrExistingCourier)
 	})
 }
+
+// Couriers are stored with a hashed password, so after verifying it against
+// the expected plaintext one it's replaced with the plaintext password to
+// allow comparing the rest of the courier.
+func assertHashedPassword(t testing.TB, stored *models.Courier, password string) {
+	t.Helper()
+
+	needsRehash, err := auth.VerifyPassword(stored.Password, password)
+	if err != nil || needsRehash {
+		t.Errorf("stored password %q isn't a hash of %q", stored.Password, password)
+	}
+
+	stored.Password = password
+}
@@ -4,7 +4,7 @@ import "github.com/VitoNaychev/food-app/courier-svc/models"
 
 type LoginCourierRequest struct {
 	Email    string `validate:"required,email,max=60"       json:"email"`
-	Password string `valdiate:"required,max=72"             json:"password"`
+	Password string `validate:"required,password"           json:"password"`
 }
 
 type UpdateCourierRequest struct {
@@ -12,7 +12,7 @@ type UpdateCourierRequest struct {
 	LastName    string `validate:"required,max=40"             json:"last_name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `valdiate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 	IBAN        string `validate:"required"                    json:"iban"`
 }
 
@@ -44,7 +44,12 @@ func CourierToUpdateCourierRequest(courier models.Courier) UpdateCourierRequest
 }
 
 type JWTResponse struct {
-	Token string `validate:"required" json:"token"`
+	Token        string `validate:"required" json:"token"`
+	RefreshToken string `validate:"required" json:"refresh_token"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `validate:"required" json:"refresh_token"`
 }
 
 type CreateCourierResponse struct {
@@ -79,7 +84,7 @@ type CreateCourierRequest struct {
 	LastName    string `validate:"required,max=40"             json:"last_name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `valdiate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 	IBAN        string `validate:"required"                    json:"iban"`
 }
 
@@ -3,16 +3,19 @@ package handlers
 import (
 	"errors"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/courier-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type CourierVerifier struct {
-	store models.CourierStore
+	store      models.CourierStore
+	tokenStore auth.TokenStore
 }
 
-func NewCourierVerifier(store models.CourierStore) *CourierVerifier {
-	return &CourierVerifier{store}
+func NewCourierVerifier(store models.CourierStore, tokenStore auth.TokenStore) *CourierVerifier {
+	return &CourierVerifier{store, tokenStore}
 }
 
 func (c *CourierVerifier) DoesSubjectExist(id int) (bool, error) {
@@ -24,3 +27,7 @@ func (c *CourierVerifier) DoesSubjectExist(id int) (bool, error) {
 	}
 	return true, nil
 }
+
+func (c *CourierVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsJWTRevoked(c.tokenStore, token)
+}
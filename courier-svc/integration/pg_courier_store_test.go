@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/courier-svc/handlers"
 	"github.com/VitoNaychev/food-app/courier-svc/models"
 	td "github.com/VitoNaychev/food-app/courier-svc/testdata"
@@ -31,7 +32,7 @@ func TestCustomerServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	server := handlers.NewCourierServer(env.SecretKey, env.ExpiresAt, &courierStore, &DummyEventPublisher{})
+	server := handlers.NewCourierServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, &courierStore, &DummyEventPublisher{}, auth.NewInMemoryTokenStore())
 
 	var michaelJWT string
 
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgCourierStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgCourierStore(ctx context.Context, connString string) (PgCourierStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgCourierStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -23,6 +24,14 @@ func NewPgCourierStore(ctx context.Context, connString string) (PgCourierStore,
 	return pgCourierStore, nil
 }
 
+func (p *PgCourierStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgCourierStore) WithTx(tx pgx.Tx) CourierStore {
+	return &PgCourierStore{tx}
+}
+
 func (p *PgCourierStore) GetCourierByEmail(email string) (Courier, error) {
 	query := `select * from couriers where email=@email`
 	args := pgx.NamedArgs{
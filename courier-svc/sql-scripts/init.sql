@@ -1,3 +1,7 @@
+DROP TABLE IF EXISTS revoked_subjects;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS outbox;
 DROP TABLE IF EXISTS couriers;
 
 CREATE TABLE couriers (
@@ -9,4 +13,29 @@ CREATE TABLE couriers (
   password            varchar(72)          NOT NULL,
   IBAN                varchar(34)          NOT NULL
   );
-  
\ No newline at end of file
+
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
+
+CREATE TABLE refresh_tokens (
+  hash                varchar(64)          PRIMARY KEY,
+  subject             int                  NOT NULL,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_tokens (
+  id                  varchar(64)          PRIMARY KEY,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_subjects (
+  subject             int                  PRIMARY KEY,
+  revoked_before      timestamp with time zone NOT NULL
+);
@@ -10,9 +10,12 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/customer-svc/handlers"
 	"github.com/VitoNaychev/food-app/customer-svc/models"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func main() {
@@ -20,6 +23,8 @@ func main() {
 		SecretKey: []byte(os.Getenv("SECRET")),
 		ExpiresAt: 24 * time.Hour,
 
+		SigningKeyFiles: strings.Split(os.Getenv("SIGNING_KEY_FILES"), ","),
+
 		Dbhost: "customer-db",
 		Dbport: "5432",
 		Dbuser: os.Getenv("POSTGRES_USER"),
@@ -29,6 +34,12 @@ func main() {
 		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "customer-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -42,11 +53,21 @@ func main() {
 		fmt.Printf("Address Store error: %v", err)
 	}
 
-	customerServer := handlers.NewCustomerServer(env.SecretKey, env.ExpiresAt, &customerStore)
-	addressServer := handlers.NewCustomerAddressServer(&addressStore, &customerStore, env.SecretKey)
+	tokenStore, err := auth.NewPgTokenStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Token Store error: %v\n", err)
+	}
+
+	signer, err := auth.NewSigner(env.SecretKey, env.SigningKeyFiles, env.ExpiresAt)
+	if err != nil {
+		log.Fatalf("Signer error: %v\n", err)
+	}
+
+	customerServer := handlers.NewCustomerServer(signer, env.ExpiresAt, &customerStore, tokenStore)
+	addressServer := handlers.NewCustomerAddressServer(&addressStore, &customerStore, signer, tokenStore)
 
 	router := handlers.NewRouterServer(customerServer, addressServer)
 
 	fmt.Println("Customer service listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	log.Fatal(http.ListenAndServe(":8080", tracing.Middleware(metrics.Middleware(router), "customer-svc")))
 }
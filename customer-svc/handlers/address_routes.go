@@ -10,16 +10,17 @@ import (
 type CustomerAddressServer struct {
 	addressStore  models.CustomerAddressStore
 	customerStore models.CustomerStore
-	secretKey     []byte
+	keys          auth.KeySet
 	verifier      auth.Verifier
 }
 
-func NewCustomerAddressServer(addressStore models.CustomerAddressStore, customerStore models.CustomerStore, secretKey []byte) *CustomerAddressServer {
+func NewCustomerAddressServer(addressStore models.CustomerAddressStore, customerStore models.CustomerStore, keys auth.KeySet,
+	tokenStore auth.TokenStore) *CustomerAddressServer {
 	customerAddressServer := CustomerAddressServer{
 		addressStore:  addressStore,
 		customerStore: customerStore,
-		secretKey:     secretKey,
-		verifier:      NewCustomerVerifier(customerStore),
+		keys:          keys,
+		verifier:      NewCustomerVerifier(customerStore, tokenStore),
 	}
 
 	return &customerAddressServer
@@ -28,12 +29,12 @@ func NewCustomerAddressServer(addressStore models.CustomerAddressStore, customer
 func (c *CustomerAddressServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		auth.AuthenticationMW(c.createAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.createAddress, c.verifier, c.keys, auth.CUSTOMER)(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(c.getAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.getAddress, c.verifier, c.keys, auth.CUSTOMER)(w, r)
 	case http.MethodDelete:
-		auth.AuthenticationMW(c.deleteAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.deleteAddress, c.verifier, c.keys, auth.CUSTOMER)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(c.updateAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.updateAddress, c.verifier, c.keys, auth.CUSTOMER)(w, r)
 	}
 }
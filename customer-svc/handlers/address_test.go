@@ -19,7 +19,7 @@ func TestAddressEndpointAuthentication(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	stubAddressStore := stubs.NewStubAddressStore(nil)
 	stubCustomerStore := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, testEnv.SecretKey)
+	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	invalidJWT := "thisIsAnInvalidJWT"
 	cases := map[string]*http.Request{
@@ -45,13 +45,13 @@ func TestUpdateCustomerAddress(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	stubAddressStore := stubs.NewStubAddressStore(addressData)
 	stubCustomerStore := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, testEnv.SecretKey)
+	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	t.Run("updates address on valid body and credentials", func(t *testing.T) {
 		updatedAddress := td.PeterAddress2
 		updatedAddress.City = "Varna"
 
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewUpdateAddressRequest(peterJWT, updatedAddress)
 		response := httptest.NewRecorder()
@@ -65,7 +65,7 @@ func TestUpdateCustomerAddress(t *testing.T) {
 	t.Run("returns Bad Request on invalid request", func(t *testing.T) {
 		invalidAddress := models.Address{}
 
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewUpdateAddressRequest(peterJWT, invalidAddress)
 		response := httptest.NewRecorder()
@@ -79,7 +79,7 @@ func TestUpdateCustomerAddress(t *testing.T) {
 		updatedAddress := td.PeterAddress2
 		updatedAddress.Id = 10
 
-		missingJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		missingJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewUpdateAddressRequest(missingJWT, updatedAddress)
 		response := httptest.NewRecorder()
@@ -94,7 +94,7 @@ func TestUpdateCustomerAddress(t *testing.T) {
 		updatedAddress := td.PeterAddress2
 		updatedAddress.City = "Varna"
 
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.AliceCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.AliceCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewUpdateAddressRequest(peterJWT, updatedAddress)
 		response := httptest.NewRecorder()
@@ -111,11 +111,11 @@ func TestDeleteCustomerAddress(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	stubAddressStore := stubs.NewStubAddressStore(addressData)
 	stubCustomerStore := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, testEnv.SecretKey)
+	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	t.Run("returns Bad Request on inavlid request", func(t *testing.T) {
 		body := bytes.NewBuffer([]byte{})
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request, _ := http.NewRequest(http.MethodDelete, "/customer/address", body)
 		request.Header.Add("Token", peterJWT)
@@ -128,7 +128,7 @@ func TestDeleteCustomerAddress(t *testing.T) {
 	})
 
 	t.Run("returns Not Found on missing address", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 		deleteAddressRequest := handlers.DeleteAddressRequest{Id: 10}
 
 		request := handlers.NewDeleteAddressRequest(peterJWT, deleteAddressRequest)
@@ -141,7 +141,7 @@ func TestDeleteCustomerAddress(t *testing.T) {
 	})
 
 	t.Run("returns Unathorized on delete on another customer's address", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 		deleteAddressRequest := handlers.DeleteAddressRequest{Id: td.AliceAddress.Id}
 
 		request := handlers.NewDeleteAddressRequest(peterJWT, deleteAddressRequest)
@@ -154,7 +154,7 @@ func TestDeleteCustomerAddress(t *testing.T) {
 	})
 
 	t.Run("deletes address on valid body and credentials", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 		deleteAddressRequest := handlers.DeleteAddressRequest{Id: td.PeterAddress1.Id}
 
 		request := handlers.NewDeleteAddressRequest(peterJWT, deleteAddressRequest)
@@ -172,10 +172,10 @@ func TestSaveCustomerAddress(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	stubAddressStore := stubs.NewStubAddressStore(addressData)
 	stubCustomerStore := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, testEnv.SecretKey)
+	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	t.Run("returns Bad Request on inavlid request", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewCreateAddressRequest(peterJWT, models.Address{})
 		response := httptest.NewRecorder()
@@ -186,7 +186,7 @@ func TestSaveCustomerAddress(t *testing.T) {
 	})
 
 	t.Run("saves Peter's new address", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewCreateAddressRequest(peterJWT, td.PeterAddress1)
 		response := httptest.NewRecorder()
@@ -200,7 +200,7 @@ func TestSaveCustomerAddress(t *testing.T) {
 	t.Run("saves Alice's new address", func(t *testing.T) {
 		stubAddressStore.Empty()
 
-		aliceJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.AliceCustomer.Id)
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.AliceCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewCreateAddressRequest(aliceJWT, td.AliceAddress)
 		response := httptest.NewRecorder()
@@ -217,10 +217,10 @@ func TestGetCustomerAddress(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	stubAddressStore := stubs.NewStubAddressStore(addressData)
 	stubCustomerStore := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, testEnv.SecretKey)
+	server := handlers.NewCustomerAddressServer(stubAddressStore, stubCustomerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	t.Run("returns Peter's addresses", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 		request := handlers.NewGetAddressRequest(peterJWT)
 		response := httptest.NewRecorder()
 
@@ -239,7 +239,7 @@ func TestGetCustomerAddress(t *testing.T) {
 	})
 
 	t.Run("returns Alice's addresses", func(t *testing.T) {
-		aliceJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.AliceCustomer.Id)
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.AliceCustomer.Id, auth.CUSTOMER)
 		request := handlers.NewGetAddressRequest(aliceJWT)
 		response := httptest.NewRecorder()
 
@@ -8,90 +8,113 @@ import (
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/httperrors"
-	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/VitoNaychev/food-app/validation"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func (c *CustomerServer) AuthHandler(w http.ResponseWriter, r *http.Request) {
-	authResponse := msgtypes.AuthResponse{Status: msgtypes.INVALID}
-
-	if tokenHeader := r.Header.Get("Token"); tokenHeader == "" {
-		handleAuthError(w, authResponse, msgtypes.MISSING_TOKEN)
-		return
-	}
-
-	token, err := auth.VerifyJWT(r.Header["Token"][0], c.secretKey)
-	if err != nil {
-		handleAuthError(w, authResponse, msgtypes.INVALID)
-		return
-	}
-
-	customerID, err := getCustomerIDFromToken(token)
+func (c *CustomerServer) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	loginCustomerRequest, err := validation.ValidateBody[LoginCustomerRequest](r.Body)
 	if err != nil {
-		handleAuthError(w, authResponse, msgtypes.INVALID)
+		httperrors.WriteJSONError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	_, err = c.store.GetCustomerByID(customerID)
+	customer, err := c.store.GetCustomerByEmail(loginCustomerRequest.Email)
 	if err != nil {
 		if errors.Is(err, storeerrors.ErrNotFound) {
-			handleAuthError(w, authResponse, msgtypes.NOT_FOUND)
+			// wrap storeerrors.ErrNotFound in customer handlers error type?
+			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrCustomerNotFound)
 			return
 		} else {
-			w.WriteHeader(http.StatusInternalServerError)
+			httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrDatabaseError)
 			return
 		}
 	}
 
-	authResponse.Status = msgtypes.OK
-	authResponse.ID = customerID
-
-	json.NewEncoder(w).Encode(authResponse)
-}
+	needsRehash, err := auth.VerifyPassword(customer.Password, loginCustomerRequest.Password)
+	if err != nil {
+		httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrInvalidCredentials)
+		return
+	}
 
-func handleAuthError(w http.ResponseWriter, authResponse msgtypes.AuthResponse, status msgtypes.AuthStatus) {
-	authResponse.Status = status
-	json.NewEncoder(w).Encode(authResponse)
-}
+	// A failed rehash doesn't fail the login, the password is rehashed again
+	// on the next successful one.
+	if needsRehash {
+		customer.Password, err = auth.HashPassword(loginCustomerRequest.Password)
+		if err == nil {
+			c.store.UpdateCustomer(&customer)
+		}
+	}
 
-func getCustomerIDFromToken(token *jwt.Token) (int, error) {
-	subject, err := token.Claims.GetSubject()
+	jwtResponse, err := c.generateTokens(customer.Id)
 	if err != nil {
-		return 0, err
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
 	}
-	return strconv.Atoi(subject)
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(jwtResponse)
 }
 
-func (c *CustomerServer) LoginHandler(w http.ResponseWriter, r *http.Request) {
-	loginCustomerRequest, err := validation.ValidateBody[LoginCustomerRequest](r.Body)
+func (c *CustomerServer) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	refreshTokenRequest, err := validation.ValidateBody[RefreshTokenRequest](r.Body)
 	if err != nil {
 		httperrors.WriteJSONError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	customer, err := c.store.GetCustomerByEmail(loginCustomerRequest.Email)
-	if err != nil {
-		if errors.Is(err, storeerrors.ErrNotFound) {
-			// wrap storeerrors.ErrNotFound in customer handlers error type?
-			httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrCustomerNotFound)
-			return
-		} else {
-			httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrDatabaseError)
-			return
-		}
+	customerID, refreshToken, err := auth.RotateRefreshToken(c.tokenStore, refreshTokenRequest.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		httperrors.WriteJSONError(w, http.StatusUnauthorized, err)
+		return
+	} else if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
 	}
 
-	if customer.Password != loginCustomerRequest.Password {
-		httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrInvalidCredentials)
+	_, err = c.store.GetCustomerByID(customerID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		c.tokenStore.DeleteRefreshTokensBySubject(customerID)
+		httperrors.WriteJSONError(w, http.StatusUnauthorized, ErrCustomerNotFound)
+		return
+	} else if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrDatabaseError)
 		return
 	}
 
-	loginJWT, _ := auth.GenerateJWT(c.secretKey, c.expiresAt, customer.Id)
+	loginJWT, err := auth.GenerateJWT(c.signer, c.expiresAt, customerID, auth.CUSTOMER)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
 
 	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(JWTResponse{Token: loginJWT})
+	json.NewEncoder(w).Encode(JWTResponse{Token: loginJWT, RefreshToken: refreshToken})
+}
+
+func (c *CustomerServer) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	// The token was already verified by the authentication middleware.
+	token, _ := auth.VerifyJWT(r.Header.Get("Token"), c.signer, auth.CUSTOMER)
+
+	err := auth.RevokeJWT(c.tokenStore, token)
+	if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+}
+
+func (c *CustomerServer) generateTokens(customerID int) (JWTResponse, error) {
+	loginJWT, err := auth.GenerateJWT(c.signer, c.expiresAt, customerID, auth.CUSTOMER)
+	if err != nil {
+		return JWTResponse{}, err
+	}
+
+	refreshToken, err := auth.GenerateRefreshToken(c.tokenStore, customerID)
+	if err != nil {
+		return JWTResponse{}, err
+	}
+
+	return JWTResponse{Token: loginJWT, RefreshToken: refreshToken}, nil
 }
 
 func (c *CustomerServer) updateCustomer(w http.ResponseWriter, r *http.Request) {
@@ -109,6 +132,12 @@ func (c *CustomerServer) updateCustomer(w http.ResponseWriter, r *http.Request)
 
 	customer = UpdateCustomerRequestToCustomer(updateCustomerRequest, id)
 
+	customer.Password, err = auth.HashPassword(customer.Password)
+	if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrPasswordHashing)
+		return
+	}
+
 	err = c.store.UpdateCustomer(&customer)
 	if err != nil {
 		httperrors.WriteJSONError(w, http.StatusBadRequest, err)
@@ -145,18 +174,28 @@ func (c *CustomerServer) createCustomer(w http.ResponseWriter, r *http.Request)
 
 	customer := CreateCustomerRequestToCustomer(createCustomerRequest)
 
+	customer.Password, err = auth.HashPassword(customer.Password)
+	if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrPasswordHashing)
+		return
+	}
+
 	err = c.store.CreateCustomer(&customer)
 	if err != nil {
 		httperrors.WriteJSONError(w, http.StatusInternalServerError, ErrDatabaseError)
 		return
 	}
 
-	customerJWT, _ := auth.GenerateJWT(c.secretKey, c.expiresAt, customer.Id)
+	jwtResponse, err := c.generateTokens(customer.Id)
+	if err != nil {
+		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
 
 	w.WriteHeader(http.StatusAccepted)
 
 	createCustomerResponse := CreateCustomerResponse{
-		JWT:      JWTResponse{Token: customerJWT},
+		JWT:      jwtResponse,
 		Customer: CustomerToCustomerResponse(customer),
 	}
 	json.NewEncoder(w).Encode(createCustomerResponse)
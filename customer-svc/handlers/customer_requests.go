@@ -56,3 +56,18 @@ func NewGetCustomerRequest(jwt string) *http.Request {
 
 	return request
 }
+
+func NewRefreshRequest(refreshToken string) *http.Request {
+	body := bytes.NewBuffer([]byte{})
+	json.NewEncoder(body).Encode(RefreshTokenRequest{RefreshToken: refreshToken})
+
+	request, _ := http.NewRequest(http.MethodPost, "/customer/login/refresh/", body)
+	return request
+}
+
+func NewLogoutRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/customer/logout/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
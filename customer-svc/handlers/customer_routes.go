@@ -9,27 +9,32 @@ import (
 )
 
 type CustomerServer struct {
-	secretKey []byte
-	expiresAt time.Duration
-	store     models.CustomerStore
-	verifier  auth.Verifier
-	http.Handler
+	signer     auth.Signer
+	expiresAt  time.Duration
+	store      models.CustomerStore
+	tokenStore auth.TokenStore
+	verifier   auth.Verifier
+	*http.ServeMux
 }
 
-func NewCustomerServer(secretKey []byte, expiresAt time.Duration, store models.CustomerStore) *CustomerServer {
+func NewCustomerServer(signer auth.Signer, expiresAt time.Duration, store models.CustomerStore, tokenStore auth.TokenStore) *CustomerServer {
 	c := new(CustomerServer)
 
-	c.secretKey = secretKey
+	c.signer = signer
 	c.expiresAt = expiresAt
 	c.store = store
-	c.verifier = NewCustomerVerifier(store)
+	c.tokenStore = tokenStore
+	c.verifier = NewCustomerVerifier(store, tokenStore)
 
 	router := http.NewServeMux()
 	router.HandleFunc("/customer/", c.CustomerHandler)
 	router.HandleFunc("/customer/login/", c.LoginHandler)
-	router.HandleFunc("/customer/auth/", c.AuthHandler)
+	router.HandleFunc("/customer/login/refresh/", c.RefreshHandler)
+	router.HandleFunc("/customer/logout/", auth.AuthenticationMW(c.LogoutHandler, c.verifier, c.signer, auth.CUSTOMER))
+	router.HandleFunc("/customer/auth/", auth.AuthHandler(c.verifier, c.signer, auth.CUSTOMER))
+	router.HandleFunc(auth.JWKSPath, auth.JWKSHandler(c.signer))
 
-	c.Handler = router
+	c.ServeMux = router
 
 	return c
 }
@@ -44,10 +49,10 @@ func (c *CustomerServer) CustomerHandler(w http.ResponseWriter, r *http.Request)
 	case http.MethodPost:
 		c.createCustomer(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(c.getCustomer, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.getCustomer, c.verifier, c.signer, auth.CUSTOMER)(w, r)
 	case http.MethodDelete:
-		auth.AuthenticationMW(c.deleteCustomer, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.deleteCustomer, c.verifier, c.signer, auth.CUSTOMER)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(c.updateCustomer, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.updateCustomer, c.verifier, c.signer, auth.CUSTOMER)(w, r)
 	}
 }
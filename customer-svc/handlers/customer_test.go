@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ import (
 func TestCustomerEndpointAuthentication(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	invalidJWT := "thisIsAnInvalidJWT"
 	cases := map[string]*http.Request{
@@ -46,10 +47,10 @@ func TestCustomerEndpointAuthentication(t *testing.T) {
 func TestAuthHandler(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("returns OK status and customer ID on valid JWT", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewAuthRequest(peterJWT)
 		response := httptest.NewRecorder()
@@ -61,6 +62,7 @@ func TestAuthHandler(t *testing.T) {
 		want := msgtypes.AuthResponse{
 			Status: msgtypes.OK,
 			ID:     td.PeterCustomer.Id,
+			Role:   string(auth.CUSTOMER),
 		}
 		var got msgtypes.AuthResponse
 		json.NewDecoder(response.Body).Decode(&got)
@@ -105,7 +107,7 @@ func TestAuthHandler(t *testing.T) {
 	})
 
 	t.Run("returns INVALID on noninteger subject", func(t *testing.T) {
-		invalidJWT, _ := GenerateJWTWithStringSubject(testEnv.SecretKey, testEnv.ExpiresAt, "peter")
+		invalidJWT, _ := GenerateJWTWithStringSubject(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, "peter")
 		request := handlers.NewAuthRequest(invalidJWT)
 		response := httptest.NewRecorder()
 
@@ -122,7 +124,7 @@ func TestAuthHandler(t *testing.T) {
 	})
 
 	t.Run("returns INVALID on missing subject", func(t *testing.T) {
-		invalidJWT, _ := GenerateJWTWithoutSubject(testEnv.SecretKey, testEnv.ExpiresAt)
+		invalidJWT, _ := GenerateJWTWithoutSubject(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt)
 		request := handlers.NewAuthRequest(invalidJWT)
 		response := httptest.NewRecorder()
 
@@ -139,7 +141,7 @@ func TestAuthHandler(t *testing.T) {
 	})
 
 	t.Run("returns NOT_FOUND on customer that doesn't exist", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, 10)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, 10, auth.CUSTOMER)
 
 		request := handlers.NewAuthRequest(peterJWT)
 		response := httptest.NewRecorder()
@@ -161,14 +163,14 @@ func TestAuthHandler(t *testing.T) {
 func TestUpdateUser(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("updates customer information on valid JWT", func(t *testing.T) {
 		updateCustomer := td.PeterCustomer
 		updateCustomer.FirstName = "John"
 		updateCustomer.PhoneNumber = "+359 88 1234 213"
 
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewUpdateCustomerRequest(updateCustomer, peterJWT)
 		response := httptest.NewRecorder()
@@ -183,10 +185,10 @@ func TestUpdateUser(t *testing.T) {
 func TestDeleteUser(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("deletes customer on valid JWT", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 
 		request := handlers.NewDeleteCustomerRequest(peterJWT)
 		response := httptest.NewRecorder()
@@ -199,7 +201,7 @@ func TestDeleteUser(t *testing.T) {
 func TestLoginUser(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("returns JWT on Peter's credentials", func(t *testing.T) {
 		request := handlers.NewLoginRequest(td.PeterCustomer)
@@ -212,7 +214,7 @@ func TestLoginUser(t *testing.T) {
 		var jwtResponse handlers.JWTResponse
 		json.NewDecoder(response.Body).Decode(&jwtResponse)
 
-		testutil.AssertJWT(t, jwtResponse.Token, testEnv.SecretKey, td.PeterCustomer.Id)
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), td.PeterCustomer.Id, auth.CUSTOMER)
 	})
 
 	t.Run("returns JWT on Alice's credentials", func(t *testing.T) {
@@ -226,7 +228,7 @@ func TestLoginUser(t *testing.T) {
 		var jwtResponse handlers.JWTResponse
 		json.NewDecoder(response.Body).Decode(&jwtResponse)
 
-		testutil.AssertJWT(t, jwtResponse.Token, testEnv.SecretKey, td.AliceCustomer.Id)
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), td.AliceCustomer.Id, auth.CUSTOMER)
 	})
 
 	t.Run("returns Unauthorized on invalid credentials", func(t *testing.T) {
@@ -241,6 +243,34 @@ func TestLoginUser(t *testing.T) {
 		testutil.AssertErrorResponse(t, response.Body, handlers.ErrInvalidCredentials)
 	})
 
+	t.Run("rehashes legacy plaintext password on login", func(t *testing.T) {
+		store := stubs.NewStubCustomerStore([]models.Customer{td.PeterCustomer})
+		server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginRequest(td.PeterCustomer)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusAccepted)
+		stubs.AssertUpdatedCustomer(t, store, td.PeterCustomer)
+	})
+
+	t.Run("returns JWT on hashed password", func(t *testing.T) {
+		hashedCustomer := td.PeterCustomer
+		hashedCustomer.Password, _ = auth.HashPassword(td.PeterCustomer.Password)
+
+		store := stubs.NewStubCustomerStore([]models.Customer{hashedCustomer})
+		server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginRequest(td.PeterCustomer)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusAccepted)
+	})
+
 	t.Run("returns Unauthorized on missing user", func(t *testing.T) {
 		missingCustomer := td.PeterCustomer
 		missingCustomer.Email = "notanemail@gmail.com"
@@ -254,10 +284,128 @@ func TestLoginUser(t *testing.T) {
 	})
 }
 
+func TestRefreshToken(t *testing.T) {
+	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
+	store := stubs.NewStubCustomerStore(customerData)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
+
+	t.Run("returns new JWT and refresh token on valid refresh token", func(t *testing.T) {
+		loginResponse := loginCustomer(server, td.PeterCustomer)
+
+		request := handlers.NewRefreshRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusAccepted)
+
+		var jwtResponse handlers.JWTResponse
+		json.NewDecoder(response.Body).Decode(&jwtResponse)
+
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), td.PeterCustomer.Id, auth.CUSTOMER)
+		if jwtResponse.RefreshToken == "" || jwtResponse.RefreshToken == loginResponse.RefreshToken {
+			t.Errorf("expected new refresh token, got %q", jwtResponse.RefreshToken)
+		}
+	})
+
+	t.Run("returns Unauthorized on reused refresh token", func(t *testing.T) {
+		loginResponse := loginCustomer(server, td.PeterCustomer)
+
+		request := handlers.NewRefreshRequest(loginResponse.RefreshToken)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns Bad Request on missing refresh token", func(t *testing.T) {
+		request := handlers.NewRefreshRequest("")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+func TestLogoutUser(t *testing.T) {
+	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
+	store := stubs.NewStubCustomerStore(customerData)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
+
+	t.Run("revokes JWT on logout", func(t *testing.T) {
+		loginResponse := loginCustomer(server, td.PeterCustomer)
+
+		request := handlers.NewLogoutRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		request = handlers.NewGetCustomerRequest(loginResponse.Token)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+
+	t.Run("invalidates refresh token on logout", func(t *testing.T) {
+		loginResponse := loginCustomer(server, td.PeterCustomer)
+
+		request := handlers.NewLogoutRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns REVOKED status on revoked JWT", func(t *testing.T) {
+		loginResponse := loginCustomer(server, td.PeterCustomer)
+
+		request := handlers.NewLogoutRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewAuthRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		var got msgtypes.AuthResponse
+		json.NewDecoder(response.Body).Decode(&got)
+
+		testutil.AssertEqual(t, got.Status, msgtypes.REVOKED)
+	})
+}
+
+func loginCustomer(server http.Handler, customer models.Customer) handlers.JWTResponse {
+	request := handlers.NewLoginRequest(customer)
+	response := httptest.NewRecorder()
+
+	server.ServeHTTP(response, request)
+
+	var jwtResponse handlers.JWTResponse
+	json.NewDecoder(response.Body).Decode(&jwtResponse)
+
+	return jwtResponse
+}
+
 func TestCreateUser(t *testing.T) {
 	customerData := []models.Customer{}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("stores customer on POST", func(t *testing.T) {
 		store.Empty()
@@ -290,10 +438,24 @@ func TestCreateUser(t *testing.T) {
 		var gotResponse handlers.CreateCustomerResponse
 		json.NewDecoder(response.Body).Decode(&gotResponse)
 
-		testutil.AssertJWT(t, gotResponse.JWT.Token, testEnv.SecretKey, td.PeterCustomer.Id)
+		testutil.AssertJWT(t, gotResponse.JWT.Token, auth.HMACKey(testEnv.SecretKey), td.PeterCustomer.Id, auth.CUSTOMER)
 		testutil.AssertEqual(t, gotResponse.Customer, wantResponseCustomer)
 	})
 
+	t.Run("returns Bad Request on password longer than 72 bytes", func(t *testing.T) {
+		store.Empty()
+
+		customer := td.PeterCustomer
+		customer.Password = strings.Repeat("ж", 37)
+
+		request := handlers.NewCreateCustomerRequest(customer)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+
 	t.Run("return Bad Request on user with same email", func(t *testing.T) {
 		store.Empty()
 
@@ -316,10 +478,10 @@ func TestCreateUser(t *testing.T) {
 func TestGetUser(t *testing.T) {
 	customerData := []models.Customer{td.PeterCustomer, td.AliceCustomer}
 	store := stubs.NewStubCustomerStore(customerData)
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, auth.NewInMemoryTokenStore())
 
 	t.Run("returns Peter's customer information", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.PeterCustomer.Id)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.PeterCustomer.Id, auth.CUSTOMER)
 		request := handlers.NewGetCustomerRequest(peterJWT)
 		response := httptest.NewRecorder()
 
@@ -335,7 +497,7 @@ func TestGetUser(t *testing.T) {
 	})
 
 	t.Run("returns Alice's customer information", func(t *testing.T) {
-		aliceJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.AliceCustomer.Id)
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.AliceCustomer.Id, auth.CUSTOMER)
 		request := handlers.NewGetCustomerRequest(aliceJWT)
 		response := httptest.NewRecorder()
 
@@ -352,9 +514,14 @@ func TestGetUser(t *testing.T) {
 }
 
 func GenerateJWTWithStringSubject(secretKey []byte, expiresAt time.Duration, subject string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Subject:   subject,
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		Role: auth.CUSTOMER,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    auth.Issuer(auth.CUSTOMER),
+			Audience:  jwt.ClaimStrings{auth.Audience},
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+		},
 	})
 
 	tokenString, err := token.SignedString(secretKey)
@@ -367,8 +534,13 @@ func GenerateJWTWithStringSubject(secretKey []byte, expiresAt time.Duration, sub
 }
 
 func GenerateJWTWithoutSubject(secretKey []byte, expiresAt time.Duration) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		Role: auth.CUSTOMER,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    auth.Issuer(auth.CUSTOMER),
+			Audience:  jwt.ClaimStrings{auth.Audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresAt)),
+		},
 	})
 
 	tokenString, err := token.SignedString(secretKey)
@@ -3,7 +3,12 @@ package handlers
 import "github.com/VitoNaychev/food-app/customer-svc/models"
 
 type JWTResponse struct {
-	Token string `validate:"required" json:"token"`
+	Token        string `validate:"required" json:"token"`
+	RefreshToken string `validate:"required" json:"refresh_token"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `validate:"required" json:"refresh_token"`
 }
 
 type GetCustomerResponse struct {
@@ -31,7 +36,7 @@ type CreateCustomerRequest struct {
 	LastName    string `validate:"required,max=20"             json:"last_name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `validate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 }
 
 func CustomerToCreateCustomerRequest(customer models.Customer) CreateCustomerRequest {
@@ -85,7 +90,7 @@ func CustomerToCustomerResponse(customer models.Customer) CustomerResponse {
 
 type LoginCustomerRequest struct {
 	Email    string `validate:"required,email,max=60" json:"email"`
-	Password string `validate:"required,max=72"       json:"password"`
+	Password string `validate:"required,password"     json:"password"`
 }
 
 func CustomerToLoginCustomerRequest(customer models.Customer) LoginCustomerRequest {
@@ -102,7 +107,7 @@ type UpdateCustomerRequest struct {
 	LastName    string `validate:"required,max=20"             json:"last_name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `validate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 }
 
 func CustomerToUpdateCustomerRequest(customer models.Customer) UpdateCustomerRequest {
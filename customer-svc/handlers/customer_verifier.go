@@ -3,16 +3,19 @@ package handlers
 import (
 	"errors"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/customer-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type CustomerVerifier struct {
-	store models.CustomerStore
+	store      models.CustomerStore
+	tokenStore auth.TokenStore
 }
 
-func NewCustomerVerifier(store models.CustomerStore) *CustomerVerifier {
-	return &CustomerVerifier{store}
+func NewCustomerVerifier(store models.CustomerStore, tokenStore auth.TokenStore) *CustomerVerifier {
+	return &CustomerVerifier{store, tokenStore}
 }
 
 func (c *CustomerVerifier) DoesSubjectExist(id int) (bool, error) {
@@ -24,3 +27,7 @@ func (c *CustomerVerifier) DoesSubjectExist(id int) (bool, error) {
 	}
 	return true, nil
 }
+
+func (c *CustomerVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsJWTRevoked(c.tokenStore, token)
+}
@@ -11,4 +11,5 @@ var (
 	ErrMissingAddress     = errors.New("address doesn't exists")
 	ErrUnathorizedAction  = errors.New("customer does not have permission to perform this action")
 	ErrDatabaseError      = errors.New("operation encountered a database error")
+	ErrPasswordHashing    = errors.New("password couldn't be hashed")
 )
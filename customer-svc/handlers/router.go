@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"net/http"
+
+	"github.com/VitoNaychev/food-app/auth"
 )
 
 type RouterServer struct {
-	http.Handler
+	*http.ServeMux
 }
 
 func NewRouterServer(customerServer http.Handler, addressServer http.Handler) *RouterServer {
@@ -13,9 +15,10 @@ func NewRouterServer(customerServer http.Handler, addressServer http.Handler) *R
 
 	router := http.NewServeMux()
 	router.Handle("/customer/", customerServer)
+	router.Handle(auth.JWKSPath, customerServer)
 	router.Handle("/customer/address/", addressServer)
 
-	routerServer.Handler = router
+	routerServer.ServeMux = router
 
 	return routerServer
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/customer-svc/handlers"
 	"github.com/VitoNaychev/food-app/customer-svc/models"
 	"github.com/VitoNaychev/food-app/customer-svc/testdata"
@@ -32,8 +33,8 @@ func TestAddressServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	customerServer := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, &customerStore)
-	addressServer := handlers.NewCustomerAddressServer(&addressStore, &customerStore, testEnv.SecretKey)
+	customerServer := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, &customerStore, auth.NewInMemoryTokenStore())
+	addressServer := handlers.NewCustomerAddressServer(&addressStore, &customerStore, auth.HMACKey(testEnv.SecretKey), auth.NewInMemoryTokenStore())
 
 	server := handlers.NewRouterServer(customerServer, addressServer)
 
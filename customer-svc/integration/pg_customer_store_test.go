@@ -27,7 +27,7 @@ func TestCustomerServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	server := handlers.NewCustomerServer(testEnv.SecretKey, testEnv.ExpiresAt, &store)
+	server := handlers.NewCustomerServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, &store, auth.NewInMemoryTokenStore())
 
 	var peterJWT string
 	var createdSuccessfully bool
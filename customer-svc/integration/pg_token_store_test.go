@@ -0,0 +1,78 @@
+package integrationtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/integrationutil"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestPgTokenStore(t *testing.T) {
+	config := pgconfig.GetConfigFromEnv(testEnv)
+	integrationutil.SetupDatabaseContainer(t, &config, "../sql-scripts/init.sql")
+
+	connStr := config.GetConnectionString()
+
+	tokenStore, err := auth.NewPgTokenStore(context.Background(), connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("takes refresh token only once", func(t *testing.T) {
+		refreshToken := auth.RefreshToken{Hash: "hash", Subject: 1, ExpiresAt: time.Now().Add(time.Hour).Round(time.Microsecond)}
+
+		err := tokenStore.CreateRefreshToken(refreshToken)
+		testutil.AssertNoErr(t, err)
+
+		got, err := tokenStore.TakeRefreshToken(refreshToken.Hash)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.Subject, refreshToken.Subject)
+		testutil.AssertEqual(t, got.ExpiresAt.Equal(refreshToken.ExpiresAt), true)
+
+		_, err = tokenStore.TakeRefreshToken(refreshToken.Hash)
+		testutil.AssertError(t, err, storeerrors.ErrNotFound)
+	})
+
+	t.Run("deletes refresh tokens of subject", func(t *testing.T) {
+		err := tokenStore.CreateRefreshToken(auth.RefreshToken{Hash: "first", Subject: 2, ExpiresAt: time.Now().Add(time.Hour)})
+		testutil.AssertNoErr(t, err)
+
+		err = tokenStore.DeleteRefreshTokensBySubject(2)
+		testutil.AssertNoErr(t, err)
+
+		_, err = tokenStore.TakeRefreshToken("first")
+		testutil.AssertError(t, err, storeerrors.ErrNotFound)
+	})
+
+	t.Run("remembers revoked tokens", func(t *testing.T) {
+		err := tokenStore.RevokeToken("revoked", time.Now().Add(time.Hour))
+		testutil.AssertNoErr(t, err)
+
+		revoked, err := tokenStore.IsTokenRevoked("revoked")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, true)
+
+		revoked, err = tokenStore.IsTokenRevoked("valid")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revoked, false)
+	})
+
+	t.Run("keeps latest revocation of subject", func(t *testing.T) {
+		revokedBefore, err := tokenStore.GetSubjectRevokedBefore(3)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revokedBefore.IsZero(), true)
+
+		latest := time.Now().Truncate(time.Second)
+		testutil.AssertNoErr(t, tokenStore.RevokeSubject(3, latest))
+		testutil.AssertNoErr(t, tokenStore.RevokeSubject(3, latest.Add(-time.Hour)))
+
+		revokedBefore, err = tokenStore.GetSubjectRevokedBefore(3)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, revokedBefore.Equal(latest), true)
+	})
+}
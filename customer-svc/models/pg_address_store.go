@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgAddressStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgAddressStore(ctx context.Context, connString string) (PgAddressStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgAddressStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -23,6 +24,14 @@ func NewPgAddressStore(ctx context.Context, connString string) (PgAddressStore,
 	return pgAddressStore, nil
 }
 
+func (p *PgAddressStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgAddressStore) WithTx(tx pgx.Tx) CustomerAddressStore {
+	return &PgAddressStore{tx}
+}
+
 func (p *PgAddressStore) CreateAddress(address *Address) error {
 	query := `insert into addresses(customer_id, lat, lon, address_line1, address_line2, city, country) 
 	values (@customer_id, @lat, @lon, @address_line1, @address_line2, @city, @country) returning id`
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgCustomerStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgCustomerStore(ctx context.Context, connString string) (PgCustomerStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgCustomerStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -22,6 +23,14 @@ func NewPgCustomerStore(ctx context.Context, connString string) (PgCustomerStore
 	return pgCustomerStore, nil
 }
 
+func (p *PgCustomerStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgCustomerStore) WithTx(tx pgx.Tx) CustomerStore {
+	return &PgCustomerStore{tx}
+}
+
 func (p *PgCustomerStore) GetCustomerByEmail(email string) (Customer, error) {
 	query := `select * from customers where email=@email`
 	args := pgx.NamedArgs{
@@ -1,3 +1,6 @@
+DROP TABLE IF EXISTS revoked_subjects;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
 DROP TABLE IF EXISTS addresses;
 DROP TABLE IF EXISTS customers;
 
@@ -19,4 +22,20 @@ CREATE TABLE addresses (
   address_line2       varchar(100)                 ,
   city                varchar(70)          NOT NULL,
   country             varchar(60)          NOT NULL
-  );
\ No newline at end of file
+  );
+
+CREATE TABLE refresh_tokens (
+  hash                varchar(64)          PRIMARY KEY,
+  subject             int                  NOT NULL,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_tokens (
+  id                  varchar(64)          PRIMARY KEY,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_subjects (
+  subject             int                  PRIMARY KEY,
+  revoked_before      timestamp with time zone NOT NULL
+);
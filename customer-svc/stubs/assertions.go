@@ -4,9 +4,24 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/customer-svc/models"
 )
 
+// Customers are stored with a hashed password, so after verifying it against
+// the expected plaintext one it's replaced with the plaintext password to
+// allow comparing the rest of the customer.
+func assertHashedPassword(t testing.TB, stored *models.Customer, password string) {
+	t.Helper()
+
+	needsRehash, err := auth.VerifyPassword(stored.Password, password)
+	if err != nil || needsRehash {
+		t.Errorf("stored password %q isn't a hash of %q", stored.Password, password)
+	}
+
+	stored.Password = password
+}
+
 func AssertUpdatedCustomer(t testing.TB, store *StubCustomerStore, customer models.Customer) {
 	t.Helper()
 
@@ -14,6 +29,7 @@ func AssertUpdatedCustomer(t testing.TB, store *StubCustomerStore, customer mode
 		t.Fatalf("got %d calls to UpdateCustomer expected %d", len(store.updateCalls), 1)
 	}
 
+	assertHashedPassword(t, &store.updateCalls[0], customer.Password)
 	if !reflect.DeepEqual(store.updateCalls[0], customer) {
 		t.Errorf("did not update correct customer got %v want %v", store.updateCalls[0], customer)
 	}
@@ -43,6 +59,7 @@ func AssertCreatedCustomer(t testing.TB, store *StubCustomerStore, customer mode
 	// dummy user data for test cases, so there is going to be a mismatch
 	// of the assigned IDs between the two.
 	store.storeCalls[0].Id = customer.Id
+	assertHashedPassword(t, &store.storeCalls[0], customer.Password)
 	if !reflect.DeepEqual(store.storeCalls[0], customer) {
 		t.Errorf("did not store correct customer got %d want %d", store.storeCalls[0].Id, customer.Id)
 	}
@@ -8,15 +8,20 @@ import (
 	"strings"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func main() {
 	env := appenv.Enviornment{
 		SecretKey: []byte(os.Getenv("SECRET")),
+		JWKSURL:   os.Getenv("JWKS_URL"),
 
 		Dbhost: "delivery-db",
 		Dbport: "5432",
@@ -27,6 +32,12 @@ func main() {
 		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "delivery-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -50,28 +61,92 @@ func main() {
 		log.Fatalf("Delivery Store error: %v\n", err)
 	}
 
+	offerStore, err := models.NewPgOfferStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Offer Store error: %v\n", err)
+	}
+
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	if err != nil {
+		log.Fatalf("Kafka Event Publisher error: %v\n", err)
+	}
+	kafkaEventPublisher.SetProducer("delivery-svc")
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(context.Background())
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
+
 	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "delivery-svc")
 	if err != nil {
 		log.Fatalf("Kafka Event Consumer error: %v\n", err)
 	}
 
+	inboxStore, err := events.NewPgInboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Inbox Store error: %v\n", err)
+	}
+	eventConsumer.SetInboxStore(inboxStore)
+	eventConsumer.SetDeadLetterPublisher(kafkaEventPublisher)
+
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		eventPublisher, dispatch.DefaultDispatcherConfig)
+	go dispatcher.Run(context.Background())
+
 	courierEventHandler := handlers.NewCourierEventHandler(courierStore, locationStore)
-	handlers.RegisterCourierEventHandlers(eventConsumer, courierEventHandler)
+	err = handlers.RegisterCourierEventHandlers(eventConsumer, courierEventHandler)
+	if err != nil {
+		log.Fatalf("Courier Event Handlers error: %v\n", err)
+	}
+
+	kitchenEventHandler := handlers.NewKitchenEventHandler(deliveryStore, offerStore, eventPublisher)
+	err = handlers.RegisterKitchenEventHandlers(eventConsumer, kitchenEventHandler)
+	if err != nil {
+		log.Fatalf("Kitchen Event Handlers error: %v\n", err)
+	}
 
-	kitchenEventHandler := handlers.NewKitchenEventHandler(deliveryStore)
-	handlers.RegisterKitchenEventHandlers(eventConsumer, kitchenEventHandler)
+	orderEventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore, dispatcher)
+	err = handlers.RegisterOrderEventHandlers(eventConsumer, orderEventHandler)
+	if err != nil {
+		log.Fatalf("Order Event Handlers error: %v\n", err)
+	}
 
-	orderEventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore)
-	handlers.RegisterOrderEventHandlers(eventConsumer, orderEventHandler)
+	dispatchEventHandler := handlers.NewDispatchEventHandler(dispatcher)
+	err = handlers.RegisterDispatchEventHandlers(eventConsumer, dispatchEventHandler)
+	if err != nil {
+		log.Fatalf("Dispatch Event Handlers error: %v\n", err)
+	}
 
 	go eventConsumer.Run(context.Background())
 	go events.LogEventConsumerErrors(context.Background(), eventConsumer)
 
-	locationServer := handlers.NewLocationServer(env.SecretKey, locationStore, courierStore)
-	deliveryServer := handlers.NewDeliveryServer(env.SecretKey, deliveryStore, addressStore, courierStore)
+	authConfig := auth.DefaultRemoteAuthConfig
+	authConfig.URL = os.Getenv("COURIER_AUTH_URL")
+	if authConfig.URL == "" {
+		authConfig.URL = "http://courier-svc:8080/courier/auth/"
+	}
+	authClient := auth.NewRemoteAuthClient(authConfig)
+
+	keys := auth.NewKeySet(env.SecretKey, env.JWKSURL)
+	locationServer := handlers.NewLocationServer(keys, locationStore, courierStore, dispatcher, authClient.VerifyJWT)
+	deliveryServer := handlers.NewDeliveryServer(keys, deliveryStore, addressStore, courierStore, eventPublisher, authClient.VerifyJWT)
+
+	offerServer := handlers.NewOfferServer(keys, offerStore, deliveryStore, addressStore, courierStore, dispatcher, authClient.VerifyJWT)
 
-	router := handlers.NewRouterServer(deliveryServer, locationServer)
+	router := handlers.NewRouterServer(deliveryServer, locationServer, offerServer)
 
 	log.Println("Delivery service listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", router))
+	log.Fatal(http.ListenAndServe(":8080", tracing.Middleware(metrics.Middleware(router), "delivery-svc")))
 }
@@ -0,0 +1,349 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type DispatcherConfig struct {
+	OfferTimeout   time.Duration
+	ExpiryInterval time.Duration
+	// ReofferCooldown is how long after an offer expires its courier isn't
+	// offered the same delivery again, whether they declined it or let it expire.
+	ReofferCooldown time.Duration
+}
+
+var DefaultDispatcherConfig = DispatcherConfig{
+	OfferTimeout:    time.Minute,
+	ExpiryInterval:  5 * time.Second,
+	ReofferCooldown: 5 * time.Minute,
+}
+
+type Dispatcher struct {
+	courierStore  models.CourierStore
+	deliveryStore models.DeliveryStore
+	addressStore  models.AddressStore
+	offerStore    models.OfferStore
+	publisher     events.EventPublisher
+
+	config DispatcherConfig
+}
+
+func NewDispatcher(courierStore models.CourierStore, deliveryStore models.DeliveryStore,
+	addressStore models.AddressStore, offerStore models.OfferStore, publisher events.EventPublisher, config DispatcherConfig) *Dispatcher {
+	dispatcher := Dispatcher{
+		courierStore:  courierStore,
+		deliveryStore: deliveryStore,
+		addressStore:  addressStore,
+		offerStore:    offerStore,
+		publisher:     publisher,
+
+		config: config,
+	}
+
+	return &dispatcher
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.ExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := d.ExpireOffers(ctx)
+			if err != nil {
+				log.Printf("Dispatcher: failed to expire offers: %v\n", err)
+			}
+		}
+	}
+}
+
+// OfferDelivery offers the delivery to the nearest available courier that
+// hasn't recently been offered it. When nobody is free the delivery stays
+// queued until DispatchQueuedDeliveries is called again.
+func (d *Dispatcher) OfferDelivery(ctx context.Context, delivery models.Delivery) error {
+	return d.inTx(ctx, func(d *Dispatcher) error {
+		// The delivery is read again under lock, so that concurrent dispatches
+		// don't both offer it and one assigned or canceled meanwhile isn't
+		// offered again.
+		delivery, err := d.deliveryStore.LockDeliveryByID(delivery.ID)
+		if err != nil {
+			return err
+		}
+		if delivery.CourierID != 0 || delivery.State == models.CANCELED || delivery.State == models.DECLINED {
+			return nil
+		}
+
+		pickupAddress, err := d.addressStore.GetAddressByID(delivery.PickupAddressID)
+		if err != nil {
+			return err
+		}
+
+		previousOffers, err := d.offerStore.GetOffersByDeliveryID(delivery.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		excludedCourierIDs := []int{}
+		for _, offer := range previousOffers {
+			if offer.State == models.OFFER_PENDING {
+				return nil
+			}
+			if now.Before(offer.ExpiresAt.Add(d.config.ReofferCooldown)) {
+				excludedCourierIDs = append(excludedCourierIDs, offer.CourierID)
+			}
+		}
+
+		courier, err := d.FindNearestAvailableCourier(pickupAddress, excludedCourierIDs...)
+		if errors.Is(err, ErrNoAvailableCouriers) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		offer := models.Offer{
+			CourierID: courier.ID,
+			ExpiresAt: now.Add(d.config.OfferTimeout),
+		}
+
+		deliverySM := models.NewDeliveryOfferSM(&delivery, &offer, now)
+		err = deliverySM.Exec(models.OFFER_DELIVERY)
+		if err != nil {
+			return err
+		}
+
+		return d.offerStore.CreateOffer(&offer)
+	})
+}
+
+func (d *Dispatcher) AcceptOffer(ctx context.Context, courierID, offerID int) (models.Delivery, error) {
+	var delivery models.Delivery
+
+	err := d.inTx(ctx, func(d *Dispatcher) error {
+		offer, courierDelivery, err := d.getCourierOffer(courierID, offerID)
+		if err != nil {
+			return err
+		}
+		delivery = courierDelivery
+
+		deliverySM := models.NewDeliveryOfferSM(&delivery, &offer, time.Now())
+		err = deliverySM.Exec(models.ACCEPT_DELIVERY)
+		if err != nil {
+			return err
+		}
+
+		err = d.deliveryStore.UpdateDelivery(&delivery)
+		if err != nil {
+			return err
+		}
+
+		err = d.offerStore.UpdateOffer(&offer)
+		if err != nil {
+			return err
+		}
+
+		return PublishCourierAssignedEvent(ctx, d.publisher, delivery)
+	})
+	if err != nil {
+		return models.Delivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (d *Dispatcher) DeclineOffer(ctx context.Context, courierID, offerID int) (models.Offer, error) {
+	var offer models.Offer
+
+	err := d.inTx(ctx, func(d *Dispatcher) error {
+		courierOffer, delivery, err := d.getCourierOffer(courierID, offerID)
+		if err != nil {
+			return err
+		}
+		offer = courierOffer
+
+		return d.closeOffer(ctx, &offer, &delivery, models.DECLINE_DELIVERY)
+	})
+	if err != nil {
+		return models.Offer{}, err
+	}
+
+	return offer, nil
+}
+
+// ExpireOffers closes every expired offer in a transaction of its own, so
+// an offer that fails to expire is logged and skipped instead of keeping the
+// others from expiring.
+func (d *Dispatcher) ExpireOffers(ctx context.Context) error {
+	expiredOffers, err := d.offerStore.GetExpiredOffers(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range expiredOffers {
+		err = d.inTx(ctx, func(d *Dispatcher) error {
+			delivery, offer, err := d.lockOfferDelivery(offer.ID)
+			if err != nil {
+				return err
+			}
+
+			return d.closeOffer(ctx, &offer, &delivery, models.EXPIRE_DELIVERY_OFFER)
+		})
+		if err != nil {
+			log.Printf("Dispatcher: failed to expire offer %d: %v\n", offer.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) DispatchQueuedDeliveries(ctx context.Context) error {
+	return d.inTx(ctx, func(d *Dispatcher) error {
+		deliveries, err := d.deliveryStore.GetUnassignedDeliveries()
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			err = d.OfferDelivery(ctx, delivery)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *Dispatcher) FindNearestAvailableCourier(pickupAddress models.Address, excludedCourierIDs ...int) (models.Courier, error) {
+	couriers, err := d.courierStore.GetAvailableCouriers()
+	if err != nil {
+		return models.Courier{}, err
+	}
+
+	nearestCourier := models.Courier{}
+	nearestDistance := math.Inf(1)
+
+	for _, courier := range couriers {
+		if isCourierExcluded(courier.ID, excludedCourierIDs) {
+			continue
+		}
+
+		distance := HaversineDistance(float64(courier.Lat), float64(courier.Lon), pickupAddress.Lat, pickupAddress.Lon)
+		if distance < nearestDistance {
+			nearestCourier = courier.Courier
+			nearestDistance = distance
+		}
+	}
+
+	if nearestCourier.ID == 0 {
+		return models.Courier{}, ErrNoAvailableCouriers
+	}
+
+	return nearestCourier, nil
+}
+
+func (d *Dispatcher) closeOffer(ctx context.Context, offer *models.Offer, delivery *models.Delivery, event models.DeliveryEvent) error {
+	deliverySM := models.NewDeliveryOfferSM(delivery, offer, time.Now())
+	err := deliverySM.Exec(event)
+	if err != nil {
+		return err
+	}
+
+	err = d.offerStore.UpdateOffer(offer)
+	if err != nil {
+		return err
+	}
+
+	return d.OfferDelivery(ctx, *delivery)
+}
+
+func (d *Dispatcher) getCourierOffer(courierID, offerID int) (models.Offer, models.Delivery, error) {
+	delivery, offer, err := d.lockOfferDelivery(offerID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		return models.Offer{}, models.Delivery{}, ErrOfferNotFound
+	} else if err != nil {
+		return models.Offer{}, models.Delivery{}, err
+	}
+
+	if offer.CourierID != courierID {
+		return models.Offer{}, models.Delivery{}, ErrOfferNotFound
+	}
+
+	return offer, delivery, nil
+}
+
+// lockOfferDelivery locks the delivery of the offer and reads the offer again
+// under that lock, since accepting, declining, expiring and withdrawing an
+// offer all lock its delivery first.
+func (d *Dispatcher) lockOfferDelivery(offerID int) (models.Delivery, models.Offer, error) {
+	offer, err := d.offerStore.GetOfferByID(offerID)
+	if err != nil {
+		return models.Delivery{}, models.Offer{}, err
+	}
+
+	delivery, err := d.deliveryStore.LockDeliveryByID(offer.DeliveryID)
+	if err != nil {
+		return models.Delivery{}, models.Offer{}, err
+	}
+
+	offer, err = d.offerStore.GetOfferByID(offerID)
+	if err != nil {
+		return models.Delivery{}, models.Offer{}, err
+	}
+
+	return delivery, offer, nil
+}
+
+// WithTx returns a copy of the dispatcher whose stores and publisher write
+// through tx.
+func (d *Dispatcher) WithTx(tx pgx.Tx) *Dispatcher {
+	txDispatcher := *d
+	txDispatcher.courierStore = pgconfig.WithTx(d.courierStore, tx)
+	txDispatcher.deliveryStore = pgconfig.WithTx(d.deliveryStore, tx)
+	txDispatcher.addressStore = pgconfig.WithTx(d.addressStore, tx)
+	txDispatcher.offerStore = pgconfig.WithTx(d.offerStore, tx)
+	txDispatcher.publisher = pgconfig.WithTx(d.publisher, tx)
+
+	return &txDispatcher
+}
+
+// inTx runs fn with a copy of the dispatcher that writes through the
+// transaction of ctx, or a new one if there is none.
+func (d *Dispatcher) inTx(ctx context.Context, fn func(d *Dispatcher) error) error {
+	return pgconfig.RunInTx(ctx, d.deliveryStore, func(tx pgx.Tx) error {
+		return fn(d.WithTx(tx))
+	})
+}
+
+func isCourierExcluded(courierID int, excludedCourierIDs []int) bool {
+	for _, excludedCourierID := range excludedCourierIDs {
+		if courierID == excludedCourierID {
+			return true
+		}
+	}
+	return false
+}
+
+func PublishCourierAssignedEvent(ctx context.Context, publisher events.EventPublisher, delivery models.Delivery) error {
+	payload := svcevents.CourierAssignedEvent{
+		ID:        delivery.ID,
+		CourierID: delivery.CourierID,
+	}
+	event := svcevents.CourierAssigned.New(delivery.ID, payload).WithTraceContext(ctx).WithCorrelationID(delivery.CorrelationID)
+
+	return publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+}
@@ -0,0 +1,258 @@
+package dispatch_test
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/delivery-svc/stubs"
+	"github.com/VitoNaychev/food-app/delivery-svc/testdata"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type testDispatcher struct {
+	*dispatch.Dispatcher
+
+	courierStore  *stubs.StubCourierStore
+	deliveryStore *stubs.StubDeliveryStore
+	offerStore    *stubs.StubOfferStore
+	publisher     *stubs.StubEventPublisher
+}
+
+func newTestDispatcher(deliveries []models.Delivery, offers []models.Offer) testDispatcher {
+	courierStore := &stubs.StubCourierStore{
+		Couriers:          []models.Courier{testdata.VolenCourier, testdata.PeterCourier},
+		AvailableCouriers: []models.AvailableCourier{testdata.VolenAvailableCourier, testdata.PeterAvailableCourier},
+	}
+	deliveryStore := &stubs.StubDeliveryStore{Deliveries: deliveries}
+	addressStore := &stubs.StubAddressStore{
+		Addresses: []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
+	}
+	offerStore := &stubs.StubOfferStore{Offers: offers}
+	publisher := &stubs.StubEventPublisher{}
+
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		publisher, dispatch.DefaultDispatcherConfig)
+
+	return testDispatcher{dispatcher, courierStore, deliveryStore, offerStore, publisher}
+}
+
+func unassignedDelivery() models.Delivery {
+	delivery := testdata.VolenDelivery
+	delivery.CourierID = 0
+
+	return delivery
+}
+
+func TestHaversineDistance(t *testing.T) {
+	// Sofia to Plovdiv is roughly 132 km as the crow flies
+	got := dispatch.HaversineDistance(42.6977, 23.3219, 42.1354, 24.7453)
+
+	if math.Abs(got-132) > 2 {
+		t.Errorf("got distance %v km, want ~132 km", got)
+	}
+
+	got = dispatch.HaversineDistance(42.6977, 23.3219, 42.6977, 23.3219)
+	testutil.AssertEqual(t, got, 0.0)
+}
+
+func TestFindNearestAvailableCourier(t *testing.T) {
+	t.Run("returns nearest available courier", func(t *testing.T) {
+		dispatcher := newTestDispatcher(nil, nil)
+
+		got, err := dispatcher.FindNearestAvailableCourier(testdata.VolenPickupAddress)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, testdata.VolenCourier)
+	})
+
+	t.Run("skips excluded couriers", func(t *testing.T) {
+		dispatcher := newTestDispatcher(nil, nil)
+
+		got, err := dispatcher.FindNearestAvailableCourier(testdata.VolenPickupAddress, testdata.VolenCourier.ID)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, testdata.PeterCourier)
+	})
+
+	t.Run("returns ErrNoAvailableCouriers when everyone is busy", func(t *testing.T) {
+		dispatcher := newTestDispatcher(nil, nil)
+		dispatcher.courierStore.AvailableCouriers = nil
+
+		_, err := dispatcher.FindNearestAvailableCourier(testdata.VolenPickupAddress)
+
+		testutil.AssertError(t, err, dispatch.ErrNoAvailableCouriers)
+	})
+}
+
+func TestDeliveryOffers(t *testing.T) {
+	t.Run("offers delivery to nearest courier", func(t *testing.T) {
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, nil)
+
+		err := dispatcher.OfferDelivery(context.Background(), unassignedDelivery())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.DeliveryID, testdata.VolenDelivery.ID)
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.VolenCourier.ID)
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.State, models.OFFER_PENDING)
+	})
+
+	t.Run("doesn't offer delivery that was assigned meanwhile", func(t *testing.T) {
+		dispatcher := newTestDispatcher([]models.Delivery{testdata.VolenDelivery}, nil)
+
+		err := dispatcher.OfferDelivery(context.Background(), unassignedDelivery())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer, models.Offer{})
+	})
+
+	t.Run("doesn't re-offer delivery to courier that declined it", func(t *testing.T) {
+		declinedOffer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_DECLINED}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{declinedOffer})
+
+		err := dispatcher.OfferDelivery(context.Background(), unassignedDelivery())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
+
+	t.Run("re-offers delivery to courier that declined it after the cooldown", func(t *testing.T) {
+		declinedOffer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(-dispatch.DefaultDispatcherConfig.ReofferCooldown - time.Second), State: models.OFFER_DECLINED}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{declinedOffer})
+
+		err := dispatcher.OfferDelivery(context.Background(), unassignedDelivery())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.VolenCourier.ID)
+	})
+
+	t.Run("assigns courier and publishes COURIER_ASSIGNED event on accept", func(t *testing.T) {
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		got, err := dispatcher.AcceptOffer(context.Background(), testdata.VolenCourier.ID, offer.ID)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, testdata.VolenDelivery)
+		testutil.AssertEqual(t, dispatcher.deliveryStore.UpdatedDelivery, testdata.VolenDelivery)
+		testutil.AssertEqual(t, dispatcher.offerStore.UpdatedOffer.State, models.OFFER_ACCEPTED)
+
+		payload := svcevents.CourierAssignedEvent{
+			ID:        testdata.VolenDelivery.ID,
+			CourierID: testdata.VolenCourier.ID,
+		}
+		wantEvent := events.NewEvent(svcevents.COURIER_ASSIGNED_EVENT_ID, testdata.VolenDelivery.ID, payload)
+
+		testutil.AssertEqual(t, dispatcher.publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, dispatcher.publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("attaches trace context of the request to COURIER_ASSIGNED event", func(t *testing.T) {
+		provider := sdktrace.NewTracerProvider()
+		t.Cleanup(func() { provider.Shutdown(context.Background()) })
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+		defer span.End()
+		traceID := span.SpanContext().TraceID().String()
+
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		_, err := dispatcher.AcceptOffer(ctx, testdata.VolenCourier.ID, offer.ID)
+		testutil.AssertNoErr(t, err)
+
+		traceParent := dispatcher.publisher.SpyEvent.Headers.TraceParent
+		if !strings.Contains(traceParent, traceID) {
+			t.Errorf("got traceparent %q want it to contain trace ID %q", traceParent, traceID)
+		}
+	})
+
+	t.Run("returns ErrOfferNotFound on another courier's offer", func(t *testing.T) {
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		_, err := dispatcher.AcceptOffer(context.Background(), testdata.PeterCourier.ID, offer.ID)
+
+		testutil.AssertError(t, err, dispatch.ErrOfferNotFound)
+	})
+
+	t.Run("rejects accepting an expired offer", func(t *testing.T) {
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(-time.Minute), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		_, err := dispatcher.AcceptOffer(context.Background(), testdata.VolenCourier.ID, offer.ID)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+	})
+
+	t.Run("re-offers delivery to next nearest courier on decline", func(t *testing.T) {
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		got, err := dispatcher.DeclineOffer(context.Background(), testdata.VolenCourier.ID, offer.ID)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got.State, models.OFFER_DECLINED)
+		testutil.AssertEqual(t, dispatcher.offerStore.UpdatedOffer.State, models.OFFER_DECLINED)
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
+
+	t.Run("expires stale offers and re-offers delivery", func(t *testing.T) {
+		offer := models.Offer{ID: 1, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(-time.Second), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, []models.Offer{offer})
+
+		err := dispatcher.ExpireOffers(context.Background())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.UpdatedOffer.State, models.OFFER_EXPIRED)
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
+
+	t.Run("keeps expiring offers after one fails to expire", func(t *testing.T) {
+		canceledDelivery := testdata.PeterDelivery
+		canceledDelivery.CourierID = 0
+		canceledDelivery.State = models.CANCELED
+
+		canceledOffer := models.Offer{ID: 1, DeliveryID: canceledDelivery.ID, CourierID: testdata.PeterCourier.ID,
+			ExpiresAt: time.Now().Add(-time.Second), State: models.OFFER_PENDING}
+		offer := models.Offer{ID: 2, DeliveryID: testdata.VolenDelivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(-time.Second), State: models.OFFER_PENDING}
+		dispatcher := newTestDispatcher([]models.Delivery{canceledDelivery, unassignedDelivery()},
+			[]models.Offer{canceledOffer, offer})
+
+		err := dispatcher.ExpireOffers(context.Background())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.Offers[0].State, models.OFFER_PENDING)
+		testutil.AssertEqual(t, dispatcher.offerStore.Offers[1].State, models.OFFER_EXPIRED)
+	})
+
+	t.Run("offers queued deliveries", func(t *testing.T) {
+		dispatcher := newTestDispatcher([]models.Delivery{unassignedDelivery()}, nil)
+
+		err := dispatcher.DispatchQueuedDeliveries(context.Background())
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.DeliveryID, testdata.VolenDelivery.ID)
+		testutil.AssertEqual(t, dispatcher.offerStore.CreatedOffer.CourierID, testdata.VolenCourier.ID)
+	})
+}
@@ -0,0 +1,8 @@
+package dispatch
+
+import "errors"
+
+var (
+	ErrNoAvailableCouriers = errors.New("no available couriers")
+	ErrOfferNotFound       = errors.New("courier doesn't have such an offer")
+)
@@ -1,11 +1,15 @@
 package handlers
 
 import (
-	"reflect"
+	"context"
+	"errors"
 
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
 )
 
 type CourierEventHandler struct {
@@ -22,50 +26,50 @@ func NewCourierEventHandler(courierStore models.CourierStore, locationStore mode
 	return &courierEventHandler
 }
 
-func RegisterCourierEventHandlers(eventConsumer events.EventConsumer, courierEventHandler *CourierEventHandler) {
-	eventConsumer.RegisterEventHandler(svcevents.COURIER_EVENTS_TOPIC,
-		svcevents.COURIER_CREATED_EVENT_ID,
-		events.EventHandlerWrapper(courierEventHandler.HandleCourierCreatedEvent),
-		reflect.TypeOf(svcevents.CourierCreatedEvent{}))
-	eventConsumer.RegisterEventHandler(svcevents.COURIER_EVENTS_TOPIC,
-		svcevents.COURIER_DELETED_EVENT_ID,
-		events.EventHandlerWrapper(courierEventHandler.HandleCourierDeletedEvent),
-		reflect.TypeOf(svcevents.CourierDeletedEvent{}))
-}
-
-func (c *CourierEventHandler) HandleCourierCreatedEvent(event events.Event[svcevents.CourierCreatedEvent]) error {
-	courier := models.Courier{
-		ID:   event.Payload.ID,
-		Name: event.Payload.Name,
-	}
-	err := c.courierStore.CreateCourier(&courier)
+func RegisterCourierEventHandlers(eventConsumer events.EventConsumer, courierEventHandler *CourierEventHandler) error {
+	err := svcevents.CourierCreated.Register(eventConsumer, courierEventHandler.HandleCourierCreatedEvent)
 	if err != nil {
 		return err
 	}
 
-	location := models.Location{
-		CourierID: courier.ID,
-		Lat:       0.0,
-		Lon:       0.0,
-	}
-	err = c.locationStore.CreateLocation(&location)
-	if err != nil {
-		return err
-	}
+	return svcevents.CourierDeleted.Register(eventConsumer, courierEventHandler.HandleCourierDeletedEvent)
+}
+
+func (c *CourierEventHandler) HandleCourierCreatedEvent(event events.Event[svcevents.CourierCreatedEvent]) error {
+	return c.inTx(event.Context(), func(c *CourierEventHandler) error {
+		courier := models.Courier{
+			ID:   event.Payload.ID,
+			Name: event.Payload.Name,
+		}
 
-	return nil
+		return c.courierStore.CreateCourier(&courier)
+	})
 }
 
 func (c *CourierEventHandler) HandleCourierDeletedEvent(event events.Event[svcevents.CourierDeletedEvent]) error {
-	err := c.locationStore.DeleteLocation(event.Payload.ID)
-	if err != nil {
-		return err
-	}
+	return c.inTx(event.Context(), func(c *CourierEventHandler) error {
+		err := c.locationStore.DeleteLocation(event.Payload.ID)
+		if err != nil && !errors.Is(err, storeerrors.ErrNotFound) {
+			return err
+		}
 
-	err = c.courierStore.DeleteCourier(event.Payload.ID)
-	if err != nil {
-		return err
-	}
+		err = c.courierStore.DeleteCourier(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (c *CourierEventHandler) inTx(ctx context.Context, fn func(c *CourierEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, c.courierStore, func(tx pgx.Tx) error {
+		txHandler := *c
+		txHandler.courierStore = pgconfig.WithTx(c.courierStore, tx)
+		txHandler.locationStore = pgconfig.WithTx(c.locationStore, tx)
 
-	return nil
+		return fn(&txHandler)
+	})
 }
@@ -18,25 +18,20 @@ func TestCourierEventHandler(t *testing.T) {
 
 	eventHandler := handlers.NewCourierEventHandler(courierStore, locationStore)
 
-	t.Run("creates courier and location on COURIER_CREATED_EVENT", func(t *testing.T) {
-		wantCourier := testdata.VolenCourier
-		wantLocation := models.Location{
-			CourierID: wantCourier.ID,
-			Lat:       0.0,
-			Lon:       0.0,
-		}
+	t.Run("creates courier without a location on COURIER_CREATED_EVENT", func(t *testing.T) {
+		want := testdata.VolenCourier
 
 		payload := svcevents.CourierCreatedEvent{
-			ID:   wantCourier.ID,
-			Name: wantCourier.Name,
+			ID:   want.ID,
+			Name: want.Name,
 		}
 		event := events.NewTypedEvent(svcevents.COURIER_CREATED_EVENT_ID, 1, payload)
 
 		err := eventHandler.HandleCourierCreatedEvent(event)
 
 		testutil.AssertNoErr(t, err)
-		testutil.AssertEqual(t, courierStore.CreatedCourier, wantCourier)
-		testutil.AssertEqual(t, locationStore.CreatedLocation, wantLocation)
+		testutil.AssertEqual(t, courierStore.CreatedCourier, want)
+		testutil.AssertEqual(t, locationStore.CreatedLocation, models.Location{})
 	})
 
 	t.Run("deletes courier and location on COURIER_DELETED_EVENT", func(t *testing.T) {
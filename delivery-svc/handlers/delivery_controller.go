@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -8,26 +9,33 @@ import (
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/VitoNaychev/food-app/validation"
+	"github.com/jackc/pgx/v5"
 )
 
 type DeliveryServer struct {
 	deliveryStore models.DeliveryStore
 	addressStore  models.AddressStore
+	publisher     events.EventPublisher
 
-	secretKey []byte
-	verifier  auth.Verifier
+	keys     auth.KeySet
+	verifier auth.Verifier
 }
 
-func NewDeliveryServer(secretKey []byte, deliveryStore models.DeliveryStore, addressStore models.AddressStore, courierStore models.CourierStore) *DeliveryServer {
+func NewDeliveryServer(keys auth.KeySet, deliveryStore models.DeliveryStore, addressStore models.AddressStore, courierStore models.CourierStore, publisher events.EventPublisher,
+	verifyJWT auth.VerifyJWTFunc) *DeliveryServer {
 	deliveryServer := DeliveryServer{
 		deliveryStore: deliveryStore,
 		addressStore:  addressStore,
+		publisher:     publisher,
 
-		secretKey: secretKey,
-		verifier:  NewCourierVerifier(courierStore),
+		keys:     keys,
+		verifier: NewCourierVerifier(courierStore, verifyJWT),
 	}
 
 	return &deliveryServer
@@ -36,9 +44,9 @@ func NewDeliveryServer(secretKey []byte, deliveryStore models.DeliveryStore, add
 func (d *DeliveryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		auth.AuthenticationMW(d.stateTransitionHandler, d.verifier, d.secretKey)(w, r)
+		auth.AuthenticationMW(d.stateTransitionHandler, d.verifier, d.keys, auth.COURIER)(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(d.getCurrentDelivery, d.verifier, d.secretKey)(w, r)
+		auth.AuthenticationMW(d.getCurrentDelivery, d.verifier, d.keys, auth.COURIER)(w, r)
 	}
 }
 
@@ -70,7 +78,14 @@ func (d *DeliveryServer) stateTransitionHandler(w http.ResponseWriter, r *http.R
 	}
 
 	delivery.State = deliverySM.Current()
-	err = d.deliveryStore.UpdateDelivery(&delivery)
+	err = d.inTx(r.Context(), func(d *DeliveryServer) error {
+		err := d.deliveryStore.UpdateDelivery(&delivery)
+		if err != nil {
+			return err
+		}
+
+		return d.sendDeliveryStateTransitionEvent(r.Context(), stateTransitionRequest.Event, delivery)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 		return
@@ -80,6 +95,65 @@ func (d *DeliveryServer) stateTransitionHandler(w http.ResponseWriter, r *http.R
 	json.NewEncoder(w).Encode(deliveryStateTransisionResponse)
 }
 
+func (d *DeliveryServer) sendDeliveryStateTransitionEvent(ctx context.Context, event models.DeliveryEvent, delivery models.Delivery) error {
+	switch event {
+	case models.PICKUP_DELIVERY:
+		payload := svcevents.DeliveryPickedUpEvent{
+			ID: delivery.ID,
+		}
+		event := svcevents.DeliveryPickedUp.New(delivery.ID, payload).WithTraceContext(ctx).WithCorrelationID(delivery.CorrelationID)
+
+		err := d.publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+		return err
+	case models.COMPLETE_DELIVERY:
+		payload := svcevents.DeliveryCompletedEvent{
+			ID:        delivery.ID,
+			CourierID: delivery.CourierID,
+		}
+		event := svcevents.DeliveryCompleted.New(delivery.ID, payload).WithTraceContext(ctx).WithCorrelationID(delivery.CorrelationID)
+
+		err := d.publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+		return err
+	case models.REJECT_HANDOVER_DELIVERY:
+		payload := svcevents.DeliveryHandoverRejectedEvent{
+			ID: delivery.ID,
+		}
+		event := svcevents.DeliveryHandoverRejected.New(delivery.ID, payload).WithTraceContext(ctx).WithCorrelationID(delivery.CorrelationID)
+
+		err := d.publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+		return err
+	case models.CANCEL_DELIVERY:
+		event := newDeliveryCanceledEvent(delivery.ID).WithTraceContext(ctx).WithCorrelationID(delivery.CorrelationID)
+
+		return d.publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+	default:
+		// no event needs to be published
+		return nil
+	}
+}
+
+// inTx runs fn with a copy of the server whose stores and publisher write
+// through a single transaction, so a delivery and its events are committed
+// together.
+func (d *DeliveryServer) inTx(ctx context.Context, fn func(d *DeliveryServer) error) error {
+	return pgconfig.RunInTx(ctx, d.deliveryStore, func(tx pgx.Tx) error {
+		txServer := *d
+		txServer.deliveryStore = pgconfig.WithTx(d.deliveryStore, tx)
+		txServer.addressStore = pgconfig.WithTx(d.addressStore, tx)
+		txServer.publisher = pgconfig.WithTx(d.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
+
+func newDeliveryCanceledEvent(deliveryID int) events.InterfaceEvent {
+	payload := svcevents.DeliveryCanceledEvent{
+		ID: deliveryID,
+	}
+
+	return svcevents.DeliveryCanceled.New(deliveryID, payload)
+}
+
 func (d *DeliveryServer) getCurrentDelivery(w http.ResponseWriter, r *http.Request) {
 	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
 
@@ -11,7 +11,10 @@ import (
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/delivery-svc/stubs"
 	"github.com/VitoNaychev/food-app/delivery-svc/testdata"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
 	"github.com/VitoNaychev/food-app/testutil/tabletests"
 	"github.com/VitoNaychev/food-app/validation"
 )
@@ -19,7 +22,7 @@ import (
 func TestDeliveryEndpointAuthentication(t *testing.T) {
 	courierStore := &stubs.StubCourierStore{}
 
-	server := handlers.NewDeliveryServer(env.SecretKey, nil, nil, courierStore)
+	server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), nil, nil, courierStore, &stubs.StubEventPublisher{}, dummies.DummyVerifyJWT)
 
 	invalidJWT := "invalidJWT"
 	cases := map[string]*http.Request{
@@ -42,9 +45,9 @@ func TestDeliveryRequestValidation(t *testing.T) {
 		Addresses: []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
 	}
 
-	server := handlers.NewDeliveryServer(env.SecretKey, deliveryStore, addressStore, courierStore)
+	server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, addressStore, courierStore, &stubs.StubEventPublisher{}, dummies.DummyVerifyJWT)
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 	cases := map[string]*http.Request{
 		"change ticket state": handlers.NewChangeDeliveryStateRequest(volenJWT, -1),
@@ -68,10 +71,12 @@ func TestDeliveryStateTransitions(t *testing.T) {
 		},
 	}
 
-	server := handlers.NewDeliveryServer(env.SecretKey, deliveryStore, nil, courierStore)
+	publisher := &stubs.StubEventPublisher{}
+
+	server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, nil, courierStore, publisher, dummies.DummyVerifyJWT)
 
 	t.Run("changes delivery state to ON_ROUTE on PICKUP_DELIVERY event", func(t *testing.T) {
-		aliceJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.AliceCourier.ID)
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.AliceCourier.ID, auth.COURIER)
 		want := testdata.AliceDelivery
 		want.State = models.ON_ROUTE
 
@@ -85,7 +90,7 @@ func TestDeliveryStateTransitions(t *testing.T) {
 	})
 
 	t.Run("returns delivery status on state transition request", func(t *testing.T) {
-		aliceJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.AliceCourier.ID)
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.AliceCourier.ID, auth.COURIER)
 
 		state, _ := models.StateValueToStateName(models.ON_ROUTE)
 		want := handlers.DeliveryStateTransitionResponse{
@@ -106,7 +111,7 @@ func TestDeliveryStateTransitions(t *testing.T) {
 	})
 
 	t.Run("changes delivery state to COMPLETED on COMPLETE_DELIVERY event", func(t *testing.T) {
-		johnJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.JohnCourier.ID)
+		johnJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.JohnCourier.ID, auth.COURIER)
 		want := testdata.JohnDelivery
 		want.State = models.COMPLETED
 
@@ -119,8 +124,86 @@ func TestDeliveryStateTransitions(t *testing.T) {
 		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, want)
 	})
 
+	t.Run("correlates published event with the order event the delivery was created by", func(t *testing.T) {
+		aliceDelivery := testdata.AliceDelivery
+		aliceDelivery.CorrelationID = "correlation-id"
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{aliceDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, nil, courierStore, publisher, dummies.DummyVerifyJWT)
+
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.AliceCourier.ID, auth.COURIER)
+		request := handlers.NewChangeDeliveryStateRequest(aliceJWT, models.PICKUP_DELIVERY)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, publisher.SpyEvent.Headers.CorrelationID, "correlation-id")
+	})
+
+	t.Run("publishes DELIVERY_PICKED_UP event on PICKUP_DELIVERY", func(t *testing.T) {
+		aliceDelivery := testdata.AliceDelivery
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{aliceDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, nil, courierStore, publisher, dummies.DummyVerifyJWT)
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: aliceDelivery.ID}
+		wantEvent := events.NewEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, aliceDelivery.ID, payload)
+
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.AliceCourier.ID, auth.COURIER)
+		request := handlers.NewChangeDeliveryStateRequest(aliceJWT, models.PICKUP_DELIVERY)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("publishes DELIVERY_HANDOVER_REJECTED event on REJECT_HANDOVER_DELIVERY", func(t *testing.T) {
+		aliceDelivery := testdata.AliceDelivery
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{aliceDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, nil, courierStore, publisher, dummies.DummyVerifyJWT)
+
+		payload := svcevents.DeliveryHandoverRejectedEvent{ID: aliceDelivery.ID}
+		wantEvent := events.NewEvent(svcevents.DELIVERY_HANDOVER_REJECTED_EVENT_ID, aliceDelivery.ID, payload)
+
+		aliceJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.AliceCourier.ID, auth.COURIER)
+		request := handlers.NewChangeDeliveryStateRequest(aliceJWT, models.REJECT_HANDOVER_DELIVERY)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery.State, models.READY_FOR_PICKUP)
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("publishes DELIVERY_COMPLETED event on COMPLETE_DELIVERY", func(t *testing.T) {
+		johnDelivery := testdata.JohnDelivery
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{johnDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, nil, courierStore, publisher, dummies.DummyVerifyJWT)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: johnDelivery.ID, CourierID: johnDelivery.CourierID}
+		wantEvent := events.NewEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, johnDelivery.ID, payload)
+
+		johnJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.JohnCourier.ID, auth.COURIER)
+		request := handlers.NewChangeDeliveryStateRequest(johnJWT, models.COMPLETE_DELIVERY)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
 	t.Run("returns Bad Request if courier doesn't have an active delivery", func(t *testing.T) {
-		ivoJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.IvoCourier.ID)
+		ivoJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.IvoCourier.ID, auth.COURIER)
 
 		request := handlers.NewChangeDeliveryStateRequest(ivoJWT, models.COMPLETE_DELIVERY)
 		response := httptest.NewRecorder()
@@ -145,12 +228,12 @@ func TestGetDelivery(t *testing.T) {
 		Addresses: []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
 	}
 
-	server := handlers.NewDeliveryServer(env.SecretKey, deliveryStore, addressStore, courierStore)
+	server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, addressStore, courierStore, &stubs.StubEventPublisher{}, dummies.DummyVerifyJWT)
 
 	t.Run("returns current delivery info on GET", func(t *testing.T) {
 		want := handlers.NewGetDeliveryResponse(testdata.VolenDelivery, testdata.VolenPickupAddress, testdata.VolenDeliveryAddress)
 
-		volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+		volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 		request, _ := http.NewRequest(http.MethodGet, "/delivery/", nil)
 		response := httptest.NewRecorder()
@@ -167,7 +250,7 @@ func TestGetDelivery(t *testing.T) {
 	})
 
 	t.Run("returns empty body on no active deliveries", func(t *testing.T) {
-		peterJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.PeterCourier.ID)
+		peterJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.PeterCourier.ID, auth.COURIER)
 
 		request, _ := http.NewRequest(http.MethodGet, "/delivery/", nil)
 		response := httptest.NewRecorder()
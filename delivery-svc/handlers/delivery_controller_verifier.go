@@ -3,17 +3,19 @@ package handlers
 import (
 	"errors"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type CourierVerifier struct {
-	store models.CourierStore
+	store     models.CourierStore
+	verifyJWT auth.VerifyJWTFunc
 }
 
-func NewCourierVerifier(store models.CourierStore) *CourierVerifier {
-	return &CourierVerifier{store}
-
+func NewCourierVerifier(store models.CourierStore, verifyJWT auth.VerifyJWTFunc) *CourierVerifier {
+	return &CourierVerifier{store, verifyJWT}
 }
 
 func (c *CourierVerifier) DoesSubjectExist(id int) (bool, error) {
@@ -25,3 +27,9 @@ func (c *CourierVerifier) DoesSubjectExist(id int) (bool, error) {
 	}
 	return true, nil
 }
+
+// Tokens are issued and revoked by courier-svc, so it's asked whether
+// the token was revoked.
+func (c *CourierVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsRemoteJWTRevoked(c.verifyJWT, token)
+}
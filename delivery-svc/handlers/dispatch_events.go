@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+)
+
+type DispatchEventHandler struct {
+	dispatcher *dispatch.Dispatcher
+}
+
+func NewDispatchEventHandler(dispatcher *dispatch.Dispatcher) *DispatchEventHandler {
+	dispatchEventHandler := DispatchEventHandler{
+		dispatcher: dispatcher,
+	}
+
+	return &dispatchEventHandler
+}
+
+func RegisterDispatchEventHandlers(eventConsumer events.EventConsumer, dispatchEventHandler *DispatchEventHandler) error {
+	err := svcevents.DeliveryCompleted.Register(eventConsumer, dispatchEventHandler.HandleDeliveryCompletedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.DeliveryCanceled.Register(eventConsumer, dispatchEventHandler.HandleDeliveryCanceledEvent)
+}
+
+func (d *DispatchEventHandler) HandleDeliveryCompletedEvent(event events.Event[svcevents.DeliveryCompletedEvent]) error {
+	return d.dispatcher.DispatchQueuedDeliveries(event.Context())
+}
+
+func (d *DispatchEventHandler) HandleDeliveryCanceledEvent(event events.Event[svcevents.DeliveryCanceledEvent]) error {
+	return d.dispatcher.DispatchQueuedDeliveries(event.Context())
+}
@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrNoActiveDeliveries = errors.New("courier doesn't have active deliveries")
+	ErrOfferNotPending    = errors.New("offer is no longer pending")
 )
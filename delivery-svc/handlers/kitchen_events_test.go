@@ -16,7 +16,9 @@ import (
 func TestKitchenEventHandler(t *testing.T) {
 	deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{testdata.VolenDelivery, testdata.PeterDelivery}}
 
-	eventHandler := handlers.NewKitchenEventHandler(deliveryStore)
+	publisher := &stubs.StubEventPublisher{}
+
+	eventHandler := handlers.NewKitchenEventHandler(deliveryStore, &stubs.StubOfferStore{}, publisher)
 
 	t.Run("updates delivery state on TICKET_BEGIN_PREPARING event", func(t *testing.T) {
 		readyBy, _ := time.Parse("2006-01-02 15:04:05", "2025-01-01 16:20:00")
@@ -54,6 +56,109 @@ func TestKitchenEventHandler(t *testing.T) {
 
 		testutil.AssertNoErr(t, err)
 		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, want)
+
+		wantEvent := events.NewEvent(svcevents.DELIVERY_CANCELED_EVENT_ID, want.ID, svcevents.DeliveryCanceledEvent{ID: want.ID})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("republishes DELIVERY_CANCELED on TICKET_CANCEL event for canceled delivery", func(t *testing.T) {
+		canceledDelivery := testdata.VolenDelivery
+		canceledDelivery.State = models.CANCELED
+
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{canceledDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(deliveryStore, &stubs.StubOfferStore{}, publisher)
+
+		payload := svcevents.TicketCancelEvent{
+			ID: canceledDelivery.ID,
+		}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_EVENT_ID, canceledDelivery.ID, payload)
+
+		err := eventHandler.HandleTicketCancelEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, models.Delivery{})
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.DELIVERY_CANCELED_EVENT_ID)
+	})
+
+	t.Run("expires pending offers of delivery on TICKET_CANCEL event", func(t *testing.T) {
+		delivery := testdata.VolenDelivery
+		delivery.CourierID = 0
+
+		offer := models.Offer{ID: 1, DeliveryID: delivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{delivery}}
+		offerStore := &stubs.StubOfferStore{Offers: []models.Offer{offer}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(deliveryStore, offerStore, publisher)
+
+		payload := svcevents.TicketCancelEvent{
+			ID: delivery.ID,
+		}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_EVENT_ID, delivery.ID, payload)
+
+		err := eventHandler.HandleTicketCancelEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, offerStore.UpdatedOffer.State, models.OFFER_EXPIRED)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery.State, models.CANCELED)
+	})
+
+	t.Run("cancels delivery on TICKET_DECLINED event", func(t *testing.T) {
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{testdata.VolenDelivery}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(deliveryStore, &stubs.StubOfferStore{}, publisher)
+
+		want := testdata.VolenDelivery
+		want.State = models.CANCELED
+
+		payload := svcevents.TicketDeclinedEvent{
+			ID:     want.ID,
+			Reason: svcevents.DECLINE_REASON_CLOSING,
+		}
+		event := events.NewTypedEvent(svcevents.TICKET_DECLINED_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketDeclinedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, want)
+
+		wantEvent := events.NewEvent(svcevents.DELIVERY_CANCELED_EVENT_ID, want.ID, svcevents.DeliveryCanceledEvent{ID: want.ID})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("cancels delivery and expires its pending offers on TICKET_REJECTED event", func(t *testing.T) {
+		delivery := testdata.VolenDelivery
+		delivery.CourierID = 0
+
+		offer := models.Offer{ID: 1, DeliveryID: delivery.ID, CourierID: testdata.VolenCourier.ID,
+			ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+
+		deliveryStore := &stubs.StubDeliveryStore{Deliveries: []models.Delivery{delivery}}
+		offerStore := &stubs.StubOfferStore{Offers: []models.Offer{offer}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(deliveryStore, offerStore, publisher)
+
+		payload := svcevents.TicketRejectedEvent{
+			ID:     delivery.ID,
+			Reason: "restaurant is closed",
+		}
+		event := events.NewTypedEvent(svcevents.TICKET_REJECTED_EVENT_ID, delivery.ID, payload)
+		event.Headers.EventID = event.ID
+
+		err := eventHandler.HandleTicketRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, offerStore.UpdatedOffer.State, models.OFFER_EXPIRED)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery.State, models.CANCELED)
+
+		wantEvent := events.NewEvent(svcevents.DELIVERY_CANCELED_EVENT_ID, delivery.ID, svcevents.DeliveryCanceledEvent{ID: delivery.ID})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.DELIVERY_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+		testutil.AssertEqual(t, publisher.SpyEvent.Headers.CausationID, event.ID)
 	})
 
 	t.Run("updates delivery state on TICKET_FINISH_PREPARING event", func(t *testing.T) {
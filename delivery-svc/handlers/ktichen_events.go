@@ -1,70 +1,160 @@
 package handlers
 
 import (
-	"reflect"
+	"context"
+	"time"
 
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
 )
 
 type KitchenEventHandler struct {
 	deliveryStore models.DeliveryStore
+	offerStore    models.OfferStore
+	publisher     events.EventPublisher
 }
 
-func NewKitchenEventHandler(deliveryStore models.DeliveryStore) *KitchenEventHandler {
+func NewKitchenEventHandler(deliveryStore models.DeliveryStore, offerStore models.OfferStore, publisher events.EventPublisher) *KitchenEventHandler {
 	kitchenEventHandler := KitchenEventHandler{
 		deliveryStore: deliveryStore,
+		offerStore:    offerStore,
+		publisher:     publisher,
 	}
 
 	return &kitchenEventHandler
 }
 
-func RegisterKitchenEventHandlers(eventConsumer events.EventConsumer, kitchenEventHandler *KitchenEventHandler) {
-	eventConsumer.RegisterEventHandler(svcevents.KITCHEN_EVENTS_TOPIC,
-		svcevents.TICKET_BEGIN_PREPARING_EVENT_ID,
-		events.EventHandlerWrapper(kitchenEventHandler.HandleTicketBeginPreparingEvent),
-		reflect.TypeOf(svcevents.TicketBeginPreparingEvent{}))
-	eventConsumer.RegisterEventHandler(svcevents.KITCHEN_EVENTS_TOPIC,
-		svcevents.TICKET_FINISH_PREPARING_EVENT_ID,
-		events.EventHandlerWrapper(kitchenEventHandler.HandleTicketFinishPreparingEvent),
-		reflect.TypeOf(svcevents.TicketFinishPreparingEvent{}))
-}
+func RegisterKitchenEventHandlers(eventConsumer events.EventConsumer, kitchenEventHandler *KitchenEventHandler) error {
+	err := svcevents.TicketBeginPreparing.Register(eventConsumer, kitchenEventHandler.HandleTicketBeginPreparingEvent)
+	if err != nil {
+		return err
+	}
 
-func (k *KitchenEventHandler) HandleTicketBeginPreparingEvent(event events.Event[svcevents.TicketBeginPreparingEvent]) error {
-	delivery, err := k.deliveryStore.GetDeliveryByID(event.Payload.ID)
+	err = svcevents.TicketFinishPreparing.Register(eventConsumer, kitchenEventHandler.HandleTicketFinishPreparingEvent)
 	if err != nil {
 		return err
 	}
 
-	err = k.applyEventToDelivery(&delivery, models.BEGIN_PREPARING_DELIVERY)
+	err = svcevents.TicketCancel.Register(eventConsumer, kitchenEventHandler.HandleTicketCancelEvent)
 	if err != nil {
 		return err
 	}
 
-	delivery.ReadyBy = event.Payload.ReadyBy
-	err = k.deliveryStore.UpdateDelivery(&delivery)
+	err = svcevents.TicketDeclined.Register(eventConsumer, kitchenEventHandler.HandleTicketDeclinedEvent)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return svcevents.TicketRejected.Register(eventConsumer, kitchenEventHandler.HandleTicketRejectedEvent)
+}
+
+func (k *KitchenEventHandler) HandleTicketBeginPreparingEvent(event events.Event[svcevents.TicketBeginPreparingEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		delivery, err := k.deliveryStore.LockDeliveryByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		err = k.applyEventToDelivery(&delivery, models.BEGIN_PREPARING_DELIVERY)
+		if err != nil {
+			return err
+		}
+
+		delivery.ReadyBy = event.Payload.ReadyBy
+		err = k.deliveryStore.UpdateDelivery(&delivery)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (k *KitchenEventHandler) HandleTicketCancelEvent(event events.Event[svcevents.TicketCancelEvent]) error {
-	err := k.applyEventAndUpdateDelivery(event.Payload.ID, models.CANCEL_DELIVERY)
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return k.cancelDelivery(event.Payload.ID, event.Headers)
+	})
+}
+
+func (k *KitchenEventHandler) HandleTicketDeclinedEvent(event events.Event[svcevents.TicketDeclinedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return k.cancelDelivery(event.Payload.ID, event.Headers)
+	})
+}
 
-	return err
+func (k *KitchenEventHandler) HandleTicketRejectedEvent(event events.Event[svcevents.TicketRejectedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return k.cancelDelivery(event.Payload.ID, event.Headers)
+	})
 }
 
 func (k *KitchenEventHandler) HandleTicketFinishPreparingEvent(event events.Event[svcevents.TicketFinishPreparingEvent]) error {
-	err := k.applyEventAndUpdateDelivery(event.Payload.ID, models.FINISH_PREPARING_DELIVERY)
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		err := k.applyEventAndUpdateDelivery(event.Payload.ID, models.FINISH_PREPARING_DELIVERY)
+
+		return err
+	})
+}
+
+func (k *KitchenEventHandler) cancelDelivery(deliveryID int, cause events.EventHeaders) error {
+	delivery, err := k.deliveryStore.LockDeliveryByID(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	// The kitchen publishes TICKET_CANCEL again when ORDER_CANCELED is
+	// redelivered, so the delivery may already be canceled.
+	if delivery.State != models.CANCELED {
+		err = k.withdrawPendingOffers(delivery)
+		if err != nil {
+			return err
+		}
+
+		err = k.applyEventAndUpdateDelivery(deliveryID, models.CANCEL_DELIVERY)
+		if err != nil {
+			return err
+		}
+	}
 
-	return err
+	event := newDeliveryCanceledEvent(deliveryID).CausedBy(cause)
+
+	return k.publisher.Publish(svcevents.DELIVERY_EVENTS_TOPIC, event)
+}
+
+// Pending offers of a canceled delivery are expired while the delivery can
+// still be offered, so couriers can't accept them and the dispatcher doesn't
+// try to expire them later.
+func (k *KitchenEventHandler) withdrawPendingOffers(delivery models.Delivery) error {
+	offers, err := k.offerStore.GetOffersByDeliveryID(delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if offer.State != models.OFFER_PENDING {
+			continue
+		}
+
+		deliverySM := models.NewDeliveryOfferSM(&delivery, &offer, time.Now())
+		err = deliverySM.Exec(models.EXPIRE_DELIVERY_OFFER)
+		if err != nil {
+			return err
+		}
+
+		err = k.offerStore.UpdateOffer(&offer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (k *KitchenEventHandler) applyEventAndUpdateDelivery(deliveryId int, event models.DeliveryEvent) error {
-	delivery, err := k.deliveryStore.GetDeliveryByID(deliveryId)
+	delivery, err := k.deliveryStore.LockDeliveryByID(deliveryId)
 	if err != nil {
 		return err
 	}
@@ -92,3 +182,17 @@ func (k *KitchenEventHandler) applyEventToDelivery(delivery *models.Delivery, ev
 	delivery.State = deliverySM.Current()
 	return nil
 }
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (k *KitchenEventHandler) inTx(ctx context.Context, fn func(k *KitchenEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, k.deliveryStore, func(tx pgx.Tx) error {
+		txHandler := *k
+		txHandler.deliveryStore = pgconfig.WithTx(k.deliveryStore, tx)
+		txHandler.offerStore = pgconfig.WithTx(k.offerStore, tx)
+		txHandler.publisher = pgconfig.WithTx(k.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -2,30 +2,36 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/httperrors"
+	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/VitoNaychev/food-app/validation"
 )
 
 type LocationServer struct {
-	secretKey []byte
-	verifier  auth.Verifier
+	keys     auth.KeySet
+	verifier auth.Verifier
 
 	locationStore models.LocationStore
 	courierStore  models.CourierStore
+	dispatcher    *dispatch.Dispatcher
 }
 
-func NewLocationServer(secretKey []byte, locationStore models.LocationStore, courierStore models.CourierStore) *LocationServer {
+func NewLocationServer(keys auth.KeySet, locationStore models.LocationStore, courierStore models.CourierStore,
+	dispatcher *dispatch.Dispatcher, verifyJWT auth.VerifyJWTFunc) *LocationServer {
 	locationServer := LocationServer{
-		secretKey: secretKey,
-		verifier:  NewCourierVerifier(courierStore),
+		keys:     keys,
+		verifier: NewCourierVerifier(courierStore, verifyJWT),
 
 		locationStore: locationStore,
 		courierStore:  courierStore,
+		dispatcher:    dispatcher,
 	}
 
 	return &locationServer
@@ -34,9 +40,9 @@ func NewLocationServer(secretKey []byte, locationStore models.LocationStore, cou
 func (l *LocationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		auth.AuthenticationMW(l.updateLocation, l.verifier, l.secretKey)(w, r)
+		auth.AuthenticationMW(l.updateLocation, l.verifier, l.keys, auth.COURIER)(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(l.getLocation, l.verifier, l.secretKey)(w, r)
+		auth.AuthenticationMW(l.getLocation, l.verifier, l.keys, auth.COURIER)(w, r)
 	}
 }
 
@@ -55,11 +61,22 @@ func (l *LocationServer) updateLocation(w http.ResponseWriter, r *http.Request)
 		Lon:       updateLocationRequest.Lon,
 	}
 	err = l.locationStore.UpdateLocation(&location)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		err = l.locationStore.CreateLocation(&location)
+	}
 	if err != nil {
 		httperrors.HandleBadRequest(w, err)
 		return
 	}
 
+	// Deliveries that found no courier stay queued until someone is near
+	// enough to take them, so every reported location may unblock one.
+	err = l.dispatcher.DispatchQueuedDeliveries(r.Context())
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
 	json.NewEncoder(w).Encode(location)
 }
 
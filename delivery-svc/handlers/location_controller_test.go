@@ -6,15 +6,23 @@ import (
 	"testing"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/delivery-svc/stubs"
 	"github.com/VitoNaychev/food-app/delivery-svc/testdata"
+	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
 	"github.com/VitoNaychev/food-app/testutil/tabletests"
 	"github.com/VitoNaychev/food-app/validation"
 )
 
+func newLocationDispatcher(courierStore *stubs.StubCourierStore) *dispatch.Dispatcher {
+	return dispatch.NewDispatcher(courierStore, &stubs.StubDeliveryStore{}, &stubs.StubAddressStore{}, &stubs.StubOfferStore{},
+		&stubs.StubEventPublisher{}, dispatch.DefaultDispatcherConfig)
+}
+
 func TestLocationRequestValidation(t *testing.T) {
 	courierStore := &stubs.StubCourierStore{
 		Couriers: []models.Courier{testdata.VolenCourier},
@@ -23,9 +31,9 @@ func TestLocationRequestValidation(t *testing.T) {
 		Locations: []models.Location{testdata.VolenLocation},
 	}
 
-	server := handlers.NewLocationServer(env.SecretKey, locationStore, courierStore)
+	server := handlers.NewLocationServer(auth.HMACKey(env.SecretKey), locationStore, courierStore, newLocationDispatcher(courierStore), dummies.DummyVerifyJWT)
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 	cases := map[string]*http.Request{
 		"update location": handlers.NewUpdateLocationRequest(volenJWT, 361, 181),
@@ -36,16 +44,30 @@ func TestLocationRequestValidation(t *testing.T) {
 
 func TestUpdateCourierLocation(t *testing.T) {
 	courierStore := &stubs.StubCourierStore{
-		Couriers: []models.Courier{testdata.VolenCourier},
+		Couriers:          []models.Courier{testdata.VolenCourier, testdata.PeterCourier},
+		AvailableCouriers: []models.AvailableCourier{testdata.PeterAvailableCourier},
 	}
 
 	locationStore := &stubs.StubLocationStore{
 		Locations: []models.Location{testdata.VolenLocation},
 	}
 
-	server := handlers.NewLocationServer(env.SecretKey, locationStore, courierStore)
+	queuedDelivery := testdata.VolenDelivery
+	queuedDelivery.CourierID = 0
+	deliveryStore := &stubs.StubDeliveryStore{
+		Deliveries: []models.Delivery{queuedDelivery},
+	}
+	addressStore := &stubs.StubAddressStore{
+		Addresses: []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
+	}
+	offerStore := &stubs.StubOfferStore{}
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		&stubs.StubEventPublisher{}, dispatch.DefaultDispatcherConfig)
+	server := handlers.NewLocationServer(auth.HMACKey(env.SecretKey), locationStore, courierStore, dispatcher, dummies.DummyVerifyJWT)
+
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
+	peterJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.PeterCourier.ID, auth.COURIER)
 
 	t.Run("updates courier location", func(t *testing.T) {
 		want := models.Location{
@@ -66,6 +88,21 @@ func TestUpdateCourierLocation(t *testing.T) {
 		testutil.AssertNoErr(t, err)
 		testutil.AssertEqual(t, got, want)
 	})
+
+	t.Run("creates location on first report and offers queued delivery", func(t *testing.T) {
+		want := testdata.PeterLocation
+
+		request := handlers.NewUpdateLocationRequest(peterJWT, want.Lat, want.Lon)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, locationStore.CreatedLocation, want)
+
+		testutil.AssertEqual(t, offerStore.CreatedOffer.DeliveryID, queuedDelivery.ID)
+		testutil.AssertEqual(t, offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
 }
 
 func TestGetLocation(t *testing.T) {
@@ -77,9 +114,9 @@ func TestGetLocation(t *testing.T) {
 		Locations: []models.Location{testdata.VolenLocation},
 	}
 
-	server := handlers.NewLocationServer(env.SecretKey, locationStore, courierStore)
+	server := handlers.NewLocationServer(auth.HMACKey(env.SecretKey), locationStore, courierStore, newLocationDispatcher(courierStore), dummies.DummyVerifyJWT)
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 	t.Run("gets courier location", func(t *testing.T) {
 		want := handlers.LocationToGetLocationResponse(testdata.VolenLocation)
@@ -98,3 +135,32 @@ func TestGetLocation(t *testing.T) {
 		testutil.AssertEqual(t, got, want)
 	})
 }
+
+func TestLocationRevokedJWT(t *testing.T) {
+	courierStore := &stubs.StubCourierStore{
+		Couriers: []models.Courier{testdata.VolenCourier},
+	}
+
+	locationStore := &stubs.StubLocationStore{
+		Locations: []models.Location{testdata.VolenLocation},
+	}
+
+	revokedVerifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+		return msgtypes.AuthResponse{Status: msgtypes.REVOKED}, nil
+	}
+	server := handlers.NewLocationServer(auth.HMACKey(env.SecretKey), locationStore, courierStore, newLocationDispatcher(courierStore), revokedVerifyJWT)
+
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
+
+	t.Run("returns Unauthorized on JWT revoked by courier-svc", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/delivery/location/", nil)
+		response := httptest.NewRecorder()
+
+		request.Header.Add("Token", volenJWT)
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+}
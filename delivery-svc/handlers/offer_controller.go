@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/httperrors"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/validation"
+)
+
+type OfferServer struct {
+	offerStore    models.OfferStore
+	deliveryStore models.DeliveryStore
+	addressStore  models.AddressStore
+	dispatcher    *dispatch.Dispatcher
+
+	keys     auth.KeySet
+	verifier auth.Verifier
+	*http.ServeMux
+}
+
+func NewOfferServer(keys auth.KeySet, offerStore models.OfferStore, deliveryStore models.DeliveryStore,
+	addressStore models.AddressStore, courierStore models.CourierStore, dispatcher *dispatch.Dispatcher,
+	verifyJWT auth.VerifyJWTFunc) *OfferServer {
+	offerServer := OfferServer{
+		offerStore:    offerStore,
+		deliveryStore: deliveryStore,
+		addressStore:  addressStore,
+		dispatcher:    dispatcher,
+
+		keys:     keys,
+		verifier: NewCourierVerifier(courierStore, verifyJWT),
+	}
+
+	router := http.NewServeMux()
+	router.Handle("/delivery/offer/", auth.AuthenticationMW(offerServer.getPendingOffers, offerServer.verifier, keys, auth.COURIER))
+	router.Handle("/delivery/offer/accept/", auth.AuthenticationMW(offerServer.acceptOffer, offerServer.verifier, keys, auth.COURIER))
+	router.Handle("/delivery/offer/decline/", auth.AuthenticationMW(offerServer.declineOffer, offerServer.verifier, keys, auth.COURIER))
+
+	offerServer.ServeMux = router
+
+	return &offerServer
+}
+
+func (o *OfferServer) getPendingOffers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
+
+	offers, err := o.offerStore.GetPendingOffersByCourierID(courierID)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	response := []GetOfferResponse{}
+	for _, offer := range offers {
+		delivery, err := o.deliveryStore.GetDeliveryByID(offer.DeliveryID)
+		if err != nil {
+			httperrors.HandleInternalServerError(w, err)
+			return
+		}
+
+		pickupAddress, err := o.addressStore.GetAddressByID(delivery.PickupAddressID)
+		if err != nil {
+			httperrors.HandleInternalServerError(w, err)
+			return
+		}
+
+		deliveryAddress, err := o.addressStore.GetAddressByID(delivery.DeliveryAddressID)
+		if err != nil {
+			httperrors.HandleInternalServerError(w, err)
+			return
+		}
+
+		response = append(response, NewGetOfferResponse(offer, delivery, pickupAddress, deliveryAddress))
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
+func (o *OfferServer) acceptOffer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
+
+	offerRequest, err := validation.ValidateBody[OfferRequest](r.Body)
+	if err != nil {
+		httperrors.HandleBadRequest(w, err)
+		return
+	}
+
+	delivery, err := o.dispatcher.AcceptOffer(r.Context(), courierID, offerRequest.ID)
+	if err != nil {
+		handleOfferError(w, err)
+		return
+	}
+
+	response := NewDeliveryStateTransitionResponse(delivery)
+	json.NewEncoder(w).Encode(response)
+}
+
+func (o *OfferServer) declineOffer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	courierID, _ := strconv.Atoi(r.Header.Get("Subject"))
+
+	offerRequest, err := validation.ValidateBody[OfferRequest](r.Body)
+	if err != nil {
+		httperrors.HandleBadRequest(w, err)
+		return
+	}
+
+	offer, err := o.dispatcher.DeclineOffer(r.Context(), courierID, offerRequest.ID)
+	if err != nil {
+		handleOfferError(w, err)
+		return
+	}
+
+	response := NewOfferStateResponse(offer)
+	json.NewEncoder(w).Encode(response)
+}
+
+func handleOfferError(w http.ResponseWriter, err error) {
+	if errors.Is(err, dispatch.ErrOfferNotFound) {
+		httperrors.HandleNotFound(w, err)
+	} else if errors.Is(err, sm.ErrInvalidEvent) {
+		httperrors.HandleBadRequest(w, ErrOfferNotPending)
+	} else {
+		httperrors.HandleInternalServerError(w, err)
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/VitoNaychev/food-app/reqbuilder"
+)
+
+func NewGetPendingOffersRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodGet, "/delivery/offer/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
+
+func NewAcceptOfferRequest(jwt string, offerID int) *http.Request {
+	offerRequest := OfferRequest{
+		ID: offerID,
+	}
+
+	request := reqbuilder.NewRequestWithBody(http.MethodPost, "/delivery/offer/accept/", offerRequest)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
+
+func NewDeclineOfferRequest(jwt string, offerID int) *http.Request {
+	offerRequest := OfferRequest{
+		ID: offerID,
+	}
+
+	request := reqbuilder.NewRequestWithBody(http.MethodPost, "/delivery/offer/decline/", offerRequest)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
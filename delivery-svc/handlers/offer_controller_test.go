@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
+	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/delivery-svc/stubs"
+	"github.com/VitoNaychev/food-app/delivery-svc/testdata"
+	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
+	"github.com/VitoNaychev/food-app/testutil/tabletests"
+	"github.com/VitoNaychev/food-app/validation"
+)
+
+func newOfferServer(offers []models.Offer) (*handlers.OfferServer, *stubs.StubOfferStore, *stubs.StubDeliveryStore) {
+	courierStore := &stubs.StubCourierStore{
+		Couriers:          []models.Courier{testdata.VolenCourier, testdata.PeterCourier},
+		AvailableCouriers: []models.AvailableCourier{testdata.VolenAvailableCourier, testdata.PeterAvailableCourier},
+	}
+	unassignedDelivery := testdata.VolenDelivery
+	unassignedDelivery.CourierID = 0
+	deliveryStore := &stubs.StubDeliveryStore{
+		Deliveries: []models.Delivery{unassignedDelivery},
+	}
+	addressStore := &stubs.StubAddressStore{
+		Addresses: []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
+	}
+	offerStore := &stubs.StubOfferStore{Offers: offers}
+
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		&stubs.StubEventPublisher{}, dispatch.DefaultDispatcherConfig)
+	server := handlers.NewOfferServer(auth.HMACKey(env.SecretKey), offerStore, deliveryStore, addressStore, courierStore, dispatcher, dummies.DummyVerifyJWT)
+
+	return server, offerStore, deliveryStore
+}
+
+func newVolenOffer() models.Offer {
+	return models.Offer{
+		ID:         1,
+		DeliveryID: testdata.VolenDelivery.ID,
+		CourierID:  testdata.VolenCourier.ID,
+		ExpiresAt:  time.Now().Add(time.Minute).Round(0),
+		State:      models.OFFER_PENDING,
+	}
+}
+
+func TestOfferEndpointAuthentication(t *testing.T) {
+	server, _, _ := newOfferServer(nil)
+
+	invalidJWT := "invalidJWT"
+	cases := map[string]*http.Request{
+		"get pending offers": handlers.NewGetPendingOffersRequest(invalidJWT),
+		"accept offer":       handlers.NewAcceptOfferRequest(invalidJWT, 1),
+		"decline offer":      handlers.NewDeclineOfferRequest(invalidJWT, 1),
+	}
+
+	tabletests.RunAuthenticationTests(t, server, cases)
+}
+
+func TestOfferRequestValidation(t *testing.T) {
+	server, _, _ := newOfferServer(nil)
+
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
+
+	cases := map[string]*http.Request{
+		"accept offer":  handlers.NewAcceptOfferRequest(volenJWT, 0),
+		"decline offer": handlers.NewDeclineOfferRequest(volenJWT, 0),
+	}
+
+	tabletests.RunRequestValidationTests(t, server, cases)
+}
+
+func TestOfferEndpoints(t *testing.T) {
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
+	peterJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.PeterCourier.ID, auth.COURIER)
+
+	t.Run("lists courier's pending offers", func(t *testing.T) {
+		offer := newVolenOffer()
+		server, _, _ := newOfferServer([]models.Offer{offer})
+
+		request := handlers.NewGetPendingOffersRequest(volenJWT)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		unassignedDelivery := testdata.VolenDelivery
+		unassignedDelivery.CourierID = 0
+		want := []handlers.GetOfferResponse{
+			handlers.NewGetOfferResponse(offer, unassignedDelivery, testdata.VolenPickupAddress, testdata.VolenDeliveryAddress),
+		}
+
+		got, err := validation.ValidateBody[[]handlers.GetOfferResponse](response.Body)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(got), len(want))
+		testutil.AssertEqual(t, got[0].ID, want[0].ID)
+		testutil.AssertEqual(t, got[0].Delivery, want[0].Delivery)
+	})
+
+	t.Run("assigns delivery on accept", func(t *testing.T) {
+		server, offerStore, deliveryStore := newOfferServer([]models.Offer{newVolenOffer()})
+
+		request := handlers.NewAcceptOfferRequest(volenJWT, 1)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, testdata.VolenDelivery)
+		testutil.AssertEqual(t, offerStore.UpdatedOffer.State, models.OFFER_ACCEPTED)
+	})
+
+	t.Run("re-offers delivery on decline", func(t *testing.T) {
+		server, offerStore, _ := newOfferServer([]models.Offer{newVolenOffer()})
+
+		request := handlers.NewDeclineOfferRequest(volenJWT, 1)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		want := handlers.OfferStateResponse{ID: 1, State: "declined"}
+		got, err := validation.ValidateBody[handlers.OfferStateResponse](response.Body)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, want)
+
+		testutil.AssertEqual(t, offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
+
+	t.Run("returns Not Found on another courier's offer", func(t *testing.T) {
+		server, _, _ := newOfferServer([]models.Offer{newVolenOffer()})
+
+		request := handlers.NewAcceptOfferRequest(peterJWT, 1)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("returns Bad Request on expired offer", func(t *testing.T) {
+		offer := newVolenOffer()
+		offer.ExpiresAt = time.Now().Add(-time.Minute)
+		server, _, _ := newOfferServer([]models.Offer{offer})
+
+		request := handlers.NewAcceptOfferRequest(volenJWT, 1)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+	t.Run("returns Bad Request on declining an offer that is no longer pending", func(t *testing.T) {
+		offer := newVolenOffer()
+		offer.State = models.OFFER_EXPIRED
+		server, offerStore, deliveryStore := newOfferServer([]models.Offer{offer})
+
+		request := handlers.NewDeclineOfferRequest(volenJWT, 1)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrOfferNotPending)
+		testutil.AssertEqual(t, offerStore.UpdatedOffer, models.Offer{})
+		testutil.AssertEqual(t, deliveryStore.UpdatedDelivery, models.Delivery{})
+	})
+}
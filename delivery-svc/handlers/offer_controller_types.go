@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+)
+
+type OfferRequest struct {
+	ID int `validate:"min=1,required"    json:"id"`
+}
+
+type OfferStateResponse struct {
+	ID    int    `validate:"required,min=1"    json:"id"`
+	State string `validate:"required"          json:"state"`
+}
+
+func NewOfferStateResponse(offer models.Offer) OfferStateResponse {
+	stateName, _ := models.OfferStateValueToStateName(offer.State)
+
+	return OfferStateResponse{
+		ID:    offer.ID,
+		State: stateName,
+	}
+}
+
+type GetOfferResponse struct {
+	ID        int                 `validate:"required,min=1"    json:"id"`
+	ExpiresAt time.Time           `validate:"required"          json:"expires_at"`
+	Delivery  GetDeliveryResponse `validate:"required"          json:"delivery"`
+}
+
+func NewGetOfferResponse(offer models.Offer, delivery models.Delivery, pickupAddress models.Address, deliveryAddress models.Address) GetOfferResponse {
+	return GetOfferResponse{
+		ID:        offer.ID,
+		ExpiresAt: offer.ExpiresAt,
+		Delivery:  NewGetDeliveryResponse(delivery, pickupAddress, deliveryAddress),
+	}
+}
@@ -1,57 +1,60 @@
 package handlers
 
 import (
-	"reflect"
+	"context"
 	"time"
 
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
 )
 
 type OrderEventHandler struct {
 	deliveryStore models.DeliveryStore
 	addressStore  models.AddressStore
+	dispatcher    *dispatch.Dispatcher
 }
 
-func NewOrderEventHandler(deliveryStore models.DeliveryStore, addressStore models.AddressStore) *OrderEventHandler {
+func NewOrderEventHandler(deliveryStore models.DeliveryStore, addressStore models.AddressStore, dispatcher *dispatch.Dispatcher) *OrderEventHandler {
 	return &OrderEventHandler{
 		deliveryStore: deliveryStore,
 		addressStore:  addressStore,
+		dispatcher:    dispatcher,
 	}
 }
 
-func RegisterOrderEventHandlers(eventConsumer events.EventConsumer, orderEventhandler *OrderEventHandler) {
-	eventConsumer.RegisterEventHandler(svcevents.ORDER_EVENTS_TOPIC,
-		svcevents.ORDER_CREATED_EVENT_ID,
-		events.EventHandlerWrapper(orderEventhandler.HandleOrderCreatedEvent),
-		reflect.TypeOf(svcevents.OrderCreatedEvent{}))
+func RegisterOrderEventHandlers(eventConsumer events.EventConsumer, orderEventhandler *OrderEventHandler) error {
+	return svcevents.OrderCreated.Register(eventConsumer, orderEventhandler.HandleOrderCreatedEvent)
 }
 
 func (o *OrderEventHandler) HandleOrderCreatedEvent(event events.Event[svcevents.OrderCreatedEvent]) error {
-	pickupAddress := AddressFromOrderCreatedEventAddress(event.Payload.PickupAddress)
-	err := o.addressStore.CreateAddress(&pickupAddress)
-	if err != nil {
-		return err
-	}
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		pickupAddress := AddressFromOrderCreatedEventAddress(event.Payload.PickupAddress)
+		err := o.addressStore.CreateAddress(&pickupAddress)
+		if err != nil {
+			return err
+		}
 
-	deliveryAddress := AddressFromOrderCreatedEventAddress(event.Payload.DeliveryAddress)
-	err = o.addressStore.CreateAddress(&deliveryAddress)
-	if err != nil {
-		return err
-	}
+		deliveryAddress := AddressFromOrderCreatedEventAddress(event.Payload.DeliveryAddress)
+		err = o.addressStore.CreateAddress(&deliveryAddress)
+		if err != nil {
+			return err
+		}
 
-	delivery := DeliveryFromOrderCreatedEvent(event.Payload)
-	// AssignDeliveryToAvailableCourier(delivery)
-	delivery.CourierID = 1
-	delivery.ReadyBy = models.ZeroTime
+		delivery := DeliveryFromOrderCreatedEvent(event.Payload)
+		delivery.ReadyBy = models.ZeroTime
+		delivery.CorrelationID = event.Headers.CorrelationID
 
-	err = o.deliveryStore.CreateDelivery(&delivery)
-	if err != nil {
-		return err
-	}
+		err = o.deliveryStore.CreateDelivery(&delivery)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return o.dispatcher.OfferDelivery(event.Context(), delivery)
+	})
 }
 
 func DeliveryFromOrderCreatedEvent(orderCreatedEvent svcevents.OrderCreatedEvent) models.Delivery {
@@ -79,3 +82,16 @@ func AddressFromOrderCreatedEventAddress(orderCreatedEventAddress svcevents.Orde
 
 	return address
 }
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (o *OrderEventHandler) inTx(ctx context.Context, fn func(o *OrderEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, o.deliveryStore, func(tx pgx.Tx) error {
+		txHandler := *o
+		txHandler.deliveryStore = pgconfig.WithTx(o.deliveryStore, tx)
+		txHandler.addressStore = pgconfig.WithTx(o.addressStore, tx)
+		txHandler.dispatcher = pgconfig.WithTx(o.dispatcher, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"testing"
 
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/delivery-svc/stubs"
@@ -15,12 +16,25 @@ import (
 
 func TestOrderCreatedEventHandler(t *testing.T) {
 	deliveryStore := &stubs.StubDeliveryStore{}
-	addressStore := &stubs.StubAddressStore{CreatedAddresses: []models.Address{}}
+	addressStore := &stubs.StubAddressStore{
+		CreatedAddresses: []models.Address{},
+		Addresses:        []models.Address{testdata.VolenPickupAddress, testdata.VolenDeliveryAddress},
+	}
+	courierStore := &stubs.StubCourierStore{
+		Couriers:          []models.Courier{testdata.VolenCourier, testdata.PeterCourier},
+		AvailableCouriers: []models.AvailableCourier{testdata.VolenAvailableCourier, testdata.PeterAvailableCourier},
+	}
+	offerStore := &stubs.StubOfferStore{}
 
-	eventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore)
+	publisher := &stubs.StubEventPublisher{}
 
-	t.Run("creates corresponding delivery", func(t *testing.T) {
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		publisher, dispatch.DefaultDispatcherConfig)
+	eventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore, dispatcher)
+
+	t.Run("creates corresponding unassigned delivery", func(t *testing.T) {
 		wantDelivery := testdata.VolenDelivery
+		wantDelivery.CourierID = 0
 		wantPickupAddress := testdata.VolenPickupAddress
 		wantDeliveryAddress := testdata.VolenDeliveryAddress
 
@@ -38,4 +52,39 @@ func TestOrderCreatedEventHandler(t *testing.T) {
 		testutil.AssertEqual(t, addressStore.CreatedAddresses[0], wantPickupAddress)
 		testutil.AssertEqual(t, addressStore.CreatedAddresses[1], wantDeliveryAddress)
 	})
+
+	t.Run("stores correlation of the order event on the delivery", func(t *testing.T) {
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, testdata.PeterOrderCreatedEvent)
+		event.Headers = events.EventHeaders{EventID: event.ID, CorrelationID: "correlation-id"}
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, deliveryStore.CreatedDelivery.CorrelationID, "correlation-id")
+	})
+
+	t.Run("offers delivery to nearest courier", func(t *testing.T) {
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, testdata.PeterOrderCreatedEvent)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, offerStore.CreatedOffer.DeliveryID, testdata.VolenDelivery.ID)
+		testutil.AssertEqual(t, offerStore.CreatedOffer.CourierID, testdata.VolenCourier.ID)
+		testutil.AssertEqual(t, offerStore.CreatedOffer.State, models.OFFER_PENDING)
+	})
+
+	t.Run("offers delivery to next nearest courier when nearest one is busy", func(t *testing.T) {
+		courierStore.AvailableCouriers = []models.AvailableCourier{testdata.PeterAvailableCourier}
+		defer func() {
+			courierStore.AvailableCouriers = []models.AvailableCourier{testdata.VolenAvailableCourier, testdata.PeterAvailableCourier}
+		}()
+
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, testdata.PeterOrderCreatedEvent)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, offerStore.CreatedOffer.CourierID, testdata.PeterCourier.ID)
+	})
 }
@@ -5,17 +5,18 @@ import (
 )
 
 type RouterServer struct {
-	http.Handler
+	*http.ServeMux
 }
 
-func NewRouterServer(deliveryServer *DeliveryServer, locationServer *LocationServer) *RouterServer {
+func NewRouterServer(deliveryServer *DeliveryServer, locationServer *LocationServer, offerServer *OfferServer) *RouterServer {
 	routerServer := new(RouterServer)
 
 	router := http.NewServeMux()
 	router.Handle("/delivery/", deliveryServer)
 	router.Handle("/delivery/location/", locationServer)
+	router.Handle("/delivery/offer/", offerServer)
 
-	routerServer.Handler = router
+	routerServer.ServeMux = router
 
 	return routerServer
 }
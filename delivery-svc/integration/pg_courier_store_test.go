@@ -3,6 +3,7 @@ package integration
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
@@ -29,29 +30,26 @@ func TestCourierEventHandlerIntegration(t *testing.T) {
 
 	courierEventHandler := handlers.NewCourierEventHandler(courierStore, locationStore)
 
-	t.Run("creates new courier and initial location", func(t *testing.T) {
-		wantCourier := testdata.VolenCourier
-		wantLocation := models.Location{CourierID: wantCourier.ID}
+	t.Run("creates new courier without a location", func(t *testing.T) {
+		want := testdata.VolenCourier
 
 		payload := svcevents.CourierCreatedEvent{
-			ID:   wantCourier.ID,
-			Name: wantCourier.Name,
+			ID:   want.ID,
+			Name: want.Name,
 		}
-		event := events.NewTypedEvent(svcevents.COURIER_CREATED_EVENT_ID, wantCourier.ID, payload)
+		event := events.NewTypedEvent(svcevents.COURIER_CREATED_EVENT_ID, want.ID, payload)
 
 		err := courierEventHandler.HandleCourierCreatedEvent(event)
 		testutil.AssertNoErr(t, err)
 
-		gotCourier, err := courierStore.GetCourierByID(wantCourier.ID)
+		got, err := courierStore.GetCourierByID(want.ID)
 
 		testutil.AssertNoErr(t, err)
-		testutil.AssertEqual(t, gotCourier, wantCourier)
-
-		gotLocation, err := locationStore.GetLocationByCourierID(wantCourier.ID)
+		testutil.AssertEqual(t, got, want)
 
-		testutil.AssertNoErr(t, err)
-		testutil.AssertEqual(t, gotLocation, wantLocation)
+		_, err = locationStore.GetLocationByCourierID(want.ID)
 
+		testutil.AssertError(t, err, storeerrors.ErrNotFound)
 	})
 
 	t.Run("deletes courier and associated location", func(t *testing.T) {
@@ -74,3 +72,56 @@ func TestCourierEventHandlerIntegration(t *testing.T) {
 		testutil.AssertError(t, err, storeerrors.ErrNotFound)
 	})
 }
+
+func TestGetAvailableCouriers(t *testing.T) {
+	config := pgconfig.GetConfigFromEnv(env)
+	integrationutil.SetupDatabaseContainer(t, &config, "../sql-scripts/init.sql")
+
+	connStr := config.GetConnectionString()
+
+	courierStore, err := models.NewPgCourierStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	locationStore, err := models.NewPgLocationStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	addressStore, err := models.NewPgAddressStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+	initAddressesTable(t, addressStore)
+
+	deliveryStore, err := models.NewPgDeliveryStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	offerStore, err := models.NewPgOfferStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	for _, courier := range []models.Courier{testdata.VolenCourier, testdata.PeterCourier, testdata.AliceCourier, testdata.JohnCourier} {
+		testutil.AssertNoErr(t, courierStore.CreateCourier(&courier))
+	}
+
+	aliceLocation := models.Location{CourierID: testdata.AliceCourier.ID, Lat: testdata.PeterLocation.Lat, Lon: testdata.PeterLocation.Lon}
+	for _, location := range []models.Location{testdata.VolenLocation, testdata.PeterLocation, aliceLocation} {
+		testutil.AssertNoErr(t, locationStore.CreateLocation(&location))
+	}
+
+	activeDelivery := testdata.VolenActiveDelivery
+	testutil.AssertNoErr(t, deliveryStore.CreateDelivery(&activeDelivery))
+
+	queuedDelivery := testdata.VolenDelivery
+	queuedDelivery.ID = 2
+	queuedDelivery.CourierID = 0
+	testutil.AssertNoErr(t, deliveryStore.CreateDelivery(&queuedDelivery))
+
+	offer := models.Offer{DeliveryID: queuedDelivery.ID, CourierID: testdata.PeterCourier.ID,
+		ExpiresAt: time.Now().Add(time.Minute), State: models.OFFER_PENDING}
+	testutil.AssertNoErr(t, offerStore.CreateOffer(&offer))
+
+	t.Run("returns located couriers without an active delivery or a pending offer", func(t *testing.T) {
+		want := []models.AvailableCourier{{Courier: testdata.AliceCourier, Lat: aliceLocation.Lat, Lon: aliceLocation.Lon}}
+
+		got, err := courierStore.GetAvailableCouriers()
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, want)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
 	"github.com/VitoNaychev/food-app/validation"
 )
 
@@ -34,9 +35,9 @@ func TestDeliveryHandlerOperations(t *testing.T) {
 	testutil.AssertNoErr(t, err)
 	initDeliveriesTable(t, deliveryStore)
 
-	server := handlers.NewDeliveryServer(env.SecretKey, deliveryStore, addressStore, courierStore)
+	server := handlers.NewDeliveryServer(auth.HMACKey(env.SecretKey), deliveryStore, addressStore, courierStore, &dummies.DummyPublisher{}, dummies.DummyVerifyJWT)
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 	t.Run("gets courier's active delivery", func(t *testing.T) {
 		want := handlers.NewGetDeliveryResponse(testdata.VolenActiveDelivery, testdata.VolenPickupAddress, testdata.VolenDeliveryAddress)
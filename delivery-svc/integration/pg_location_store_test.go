@@ -6,12 +6,14 @@ import (
 	"testing"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/delivery-svc/testdata"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
 	"github.com/VitoNaychev/food-app/validation"
 )
 
@@ -29,9 +31,20 @@ func TestLocationHandlerOperations(t *testing.T) {
 	testutil.AssertNoErr(t, err)
 	initLocationsTable(t, locationStore)
 
-	server := handlers.NewLocationServer(env.SecretKey, locationStore, courierStore)
+	deliveryStore, err := models.NewPgDeliveryStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	addressStore, err := models.NewPgAddressStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	offerStore, err := models.NewPgOfferStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		&dummies.DummyPublisher{}, dispatch.DefaultDispatcherConfig)
+	server := handlers.NewLocationServer(auth.HMACKey(env.SecretKey), locationStore, courierStore, dispatcher, dummies.DummyVerifyJWT)
 
-	volenJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.VolenCourier.ID)
+	volenJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.VolenCourier.ID, auth.COURIER)
 
 	t.Run("gets courier location", func(t *testing.T) {
 		want := testdata.VolenLocation
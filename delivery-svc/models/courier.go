@@ -4,3 +4,11 @@ type Courier struct {
 	ID   int
 	Name string
 }
+
+// AvailableCourier is a courier without an active delivery or a pending
+// offer, along with the location they last reported.
+type AvailableCourier struct {
+	Courier
+	Lat float32
+	Lon float32
+}
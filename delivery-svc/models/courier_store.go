@@ -4,4 +4,6 @@ type CourierStore interface {
 	CreateCourier(*Courier) error
 	DeleteCourier(int) error
 	GetCourierByID(int) (Courier, error)
+	GetAllCouriers() ([]Courier, error)
+	GetAvailableCouriers() ([]AvailableCourier, error)
 }
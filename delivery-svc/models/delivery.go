@@ -9,4 +9,7 @@ type Delivery struct {
 	DeliveryAddressID int       `db:"delivery_address_id"`
 	ReadyBy           time.Time `db:"ready_by"`
 	State             DeliveryState
+	// CorrelationID is the correlation of the event the delivery was created
+	// by, which the events published on behalf of the courier continue.
+	CorrelationID string `db:"correlation_id"`
 }
@@ -1,16 +1,109 @@
 package models
 
-import "github.com/VitoNaychev/food-app/sm"
+import (
+	"time"
 
+	"github.com/VitoNaychev/food-app/sm"
+)
+
+// Offer deltas leave the delivery state untouched, as a courier can be offered
+// a delivery at any point before pickup. They come before the rest so that a
+// DECLINE_DELIVERY on an outstanding offer doesn't decline the delivery itself.
 var deliveryDeltas = []sm.Delta{
+	{Current: sm.State(PENDING), Event: sm.Event(OFFER_DELIVERY), Next: sm.State(PENDING), Predicate: isDeliveryUnassigned, Callback: openOffer},
+	{Current: sm.State(PENDING), Event: sm.Event(ACCEPT_DELIVERY), Next: sm.State(PENDING), Predicate: isOfferOpen, Callback: acceptOffer},
+	{Current: sm.State(PENDING), Event: sm.Event(DECLINE_DELIVERY), Next: sm.State(PENDING), Predicate: isOfferPending, Callback: declineOffer},
+	{Current: sm.State(PENDING), Event: sm.Event(EXPIRE_DELIVERY_OFFER), Next: sm.State(PENDING), Predicate: isOfferPending, Callback: expireOffer},
+	{Current: sm.State(IN_PROGRESS), Event: sm.Event(OFFER_DELIVERY), Next: sm.State(IN_PROGRESS), Predicate: isDeliveryUnassigned, Callback: openOffer},
+	{Current: sm.State(IN_PROGRESS), Event: sm.Event(ACCEPT_DELIVERY), Next: sm.State(IN_PROGRESS), Predicate: isOfferOpen, Callback: acceptOffer},
+	{Current: sm.State(IN_PROGRESS), Event: sm.Event(DECLINE_DELIVERY), Next: sm.State(IN_PROGRESS), Predicate: isOfferPending, Callback: declineOffer},
+	{Current: sm.State(IN_PROGRESS), Event: sm.Event(EXPIRE_DELIVERY_OFFER), Next: sm.State(IN_PROGRESS), Predicate: isOfferPending, Callback: expireOffer},
+	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(OFFER_DELIVERY), Next: sm.State(READY_FOR_PICKUP), Predicate: isDeliveryUnassigned, Callback: openOffer},
+	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(ACCEPT_DELIVERY), Next: sm.State(READY_FOR_PICKUP), Predicate: isOfferOpen, Callback: acceptOffer},
+	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(DECLINE_DELIVERY), Next: sm.State(READY_FOR_PICKUP), Predicate: isOfferPending, Callback: declineOffer},
+	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(EXPIRE_DELIVERY_OFFER), Next: sm.State(READY_FOR_PICKUP), Predicate: isOfferPending, Callback: expireOffer},
+
 	{Current: sm.State(PENDING), Event: sm.Event(CANCEL_DELIVERY), Next: sm.State(CANCELED), Predicate: nil, Callback: nil},
-	{Current: sm.State(PENDING), Event: sm.Event(DECLINE_DELIVERY), Next: sm.State(DECLINED), Predicate: nil, Callback: nil},
+	{Current: sm.State(PENDING), Event: sm.Event(DECLINE_DELIVERY), Next: sm.State(DECLINED), Predicate: isNotOfferEvent, Callback: nil},
 	{Current: sm.State(PENDING), Event: sm.Event(BEGIN_PREPARING_DELIVERY), Next: sm.State(IN_PROGRESS), Predicate: nil, Callback: nil},
 	{Current: sm.State(IN_PROGRESS), Event: sm.Event(FINISH_PREPARING_DELIVERY), Next: sm.State(READY_FOR_PICKUP), Predicate: nil, Callback: nil},
 	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(PICKUP_DELIVERY), Next: sm.State(ON_ROUTE), Predicate: nil, Callback: nil},
+	{Current: sm.State(READY_FOR_PICKUP), Event: sm.Event(REJECT_HANDOVER_DELIVERY), Next: sm.State(READY_FOR_PICKUP), Predicate: nil, Callback: nil},
 	{Current: sm.State(ON_ROUTE), Event: sm.Event(COMPLETE_DELIVERY), Next: sm.State(COMPLETED), Predicate: nil, Callback: nil},
 }
 
+type OfferContext struct {
+	Delivery *Delivery
+	Offer    *Offer
+	Now      time.Time
+}
+
+func isDeliveryUnassigned(delta sm.Delta, context sm.Context) (bool, error) {
+	offerContext, ok := context.(*OfferContext)
+	if !ok {
+		return false, nil
+	}
+
+	return offerContext.Delivery.CourierID == 0, nil
+}
+
+// A courier declining an offer that is no longer pending must not fall
+// through to declining the delivery itself.
+func isNotOfferEvent(delta sm.Delta, context sm.Context) (bool, error) {
+	_, ok := context.(*OfferContext)
+	return !ok, nil
+}
+
+func isOfferPending(delta sm.Delta, context sm.Context) (bool, error) {
+	offerContext, ok := context.(*OfferContext)
+	if !ok {
+		return false, nil
+	}
+
+	return offerContext.Delivery.CourierID == 0 &&
+		offerContext.Offer.DeliveryID == offerContext.Delivery.ID &&
+		offerContext.Offer.State == OFFER_PENDING, nil
+}
+
+func isOfferOpen(delta sm.Delta, context sm.Context) (bool, error) {
+	if ok, err := isOfferPending(delta, context); !ok || err != nil {
+		return ok, err
+	}
+
+	offerContext := context.(*OfferContext)
+	return offerContext.Now.Before(offerContext.Offer.ExpiresAt), nil
+}
+
+func openOffer(delta sm.Delta, context sm.Context) error {
+	offerContext := context.(*OfferContext)
+	offerContext.Offer.DeliveryID = offerContext.Delivery.ID
+	offerContext.Offer.State = OFFER_PENDING
+
+	return nil
+}
+
+func acceptOffer(delta sm.Delta, context sm.Context) error {
+	offerContext := context.(*OfferContext)
+	offerContext.Delivery.CourierID = offerContext.Offer.CourierID
+	offerContext.Offer.State = OFFER_ACCEPTED
+
+	return nil
+}
+
+func declineOffer(delta sm.Delta, context sm.Context) error {
+	offerContext := context.(*OfferContext)
+	offerContext.Offer.State = OFFER_DECLINED
+
+	return nil
+}
+
+func expireOffer(delta sm.Delta, context sm.Context) error {
+	offerContext := context.(*OfferContext)
+	offerContext.Offer.State = OFFER_EXPIRED
+
+	return nil
+}
+
 type DeliverySM struct {
 	sm sm.SM
 }
@@ -20,6 +113,17 @@ func NewDeliverySM(initial DeliveryState) DeliverySM {
 	return DeliverySM{sm}
 }
 
+func NewDeliveryOfferSM(delivery *Delivery, offer *Offer, now time.Time) DeliverySM {
+	context := &OfferContext{
+		Delivery: delivery,
+		Offer:    offer,
+		Now:      now,
+	}
+
+	sm := sm.New(sm.State(delivery.State), deliveryDeltas, context)
+	return DeliverySM{sm}
+}
+
 func (d *DeliverySM) Exec(event DeliveryEvent) error {
 	err := d.sm.Exec(sm.Event(event))
 	return err
@@ -70,4 +70,8 @@ const (
 	FINISH_PREPARING_DELIVERY
 	PICKUP_DELIVERY
 	COMPLETE_DELIVERY
+	OFFER_DELIVERY
+	ACCEPT_DELIVERY
+	EXPIRE_DELIVERY_OFFER
+	REJECT_HANDOVER_DELIVERY
 )
@@ -3,6 +3,10 @@ package models
 type DeliveryStore interface {
 	CreateDelivery(*Delivery) error
 	GetDeliveryByID(int) (Delivery, error)
+	// LockDeliveryByID gets the delivery and keeps other transactions from
+	// changing it until the calling transaction ends.
+	LockDeliveryByID(int) (Delivery, error)
 	UpdateDelivery(*Delivery) error
 	GetActiveDeliveryByCourierID(courierID int) (Delivery, error)
+	GetUnassignedDeliveries() ([]Delivery, error)
 }
@@ -17,8 +17,14 @@ func (i *InMemoryCourierStore) CreateCourier(courier *Courier) error {
 	return nil
 }
 
-func (i *InMemoryCourierStore) GetAllCouriers() []Courier {
-	return i.couriers
+func (i *InMemoryCourierStore) GetAllCouriers() ([]Courier, error) {
+	return i.couriers, nil
+}
+
+// The in-memory store doesn't know where couriers are, so none of them is
+// ever available.
+func (i *InMemoryCourierStore) GetAvailableCouriers() ([]AvailableCourier, error) {
+	return []AvailableCourier{}, nil
 }
 
 func (i *InMemoryCourierStore) GetCourierByID(id int) (Courier, error) {
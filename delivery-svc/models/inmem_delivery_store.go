@@ -27,6 +27,10 @@ func (i *InMemoryDeliveryStore) GetDeliveryByID(id int) (Delivery, error) {
 	return Delivery{}, storeerrors.ErrNotFound
 }
 
+func (i *InMemoryDeliveryStore) LockDeliveryByID(id int) (Delivery, error) {
+	return i.GetDeliveryByID(id)
+}
+
 func (i *InMemoryDeliveryStore) UpdateDelivery(updatedDelivery *Delivery) error {
 	for j, delivery := range i.deliveries {
 		if delivery.ID == updatedDelivery.ID {
@@ -48,3 +52,16 @@ func (i *InMemoryDeliveryStore) GetActiveDeliveryByCourierID(courierID int) (Del
 	}
 	return Delivery{}, storeerrors.ErrNotFound
 }
+
+func (i *InMemoryDeliveryStore) GetUnassignedDeliveries() ([]Delivery, error) {
+	unassignedDeliveries := []Delivery{}
+	for _, delivery := range i.deliveries {
+		if delivery.CourierID == 0 &&
+			delivery.State != CANCELED &&
+			delivery.State != COMPLETED &&
+			delivery.State != DECLINED {
+			unassignedDeliveries = append(unassignedDeliveries, delivery)
+		}
+	}
+	return unassignedDeliveries, nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type InMemoryOfferStore struct {
+	offers []Offer
+}
+
+func NewInMemoryOfferStore() *InMemoryOfferStore {
+	return &InMemoryOfferStore{[]Offer{}}
+}
+
+func (i *InMemoryOfferStore) CreateOffer(offer *Offer) error {
+	offer.ID = len(i.offers) + 1
+	i.offers = append(i.offers, *offer)
+
+	return nil
+}
+
+func (i *InMemoryOfferStore) GetOfferByID(id int) (Offer, error) {
+	for _, offer := range i.offers {
+		if offer.ID == id {
+			return offer, nil
+		}
+	}
+	return Offer{}, storeerrors.ErrNotFound
+}
+
+func (i *InMemoryOfferStore) UpdateOffer(updatedOffer *Offer) error {
+	for j, offer := range i.offers {
+		if offer.ID == updatedOffer.ID {
+			i.offers[j] = *updatedOffer
+			return nil
+		}
+	}
+	return storeerrors.ErrNotFound
+}
+
+func (i *InMemoryOfferStore) GetPendingOffersByCourierID(courierID int) ([]Offer, error) {
+	pendingOffers := []Offer{}
+	for _, offer := range i.offers {
+		if offer.CourierID == courierID && offer.State == OFFER_PENDING {
+			pendingOffers = append(pendingOffers, offer)
+		}
+	}
+	return pendingOffers, nil
+}
+
+func (i *InMemoryOfferStore) GetOffersByDeliveryID(deliveryID int) ([]Offer, error) {
+	deliveryOffers := []Offer{}
+	for _, offer := range i.offers {
+		if offer.DeliveryID == deliveryID {
+			deliveryOffers = append(deliveryOffers, offer)
+		}
+	}
+	return deliveryOffers, nil
+}
+
+func (i *InMemoryOfferStore) GetExpiredOffers(now time.Time) ([]Offer, error) {
+	expiredOffers := []Offer{}
+	for _, offer := range i.offers {
+		if offer.State == OFFER_PENDING && offer.ExpiresAt.Before(now) {
+			expiredOffers = append(expiredOffers, offer)
+		}
+	}
+	return expiredOffers, nil
+}
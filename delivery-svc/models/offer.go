@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type OfferState int
+
+const (
+	OFFER_PENDING OfferState = iota
+	OFFER_ACCEPTED
+	OFFER_DECLINED
+	OFFER_EXPIRED
+)
+
+type Offer struct {
+	ID         int
+	DeliveryID int       `db:"delivery_id"`
+	CourierID  int       `db:"courier_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	State      OfferState
+}
+
+func OfferStateValueToStateName(stateValue OfferState) (string, error) {
+	var stateName string
+
+	switch stateValue {
+	case OFFER_PENDING:
+		stateName = "pending"
+	case OFFER_ACCEPTED:
+		stateName = "accepted"
+	case OFFER_DECLINED:
+		stateName = "declined"
+	case OFFER_EXPIRED:
+		stateName = "expired"
+	default:
+		return "", ErrNonexistentState
+	}
+
+	return stateName, nil
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+type OfferStore interface {
+	CreateOffer(*Offer) error
+	GetOfferByID(int) (Offer, error)
+	UpdateOffer(*Offer) error
+	GetPendingOffersByCourierID(courierID int) ([]Offer, error)
+	GetOffersByDeliveryID(deliveryID int) ([]Offer, error)
+	GetExpiredOffers(now time.Time) ([]Offer, error)
+}
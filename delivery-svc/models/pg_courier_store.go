@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgCourierStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgCourierStore(ctx context.Context, connString string) (*PgCourierStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -21,6 +22,14 @@ func NewPgCourierStore(ctx context.Context, connString string) (*PgCourierStore,
 	return &PgCourierStore{conn}, nil
 }
 
+func (p *PgCourierStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgCourierStore) WithTx(tx pgx.Tx) CourierStore {
+	return &PgCourierStore{tx}
+}
+
 func (p *PgCourierStore) GetCourierByID(id int) (Courier, error) {
 	query := `select * from couriers where id=@id`
 	args := pgx.NamedArgs{
@@ -37,6 +46,42 @@ func (p *PgCourierStore) GetCourierByID(id int) (Courier, error) {
 	return courier, nil
 }
 
+func (p *PgCourierStore) GetAllCouriers() ([]Courier, error) {
+	query := `select * from couriers order by id`
+
+	rows, _ := p.conn.Query(context.Background(), query)
+	couriers, err := pgx.CollectRows(rows, pgx.RowToStructByName[Courier])
+
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return couriers, nil
+}
+
+// Couriers that have never reported a location can't be dispatched to, so
+// they aren't available.
+func (p *PgCourierStore) GetAvailableCouriers() ([]AvailableCourier, error) {
+	query := `select c.id, c.name, l.lat, l.lon from couriers c join locations l on l.courier_id=c.id
+		where not exists (select 1 from deliveries d where d.courier_id=c.id and d.state not in (@canceled, @completed, @declined))
+		and not exists (select 1 from offers o where o.courier_id=c.id and o.state=@pending) order by c.id`
+	args := pgx.NamedArgs{
+		"canceled":  CANCELED,
+		"completed": COMPLETED,
+		"declined":  DECLINED,
+		"pending":   OFFER_PENDING,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	couriers, err := pgx.CollectRows(rows, pgx.RowToStructByName[AvailableCourier])
+
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return couriers, nil
+}
+
 func (p *PgCourierStore) CreateCourier(courier *Courier) error {
 	query := `insert into couriers(id, name) values (@id, @name)`
 	args := pgx.NamedArgs{
@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
+// courier_id is nullable since deliveries wait unassigned until a courier
+// becomes available.
+const deliveryColumns = `id, coalesce(courier_id, 0) as courier_id, pickup_address_id, delivery_address_id, ready_by, state, correlation_id`
+
 type PgDeliveryStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgDeliveryStore(ctx context.Context, connString string) (*PgDeliveryStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -21,16 +26,25 @@ func NewPgDeliveryStore(ctx context.Context, connString string) (*PgDeliveryStor
 	return &PgDeliveryStore{conn}, nil
 }
 
+func (p *PgDeliveryStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgDeliveryStore) WithTx(tx pgx.Tx) DeliveryStore {
+	return &PgDeliveryStore{tx}
+}
+
 func (p *PgDeliveryStore) CreateDelivery(delivery *Delivery) error {
-	query := `insert into deliveries(id, courier_id, pickup_address_id, delivery_address_id, ready_by, state) 
-		values (@id, @courier_id, @pickup_address_id, @delivery_address_id, @ready_by, @state)`
+	query := `insert into deliveries(id, courier_id, pickup_address_id, delivery_address_id, ready_by, state, correlation_id) 
+		values (@id, @courier_id, @pickup_address_id, @delivery_address_id, @ready_by, @state, @correlation_id)`
 	args := pgx.NamedArgs{
 		"id":                  delivery.ID,
-		"courier_id":          delivery.CourierID,
+		"courier_id":          nullableCourierID(delivery.CourierID),
 		"pickup_address_id":   delivery.PickupAddressID,
 		"delivery_address_id": delivery.DeliveryAddressID,
 		"ready_by":            delivery.ReadyBy,
 		"state":               delivery.State,
+		"correlation_id":      delivery.CorrelationID,
 	}
 
 	_, err := p.conn.Exec(context.Background(), query, args)
@@ -38,7 +52,25 @@ func (p *PgDeliveryStore) CreateDelivery(delivery *Delivery) error {
 }
 
 func (p *PgDeliveryStore) GetDeliveryByID(id int) (Delivery, error) {
-	query := `select * from deliveries where id=@id`
+	query := `select ` + deliveryColumns + ` from deliveries where id=@id`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	row, _ := p.conn.Query(context.Background(), query, args)
+	delivery, err := pgx.CollectOneRow(row, pgx.RowToStructByName[Delivery])
+
+	if err != nil {
+		return Delivery{}, storeerrors.FromPgxError(err)
+	}
+
+	return delivery, nil
+}
+
+// The row lock is transaction scoped, so the store has to be bound to the
+// transaction that updates the delivery.
+func (p *PgDeliveryStore) LockDeliveryByID(id int) (Delivery, error) {
+	query := `select ` + deliveryColumns + ` from deliveries where id=@id for update`
 	args := pgx.NamedArgs{
 		"id": id,
 	}
@@ -54,13 +86,13 @@ func (p *PgDeliveryStore) GetDeliveryByID(id int) (Delivery, error) {
 }
 
 func (p *PgDeliveryStore) GetActiveDeliveryByCourierID(courierID int) (Delivery, error) {
-	query := `select * from deliveries where courier_id=@courier_id and 
-		(state != @pending or state != @canceled or state != @completed)`
+	query := `select ` + deliveryColumns + ` from deliveries where courier_id=@courier_id and 
+		state not in (@canceled, @completed, @declined)`
 	args := pgx.NamedArgs{
 		"courier_id": courierID,
-		"pending":    PENDING,
 		"canceled":   CANCELED,
 		"completed":  COMPLETED,
+		"declined":   DECLINED,
 	}
 
 	row, _ := p.conn.Query(context.Background(), query, args)
@@ -78,7 +110,7 @@ func (p *PgDeliveryStore) UpdateDelivery(delivery *Delivery) error {
 		delivery_address_id=@delivery_address_id, ready_by=@ready_by, state=@state where id=@id`
 	args := pgx.NamedArgs{
 		"id":                  delivery.ID,
-		"courier_id":          delivery.CourierID,
+		"courier_id":          nullableCourierID(delivery.CourierID),
 		"pickup_address_id":   delivery.PickupAddressID,
 		"delivery_address_id": delivery.DeliveryAddressID,
 		"ready_by":            delivery.ReadyBy,
@@ -88,3 +120,29 @@ func (p *PgDeliveryStore) UpdateDelivery(delivery *Delivery) error {
 	_, err := p.conn.Exec(context.Background(), query, args)
 	return storeerrors.FromPgxError(err)
 }
+
+func (p *PgDeliveryStore) GetUnassignedDeliveries() ([]Delivery, error) {
+	query := `select ` + deliveryColumns + ` from deliveries where courier_id is null and 
+		state not in (@canceled, @completed, @declined) order by id`
+	args := pgx.NamedArgs{
+		"canceled":  CANCELED,
+		"completed": COMPLETED,
+		"declined":  DECLINED,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[Delivery])
+
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return deliveries, nil
+}
+
+func nullableCourierID(courierID int) *int {
+	if courierID == 0 {
+		return nil
+	}
+	return &courierID
+}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgLocationStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgLocationStore(ctx context.Context, connString string) (*PgLocationStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -21,6 +22,14 @@ func NewPgLocationStore(ctx context.Context, connString string) (*PgLocationStor
 	return &PgLocationStore{conn}, nil
 }
 
+func (p *PgLocationStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgLocationStore) WithTx(tx pgx.Tx) LocationStore {
+	return &PgLocationStore{tx}
+}
+
 func (p *PgLocationStore) CreateLocation(location *Location) error {
 	query := `insert into locations(courier_id, lat, lon) values (@courier_id, @lat, @lon)`
 	args := pgx.NamedArgs{
@@ -57,8 +66,16 @@ func (p *PgLocationStore) UpdateLocation(location *Location) error {
 		"lon":        location.Lon,
 	}
 
-	_, err := p.conn.Exec(context.Background(), query, args)
-	return storeerrors.FromPgxError(err)
+	tag, err := p.conn.Exec(context.Background(), query, args)
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storeerrors.ErrNotFound
+	}
+
+	return nil
 }
 
 func (p *PgLocationStore) DeleteLocation(courierID int) error {
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgOfferStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgOfferStore(ctx context.Context, connString string) (*PgOfferStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgOfferStore{conn}, nil
+}
+
+func (p *PgOfferStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgOfferStore) WithTx(tx pgx.Tx) OfferStore {
+	return &PgOfferStore{tx}
+}
+
+func (p *PgOfferStore) CreateOffer(offer *Offer) error {
+	query := `insert into offers(delivery_id, courier_id, expires_at, state) 
+		values (@delivery_id, @courier_id, @expires_at, @state) returning id`
+	args := pgx.NamedArgs{
+		"delivery_id": offer.DeliveryID,
+		"courier_id":  offer.CourierID,
+		"expires_at":  offer.ExpiresAt,
+		"state":       offer.State,
+	}
+
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&offer.ID)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgOfferStore) GetOfferByID(id int) (Offer, error) {
+	query := `select * from offers where id=@id`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	row, _ := p.conn.Query(context.Background(), query, args)
+	offer, err := pgx.CollectOneRow(row, pgx.RowToStructByName[Offer])
+
+	if err != nil {
+		return Offer{}, storeerrors.FromPgxError(err)
+	}
+
+	return offer, nil
+}
+
+func (p *PgOfferStore) UpdateOffer(offer *Offer) error {
+	query := `update offers set delivery_id=@delivery_id, courier_id=@courier_id, 
+		expires_at=@expires_at, state=@state where id=@id`
+	args := pgx.NamedArgs{
+		"id":          offer.ID,
+		"delivery_id": offer.DeliveryID,
+		"courier_id":  offer.CourierID,
+		"expires_at":  offer.ExpiresAt,
+		"state":       offer.State,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgOfferStore) GetPendingOffersByCourierID(courierID int) ([]Offer, error) {
+	query := `select * from offers where courier_id=@courier_id and state=@pending order by id`
+	args := pgx.NamedArgs{
+		"courier_id": courierID,
+		"pending":    OFFER_PENDING,
+	}
+
+	return p.collectOffers(query, args)
+}
+
+func (p *PgOfferStore) GetOffersByDeliveryID(deliveryID int) ([]Offer, error) {
+	query := `select * from offers where delivery_id=@delivery_id order by id`
+	args := pgx.NamedArgs{
+		"delivery_id": deliveryID,
+	}
+
+	return p.collectOffers(query, args)
+}
+
+func (p *PgOfferStore) GetExpiredOffers(now time.Time) ([]Offer, error) {
+	query := `select * from offers where state=@pending and expires_at < @now order by id`
+	args := pgx.NamedArgs{
+		"pending": OFFER_PENDING,
+		"now":     now,
+	}
+
+	return p.collectOffers(query, args)
+}
+
+func (p *PgOfferStore) collectOffers(query string, args pgx.NamedArgs) ([]Offer, error) {
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	offers, err := pgx.CollectRows(rows, pgx.RowToStructByName[Offer])
+
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return offers, nil
+}
@@ -1,3 +1,6 @@
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS inbox;
+DROP TABLE IF EXISTS offers;
 DROP TABLE IF EXISTS deliveries;
 DROP TABLE IF EXISTS addresses;
 DROP TABLE IF EXISTS locations;
@@ -25,6 +28,15 @@ CREATE TABLE deliveries (
   pickup_address_id   int                        REFERENCES addresses(id),
   delivery_address_id int                        REFERENCES addresses(id),
   ready_by            timestamp with time zone   NOT NULL,
+  state               int                        NOT NULL,
+  correlation_id      varchar(100)               NOT NULL      DEFAULT ''
+  );
+
+CREATE TABLE offers (
+  id                  serial                     PRIMARY KEY,
+  delivery_id         int                        NOT NULL      REFERENCES deliveries(id),
+  courier_id          int                        NOT NULL      REFERENCES couriers(id),
+  expires_at          timestamp with time zone   NOT NULL,
   state               int                        NOT NULL
   );
 
@@ -34,3 +46,17 @@ CREATE TABLE locations (
   lon                  numeric(10, 7)  NOT NULL
   );
 
+CREATE TABLE inbox (
+  key                 varchar(100)         PRIMARY KEY,
+  processed_at        timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
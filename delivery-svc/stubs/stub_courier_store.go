@@ -6,9 +6,10 @@ import (
 )
 
 type StubCourierStore struct {
-	Couriers         []models.Courier
-	CreatedCourier   models.Courier
-	DeletedCourierID int
+	Couriers          []models.Courier
+	AvailableCouriers []models.AvailableCourier
+	CreatedCourier    models.Courier
+	DeletedCourierID  int
 }
 
 func (s *StubCourierStore) GetCourierByID(id int) (models.Courier, error) {
@@ -21,6 +22,14 @@ func (s *StubCourierStore) GetCourierByID(id int) (models.Courier, error) {
 	return models.Courier{}, storeerrors.ErrNotFound
 }
 
+func (s *StubCourierStore) GetAllCouriers() ([]models.Courier, error) {
+	return s.Couriers, nil
+}
+
+func (s *StubCourierStore) GetAvailableCouriers() ([]models.AvailableCourier, error) {
+	return s.AvailableCouriers, nil
+}
+
 func (s *StubCourierStore) CreateCourier(courier *models.Courier) error {
 	s.CreatedCourier = *courier
 	return nil
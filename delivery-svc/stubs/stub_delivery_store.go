@@ -13,6 +13,7 @@ type StubDeliveryStore struct {
 
 func (d *StubDeliveryStore) CreateDelivery(delivery *models.Delivery) error {
 	d.CreatedDelivery = *delivery
+	d.Deliveries = append(d.Deliveries, *delivery)
 
 	return nil
 }
@@ -40,8 +41,26 @@ func (d *StubDeliveryStore) GetDeliveryByID(id int) (models.Delivery, error) {
 	return models.Delivery{}, storeerrors.ErrNotFound
 }
 
+func (d *StubDeliveryStore) LockDeliveryByID(id int) (models.Delivery, error) {
+	return d.GetDeliveryByID(id)
+}
+
 func (d *StubDeliveryStore) UpdateDelivery(delivery *models.Delivery) error {
 	d.UpdatedDelivery = *delivery
 
 	return nil
 }
+
+func (d *StubDeliveryStore) GetUnassignedDeliveries() ([]models.Delivery, error) {
+	unassignedDeliveries := []models.Delivery{}
+	for _, delivery := range d.Deliveries {
+		if delivery.CourierID == 0 &&
+			delivery.State != models.CANCELED &&
+			delivery.State != models.COMPLETED &&
+			delivery.State != models.DECLINED {
+			unassignedDeliveries = append(unassignedDeliveries, delivery)
+		}
+	}
+
+	return unassignedDeliveries, nil
+}
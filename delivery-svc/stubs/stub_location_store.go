@@ -35,7 +35,12 @@ func (s *StubLocationStore) GetLocationByCourierID(courierID int) (models.Locati
 }
 
 func (s *StubLocationStore) UpdateLocation(location *models.Location) error {
-	s.UpdatedLocation = *location
+	for _, storedLocation := range s.Locations {
+		if storedLocation.CourierID == location.CourierID {
+			s.UpdatedLocation = *location
+			return nil
+		}
+	}
 
-	return nil
+	return storeerrors.ErrNotFound
 }
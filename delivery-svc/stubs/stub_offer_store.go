@@ -0,0 +1,76 @@
+package stubs
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/delivery-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type StubOfferStore struct {
+	Offers       []models.Offer
+	CreatedOffer models.Offer
+	UpdatedOffer models.Offer
+}
+
+func (s *StubOfferStore) CreateOffer(offer *models.Offer) error {
+	offer.ID = len(s.Offers) + 1
+	s.CreatedOffer = *offer
+
+	return nil
+}
+
+func (s *StubOfferStore) GetOfferByID(id int) (models.Offer, error) {
+	for _, offer := range s.Offers {
+		if offer.ID == id {
+			return offer, nil
+		}
+	}
+
+	return models.Offer{}, storeerrors.ErrNotFound
+}
+
+func (s *StubOfferStore) UpdateOffer(offer *models.Offer) error {
+	s.UpdatedOffer = *offer
+
+	for i := range s.Offers {
+		if s.Offers[i].ID == offer.ID {
+			s.Offers[i] = *offer
+		}
+	}
+
+	return nil
+}
+
+func (s *StubOfferStore) GetPendingOffersByCourierID(courierID int) ([]models.Offer, error) {
+	pendingOffers := []models.Offer{}
+	for _, offer := range s.Offers {
+		if offer.CourierID == courierID && offer.State == models.OFFER_PENDING {
+			pendingOffers = append(pendingOffers, offer)
+		}
+	}
+
+	return pendingOffers, nil
+}
+
+func (s *StubOfferStore) GetOffersByDeliveryID(deliveryID int) ([]models.Offer, error) {
+	deliveryOffers := []models.Offer{}
+	for _, offer := range s.Offers {
+		if offer.DeliveryID == deliveryID {
+			deliveryOffers = append(deliveryOffers, offer)
+		}
+	}
+
+	return deliveryOffers, nil
+}
+
+func (s *StubOfferStore) GetExpiredOffers(now time.Time) ([]models.Offer, error) {
+	expiredOffers := []models.Offer{}
+	for _, offer := range s.Offers {
+		if offer.State == models.OFFER_PENDING && offer.ExpiresAt.Before(now) {
+			expiredOffers = append(expiredOffers, offer)
+		}
+	}
+
+	return expiredOffers, nil
+}
@@ -8,4 +8,24 @@ var (
 		Lat:       42.651552579,
 		Lon:       23.343831658,
 	}
+
+	PeterLocation = models.Location{
+		CourierID: 2,
+		Lat:       42.697708,
+		Lon:       23.321868,
+	}
+)
+
+var (
+	VolenAvailableCourier = models.AvailableCourier{
+		Courier: VolenCourier,
+		Lat:     VolenLocation.Lat,
+		Lon:     VolenLocation.Lon,
+	}
+
+	PeterAvailableCourier = models.AvailableCourier{
+		Courier: PeterCourier,
+		Lat:     PeterLocation.Lat,
+		Lon:     PeterLocation.Lon,
+	}
 )
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/VitoNaychev/food-app/events"
+)
+
+func main() {
+	brokers := flag.String("brokers", os.Getenv("KAFKA_BROKERS"), "comma separated list of Kafka brokers")
+	topic := flag.String("topic", "", "topic whose dead letters to replay, e.g. kitchen-events-topic")
+	groupID := flag.String("group", "dlq-replayer", "consumer group used to track replay progress")
+	flag.Parse()
+
+	if *topic == "" || *brokers == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	replayer, err := events.NewDeadLetterReplayer(strings.Split(*brokers, ","), *groupID)
+	if err != nil {
+		log.Fatalf("Dead Letter Replayer error: %v\n", err)
+	}
+	defer replayer.Close()
+
+	dlqTopic := events.GetDeadLetterTopic(*topic)
+
+	replayed, err := replayer.Replay(dlqTopic)
+	if err != nil {
+		log.Fatalf("Replay of %s failed after %d messages: %v\n", dlqTopic, replayed, err)
+	}
+
+	log.Printf("Replayed %d messages from %s to %s\n", replayed, dlqTopic, *topic)
+}
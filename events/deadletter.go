@@ -0,0 +1,87 @@
+package events
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	DEAD_LETTER_TOPIC_SUFFIX = ".DLQ"
+
+	DLQ_ORIGINAL_TOPIC_HEADER     = "dlq-original-topic"
+	DLQ_ORIGINAL_PARTITION_HEADER = "dlq-original-partition"
+	DLQ_ORIGINAL_OFFSET_HEADER    = "dlq-original-offset"
+	DLQ_ERROR_HEADER              = "dlq-error"
+	DLQ_ATTEMPTS_HEADER           = "dlq-attempts"
+	DLQ_FAILED_AT_HEADER          = "dlq-failed-at"
+)
+
+type DeadLetterPublisher interface {
+	PublishDeadLetter(message *sarama.ConsumerMessage, attempts int, err error) error
+}
+
+func GetDeadLetterTopic(topic string) string {
+	return topic + DEAD_LETTER_TOPIC_SUFFIX
+}
+
+func NewDeadLetterMessage(message *sarama.ConsumerMessage, attempts int, err error) *sarama.ProducerMessage {
+	headers := []sarama.RecordHeader{}
+	for _, header := range message.Headers {
+		headers = append(headers, *header)
+	}
+
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(DLQ_ORIGINAL_TOPIC_HEADER), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(DLQ_ORIGINAL_PARTITION_HEADER), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(DLQ_ORIGINAL_OFFSET_HEADER), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(DLQ_ERROR_HEADER), Value: []byte(err.Error())},
+		sarama.RecordHeader{Key: []byte(DLQ_ATTEMPTS_HEADER), Value: []byte(strconv.Itoa(attempts))},
+		sarama.RecordHeader{Key: []byte(DLQ_FAILED_AT_HEADER), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	deadLetter := &sarama.ProducerMessage{
+		Topic:   GetDeadLetterTopic(message.Topic),
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+
+	return deadLetter
+}
+
+// NewReplayMessage strips the dead-letter metadata from message and addresses
+// it back to the topic it originally failed on.
+func NewReplayMessage(message *sarama.ConsumerMessage) (*sarama.ProducerMessage, error) {
+	originalTopic := ""
+	headers := []sarama.RecordHeader{}
+	for _, header := range message.Headers {
+		switch string(header.Key) {
+		case DLQ_ORIGINAL_TOPIC_HEADER:
+			originalTopic = string(header.Value)
+		case DLQ_ORIGINAL_PARTITION_HEADER, DLQ_ORIGINAL_OFFSET_HEADER, DLQ_ERROR_HEADER,
+			DLQ_ATTEMPTS_HEADER, DLQ_FAILED_AT_HEADER:
+		default:
+			headers = append(headers, *header)
+		}
+	}
+
+	if originalTopic == "" {
+		return nil, ErrMissingOriginalTopic
+	}
+
+	replay := &sarama.ProducerMessage{
+		Topic:   originalTopic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+
+	return replay, nil
+}
+
+func (k *KafkaEventPublisher) PublishDeadLetter(message *sarama.ConsumerMessage, attempts int, err error) error {
+	_, _, err = k.producer.SendMessage(NewDeadLetterMessage(message, attempts, err))
+	return err
+}
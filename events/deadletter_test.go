@@ -0,0 +1,62 @@
+package events_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestDeadLetter(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Topic:     "test-topic",
+		Partition: 2,
+		Offset:    7,
+		Key:       []byte("1"),
+		Value:     []byte(`{"EventID":10}`),
+	}
+
+	t.Run("routes failed message to DLQ topic with error metadata", func(t *testing.T) {
+		deadLetter := events.NewDeadLetterMessage(message, 5, errors.New("poison"))
+
+		testutil.AssertEqual(t, deadLetter.Topic, "test-topic.DLQ")
+
+		headers := map[string]string{}
+		for _, header := range deadLetter.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		testutil.AssertEqual(t, headers[events.DLQ_ORIGINAL_TOPIC_HEADER], "test-topic")
+		testutil.AssertEqual(t, headers[events.DLQ_ORIGINAL_PARTITION_HEADER], "2")
+		testutil.AssertEqual(t, headers[events.DLQ_ORIGINAL_OFFSET_HEADER], "7")
+		testutil.AssertEqual(t, headers[events.DLQ_ERROR_HEADER], "poison")
+		testutil.AssertEqual(t, headers[events.DLQ_ATTEMPTS_HEADER], "5")
+	})
+
+	t.Run("replays dead letter to original topic", func(t *testing.T) {
+		deadLetter := events.NewDeadLetterMessage(message, 5, errors.New("poison"))
+
+		value, _ := deadLetter.Value.Encode()
+		dlqMessage := &sarama.ConsumerMessage{
+			Topic: deadLetter.Topic,
+			Value: value,
+		}
+		for i := range deadLetter.Headers {
+			dlqMessage.Headers = append(dlqMessage.Headers, &deadLetter.Headers[i])
+		}
+
+		replay, err := events.NewReplayMessage(dlqMessage)
+		testutil.AssertNoErr(t, err)
+
+		replayValue, _ := replay.Value.Encode()
+		testutil.AssertEqual(t, replay.Topic, "test-topic")
+		testutil.AssertEqual(t, replayValue, message.Value)
+		testutil.AssertEqual(t, len(replay.Headers), 0)
+	})
+
+	t.Run("refuses to replay message without original topic", func(t *testing.T) {
+		_, err := events.NewReplayMessage(&sarama.ConsumerMessage{Topic: "test-topic.DLQ"})
+		testutil.AssertError(t, err, events.ErrMissingOriginalTopic)
+	})
+}
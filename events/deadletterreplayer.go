@@ -0,0 +1,133 @@
+package events
+
+import (
+	"github.com/IBM/sarama"
+)
+
+type DeadLetterReplayer struct {
+	client   sarama.Client
+	consumer sarama.Consumer
+	producer sarama.SyncProducer
+	offsets  sarama.OffsetManager
+}
+
+func NewDeadLetterReplayer(brokersAddrs []string, groupID string) (*DeadLetterReplayer, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.Return.Errors = true
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	client, err := sarama.NewClient(brokersAddrs, config)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	offsets, err := sarama.NewOffsetManagerFromClient(groupID, client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	replayer := DeadLetterReplayer{
+		client:   client,
+		consumer: consumer,
+		producer: producer,
+		offsets:  offsets,
+	}
+
+	return &replayer, nil
+}
+
+func (d *DeadLetterReplayer) Close() {
+	d.offsets.Close()
+	d.producer.Close()
+	d.consumer.Close()
+	d.client.Close()
+}
+
+// Replay republishes every message currently in dlqTopic to its original
+// topic and commits its progress, so each dead letter is replayed once.
+func (d *DeadLetterReplayer) Replay(dlqTopic string) (int, error) {
+	partitions, err := d.client.Partitions(dlqTopic)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, partition := range partitions {
+		n, err := d.replayPartition(dlqTopic, partition)
+		replayed += n
+		if err != nil {
+			return replayed, err
+		}
+	}
+
+	d.offsets.Commit()
+
+	return replayed, nil
+}
+
+func (d *DeadLetterReplayer) replayPartition(dlqTopic string, partition int32) (int, error) {
+	partitionOffsets, err := d.offsets.ManagePartition(dlqTopic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer partitionOffsets.Close()
+
+	highWaterMark, err := d.client.GetOffset(dlqTopic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, err
+	}
+
+	next, _ := partitionOffsets.NextOffset()
+	if next < 0 {
+		next, err = d.client.GetOffset(dlqTopic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if next >= highWaterMark {
+		return 0, nil
+	}
+
+	partitionConsumer, err := d.consumer.ConsumePartition(dlqTopic, partition, next)
+	if err != nil {
+		return 0, err
+	}
+	defer partitionConsumer.Close()
+
+	replayed := 0
+	for message := range partitionConsumer.Messages() {
+		replay, err := NewReplayMessage(message)
+		if err != nil {
+			return replayed, err
+		}
+
+		_, _, err = d.producer.SendMessage(replay)
+		if err != nil {
+			return replayed, err
+		}
+
+		partitionOffsets.MarkOffset(message.Offset+1, "")
+		replayed++
+
+		if message.Offset+1 >= highWaterMark {
+			break
+		}
+	}
+
+	return replayed, nil
+}
@@ -1,36 +1,59 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type EventID int
 
 type EventEnvelope struct {
+	ID          string
 	EventID     EventID
+	Version     int
 	AggregateID int
 	Timestamp   time.Time
 }
 
 func NewEventEnvelope(eventID EventID, aggregateID int) EventEnvelope {
 	return EventEnvelope{
+		ID:          uuid.NewString(),
 		EventID:     eventID,
+		Version:     InitialSchemaVersion,
 		AggregateID: aggregateID,
 		Timestamp:   time.Now().Round(0),
 	}
 }
 
 type Event[T any] struct {
+	ID          string
 	EventID     EventID
+	Version     int
 	AggregateID int
 	Timestamp   time.Time
 	Payload     T
+	Headers     EventHeaders `json:"-"`
+
+	ctx context.Context
+}
+
+// Context returns the context the event is handled in, which carries the
+// transaction the handler's writes should take part in, if there is one.
+func (e Event[T]) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
 }
 
 func NewTypedEvent[T any](eventID EventID, aggregateID int, payload T) Event[T] {
 	return Event[T]{
+		ID:          uuid.NewString(),
 		EventID:     eventID,
+		Version:     InitialSchemaVersion,
 		AggregateID: aggregateID,
 		Timestamp:   time.Now().Round(0),
 		Payload:     payload,
@@ -38,22 +61,44 @@ func NewTypedEvent[T any](eventID EventID, aggregateID int, payload T) Event[T]
 }
 
 type RawPayloadEvent struct {
+	ID          string
 	EventID     EventID
+	Version     int
 	AggregateID int
 	Timestamp   time.Time
 	Payload     json.RawMessage
 }
 
 type InterfaceEvent struct {
+	ID          string
 	EventID     EventID
+	Version     int
 	AggregateID int
 	Timestamp   time.Time
 	Payload     interface{}
+	Headers     EventHeaders `json:"-"`
+
+	ctx context.Context
+}
+
+func (e InterfaceEvent) Context() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// WithContext returns a copy of event that is handled in ctx.
+func (e InterfaceEvent) WithContext(ctx context.Context) InterfaceEvent {
+	e.ctx = ctx
+	return e
 }
 
 func NewEvent(eventID EventID, aggregateID int, payload any) InterfaceEvent {
 	return InterfaceEvent{
+		ID:          uuid.NewString(),
 		EventID:     eventID,
+		Version:     InitialSchemaVersion,
 		AggregateID: aggregateID,
 		Timestamp:   time.Now().Round(0),
 		Payload:     payload,
@@ -7,3 +7,9 @@ type EventHandlerFunc func(envelope EventEnvelope, payload []byte) error
 type EventConsumer interface {
 	RegisterEventHandler(string, EventID, InterfaceEventHandler, reflect.Type) error
 }
+
+// UpcasterRegistry is implemented by consumers that can convert payloads of
+// older schema versions before handling them.
+type UpcasterRegistry interface {
+	RegisterUpcaster(topic string, eventID EventID, fromVersion int, upcaster Upcaster) error
+}
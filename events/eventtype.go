@@ -0,0 +1,76 @@
+package events
+
+import "reflect"
+
+// EventType binds an EventID on a topic to the type of its payload, so
+// publishers and consumers of the event can't disagree on either.
+type EventType[T any] struct {
+	Topic     string
+	ID        EventID
+	Upcasters Upcasters
+}
+
+func NewEventType[T any](topic string, eventID EventID) EventType[T] {
+	return EventType[T]{
+		Topic:     topic,
+		ID:        eventID,
+		Upcasters: Upcasters{},
+	}
+}
+
+// WithUpcaster returns a copy of the EventType whose schema has moved past
+// fromVersion, converting payloads of fromVersion with upcaster.
+func (e EventType[T]) WithUpcaster(fromVersion int, upcaster Upcaster) EventType[T] {
+	upcasters := Upcasters{}
+	for version, u := range e.Upcasters {
+		upcasters[version] = u
+	}
+	upcasters[fromVersion] = upcaster
+
+	e.Upcasters = upcasters
+	return e
+}
+
+func (e EventType[T]) Version() int {
+	return e.Upcasters.Version()
+}
+
+func (e EventType[T]) New(aggregateID int, payload T) InterfaceEvent {
+	event := NewEvent(e.ID, aggregateID, payload)
+	event.Version = e.Version()
+
+	return event
+}
+
+func (e EventType[T]) Register(consumer EventConsumer, eventHandler func(event Event[T]) error) error {
+	err := Register(consumer, e.Topic, e.ID, eventHandler)
+	if err != nil {
+		return err
+	}
+
+	if len(e.Upcasters) == 0 {
+		return nil
+	}
+
+	registry, ok := consumer.(UpcasterRegistry)
+	if !ok {
+		return ErrUpcastingNotSupported
+	}
+
+	for fromVersion, upcaster := range e.Upcasters {
+		err = registry.RegisterUpcaster(e.Topic, e.ID, fromVersion, upcaster)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Register registers eventHandler for the event with eventID on topic,
+// deriving the type of the payload from the handler.
+func Register[T any](consumer EventConsumer, topic string, eventID EventID, eventHandler func(event Event[T]) error) error {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+
+	return consumer.RegisterEventHandler(topic, eventID, EventHandlerWrapper(eventHandler), eventType)
+}
@@ -0,0 +1,113 @@
+package events_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+type SpyEventConsumer struct {
+	topic        string
+	eventID      events.EventID
+	eventHandler events.InterfaceEventHandler
+	eventType    reflect.Type
+}
+
+func (s *SpyEventConsumer) RegisterEventHandler(topic string, eventID events.EventID, eventHandler events.InterfaceEventHandler, eventType reflect.Type) error {
+	s.topic = topic
+	s.eventID = eventID
+	s.eventHandler = eventHandler
+	s.eventType = eventType
+
+	return nil
+}
+
+type SpyUpcastingEventConsumer struct {
+	SpyEventConsumer
+	upcasters events.Upcasters
+}
+
+func (s *SpyUpcastingEventConsumer) RegisterUpcaster(topic string, eventID events.EventID, fromVersion int, upcaster events.Upcaster) error {
+	s.upcasters[fromVersion] = upcaster
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	t.Run("registers handler with payload type derived from handler", func(t *testing.T) {
+		consumer := &SpyEventConsumer{}
+		spy := &SpyHandler{}
+
+		err := events.Register(consumer, "topic", 1, spy.EventHandler)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, consumer.topic, "topic")
+		testutil.AssertEqual(t, consumer.eventID, events.EventID(1))
+		testutil.AssertEqual(t, consumer.eventType, reflect.TypeOf(DummyEvent{}))
+
+		event := events.NewEvent(1, 1, DummyEvent{Message: "Hello, World!"})
+		err = consumer.eventHandler(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, spy.message, "Hello, World!")
+	})
+
+	t.Run("registers handler for EventType", func(t *testing.T) {
+		consumer := &SpyEventConsumer{}
+		spy := &SpyHandler{}
+
+		dummyEventType := events.NewEventType[DummyEvent]("topic", 2)
+		dummyEventType.Register(consumer, spy.EventHandler)
+
+		testutil.AssertEqual(t, consumer.topic, dummyEventType.Topic)
+		testutil.AssertEqual(t, consumer.eventID, dummyEventType.ID)
+		testutil.AssertEqual(t, consumer.eventType, reflect.TypeOf(DummyEvent{}))
+	})
+}
+
+func TestEventTypeNew(t *testing.T) {
+	t.Run("creates event of initial version", func(t *testing.T) {
+		dummyEventType := events.NewEventType[DummyEvent]("topic", 2)
+
+		event := dummyEventType.New(10, DummyEvent{Message: "Hello, World!"})
+
+		testutil.AssertEqual(t, event.EventID, dummyEventType.ID)
+		testutil.AssertEqual(t, event.Version, events.InitialSchemaVersion)
+		testutil.AssertEqual(t, event.AggregateID, 10)
+		testutil.AssertEqual(t, event.Payload, interface{}(DummyEvent{Message: "Hello, World!"}))
+	})
+
+	t.Run("creates event of current version", func(t *testing.T) {
+		dummyEventType := events.NewEventType[DummyEventV3]("topic", 2).
+			WithUpcaster(1, renameMessageToText).
+			WithUpcaster(2, addAuthor)
+
+		event := dummyEventType.New(10, DummyEventV3{Text: "Hello, World!", Author: "Vito"})
+
+		testutil.AssertEqual(t, event.Version, 3)
+	})
+}
+
+func TestEventTypeRegisterUpcasters(t *testing.T) {
+	dummyEventType := events.NewEventType[DummyEventV3]("topic", 2).
+		WithUpcaster(1, renameMessageToText).
+		WithUpcaster(2, addAuthor)
+	handler := func(event events.Event[DummyEventV3]) error { return nil }
+
+	t.Run("registers upcasters with consumer", func(t *testing.T) {
+		consumer := &SpyUpcastingEventConsumer{upcasters: events.Upcasters{}}
+
+		err := dummyEventType.Register(consumer, handler)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, consumer.upcasters.Version(), 3)
+	})
+
+	t.Run("returns ErrUpcastingNotSupported on consumer without upcasting", func(t *testing.T) {
+		consumer := &SpyEventConsumer{}
+
+		err := dummyEventType.Register(consumer, handler)
+		testutil.AssertError(t, err, events.ErrUpcastingNotSupported)
+	})
+}
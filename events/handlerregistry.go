@@ -31,6 +31,8 @@ func GetRegistryKey(topic string, eventID EventID) RegistryKey {
 type RegistryEntry struct {
 	eventHandler InterfaceEventHandler
 	eventType    reflect.Type
+	retryPolicy  RetryPolicy
+	upcasters    Upcasters
 }
 
 type ConsumerGroup struct {
@@ -41,6 +43,7 @@ func (r *ConsumerGroup) RegisterEventHandler(topic string, eventID EventID, even
 	entry := RegistryEntry{
 		eventHandler: eventHandler,
 		eventType:    eventType,
+		retryPolicy:  DefaultRetryPolicy,
 	}
 
 	r.eventHandlerRegistry[GetRegistryKey(topic, eventID)] = entry
@@ -51,9 +54,13 @@ type InterfaceEventHandler func(event InterfaceEvent) error
 func EventHandlerWrapper[T any](eventHandler func(event Event[T]) error) InterfaceEventHandler {
 	return InterfaceEventHandler(func(ievent InterfaceEvent) error {
 		event := Event[T]{
+			ID:          ievent.ID,
 			EventID:     ievent.EventID,
+			Version:     ievent.Version,
 			AggregateID: ievent.AggregateID,
 			Timestamp:   ievent.Timestamp,
+			Headers:     ievent.Headers,
+			ctx:         ievent.ctx,
 		}
 
 		if payload, ok := ievent.Payload.(T); ok {
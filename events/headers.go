@@ -0,0 +1,142 @@
+package events
+
+import (
+	"strconv"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	EVENT_ID_HEADER       = "event-id"
+	CORRELATION_ID_HEADER = "correlation-id"
+	CAUSATION_ID_HEADER   = "causation-id"
+	PRODUCER_HEADER       = "producer"
+	CONTENT_TYPE_HEADER   = "content-type"
+	SCHEMA_VERSION_HEADER = "schema-version"
+
+	JSON_CONTENT_TYPE = "application/json"
+)
+
+// EventHeaders are carried in the Kafka message headers rather than the
+// event itself. All events caused by the same initial event share its
+// CorrelationID, while CausationID is the ID of the event that directly
+// caused this one.
+type EventHeaders struct {
+	EventID       string
+	CorrelationID string
+	CausationID   string
+	Producer      string
+	ContentType   string
+	SchemaVersion int
+
+	TraceParent string
+	TraceState  string
+}
+
+// CausedBy returns a copy of event that is correlated with the event cause
+// was read from.
+func (e InterfaceEvent) CausedBy(cause EventHeaders) InterfaceEvent {
+	e.Headers.CorrelationID = cause.CorrelationID
+	if e.Headers.CorrelationID == "" {
+		e.Headers.CorrelationID = cause.EventID
+	}
+	e.Headers.CausationID = cause.EventID
+	e.Headers.TraceParent = cause.TraceParent
+	e.Headers.TraceState = cause.TraceState
+
+	return e
+}
+
+// WithCorrelationID returns a copy of event that continues the correlation
+// with the given ID, such as the one the event's aggregate was created in.
+func (e InterfaceEvent) WithCorrelationID(correlationID string) InterfaceEvent {
+	e.Headers.CorrelationID = correlationID
+
+	return e
+}
+
+// Fills in the headers that are derived from the event and the publishing
+// service. An event that wasn't caused by another one starts a new
+// correlation.
+func newPublishedHeaders(event InterfaceEvent, producer string) EventHeaders {
+	headers := event.Headers
+	headers.EventID = event.ID
+	if headers.CorrelationID == "" {
+		headers.CorrelationID = event.ID
+	}
+	if headers.Producer == "" {
+		headers.Producer = producer
+	}
+	headers.ContentType = JSON_CONTENT_TYPE
+	headers.SchemaVersion = event.Version
+
+	return headers
+}
+
+func NewKafkaHeaders(headers EventHeaders) []sarama.RecordHeader {
+	kafkaHeaders := []sarama.RecordHeader{}
+
+	add := func(key, value string) {
+		if value != "" {
+			kafkaHeaders = append(kafkaHeaders, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+		}
+	}
+
+	add(EVENT_ID_HEADER, headers.EventID)
+	add(CORRELATION_ID_HEADER, headers.CorrelationID)
+	add(CAUSATION_ID_HEADER, headers.CausationID)
+	add(PRODUCER_HEADER, headers.Producer)
+	add(CONTENT_TYPE_HEADER, headers.ContentType)
+	if headers.SchemaVersion != 0 {
+		add(SCHEMA_VERSION_HEADER, strconv.Itoa(headers.SchemaVersion))
+	}
+	add(TRACEPARENT_HEADER, headers.TraceParent)
+	add(TRACESTATE_HEADER, headers.TraceState)
+
+	return kafkaHeaders
+}
+
+// ParseKafkaHeaders reads the event headers of a consumed message. Messages
+// published without headers get their event ID and schema version from the
+// event itself.
+func ParseKafkaHeaders(kafkaHeaders []*sarama.RecordHeader, event RawPayloadEvent) EventHeaders {
+	headers := EventHeaders{}
+
+	for _, header := range kafkaHeaders {
+		value := string(header.Value)
+
+		switch string(header.Key) {
+		case EVENT_ID_HEADER:
+			headers.EventID = value
+		case CORRELATION_ID_HEADER:
+			headers.CorrelationID = value
+		case CAUSATION_ID_HEADER:
+			headers.CausationID = value
+		case PRODUCER_HEADER:
+			headers.Producer = value
+		case CONTENT_TYPE_HEADER:
+			headers.ContentType = value
+		case SCHEMA_VERSION_HEADER:
+			headers.SchemaVersion, _ = strconv.Atoi(value)
+		case TRACEPARENT_HEADER:
+			headers.TraceParent = value
+		case TRACESTATE_HEADER:
+			headers.TraceState = value
+		}
+	}
+
+	if headers.EventID == "" {
+		headers.EventID = event.ID
+	}
+	if headers.CorrelationID == "" {
+		headers.CorrelationID = headers.EventID
+	}
+	if headers.ContentType == "" {
+		headers.ContentType = JSON_CONTENT_TYPE
+	}
+	if headers.SchemaVersion == 0 {
+		headers.SchemaVersion = max(event.Version, InitialSchemaVersion)
+	}
+
+	return headers
+}
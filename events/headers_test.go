@@ -0,0 +1,70 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func toConsumedHeaders(headers []sarama.RecordHeader) []*sarama.RecordHeader {
+	consumed := []*sarama.RecordHeader{}
+	for i := range headers {
+		consumed = append(consumed, &headers[i])
+	}
+
+	return consumed
+}
+
+func TestKafkaHeaders(t *testing.T) {
+	t.Run("parses headers written to Kafka message", func(t *testing.T) {
+		want := events.EventHeaders{
+			EventID:       "event-id",
+			CorrelationID: "correlation-id",
+			CausationID:   "causation-id",
+			Producer:      "test-svc",
+			ContentType:   events.JSON_CONTENT_TYPE,
+			SchemaVersion: 2,
+		}
+
+		kafkaHeaders := events.NewKafkaHeaders(want)
+		got := events.ParseKafkaHeaders(toConsumedHeaders(kafkaHeaders), events.RawPayloadEvent{})
+
+		testutil.AssertEqual(t, got, want)
+	})
+
+	t.Run("derives headers of message published without them from event", func(t *testing.T) {
+		event := events.RawPayloadEvent{ID: "event-id"}
+
+		got := events.ParseKafkaHeaders(nil, event)
+
+		want := events.EventHeaders{
+			EventID:       "event-id",
+			CorrelationID: "event-id",
+			ContentType:   events.JSON_CONTENT_TYPE,
+			SchemaVersion: events.InitialSchemaVersion,
+		}
+		testutil.AssertEqual(t, got, want)
+	})
+}
+
+func TestCausedBy(t *testing.T) {
+	t.Run("keeps correlation ID of cause", func(t *testing.T) {
+		cause := events.EventHeaders{EventID: "cause-id", CorrelationID: "correlation-id"}
+
+		event := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).CausedBy(cause)
+
+		testutil.AssertEqual(t, event.Headers.CorrelationID, "correlation-id")
+		testutil.AssertEqual(t, event.Headers.CausationID, "cause-id")
+	})
+
+	t.Run("correlates with cause that has no correlation ID", func(t *testing.T) {
+		cause := events.EventHeaders{EventID: "cause-id"}
+
+		event := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).CausedBy(cause)
+
+		testutil.AssertEqual(t, event.Headers.CorrelationID, "cause-id")
+		testutil.AssertEqual(t, event.Headers.CausationID, "cause-id")
+	})
+}
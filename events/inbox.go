@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrDuplicateEvent = errors.New("event has already been processed")
+
+type InboxStore interface {
+	IsProcessed(key string) (bool, error)
+	MarkProcessed(key string) error
+}
+
+// GetInboxKey identifies a consumed event by its ID, falling back to the
+// message's position in the log for events published without one.
+func GetInboxKey(topic string, partition int32, offset int64, eventID string) string {
+	if eventID != "" {
+		return eventID
+	}
+	return fmt.Sprintf("%s-%d-%d", topic, partition, offset)
+}
+
+// HandleOnce runs handler unless the event's inbox key is already recorded.
+// When the inbox supports transactions the key is recorded in a transaction
+// that is passed to the handler through the event's context, so the
+// handler's writes and the inbox entry are committed together and a
+// concurrent duplicate is rejected by the inbox. Otherwise the inbox is
+// checked before and marked after the handler runs.
+func HandleOnce(ctx context.Context, inbox InboxStore, key string, event InterfaceEvent, handler InterfaceEventHandler) error {
+	if inbox == nil {
+		return handler(event)
+	}
+
+	err := pgconfig.RunInTx(ctx, inbox, func(tx pgx.Tx) error {
+		if tx == nil {
+			return handleUnlessProcessed(inbox, key, event, handler)
+		}
+
+		err := pgconfig.WithTx(inbox, tx).MarkProcessed(key)
+		if err != nil {
+			return err
+		}
+
+		return handler(event.WithContext(pgconfig.ContextWithTx(ctx, tx)))
+	})
+	if errors.Is(err, ErrDuplicateEvent) {
+		return nil
+	}
+
+	return err
+}
+
+func handleUnlessProcessed(inbox InboxStore, key string, event InterfaceEvent, handler InterfaceEventHandler) error {
+	processed, err := inbox.IsProcessed(key)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return ErrDuplicateEvent
+	}
+
+	err = handler(event)
+	if err != nil {
+		return err
+	}
+
+	return inbox.MarkProcessed(key)
+}
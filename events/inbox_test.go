@@ -0,0 +1,73 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestInbox(t *testing.T) {
+	t.Run("uses event ID as inbox key", func(t *testing.T) {
+		got := events.GetInboxKey("test-topic", 0, 42, "3f1c")
+		testutil.AssertEqual(t, got, "3f1c")
+	})
+
+	t.Run("falls back to message position without event ID", func(t *testing.T) {
+		got := events.GetInboxKey("test-topic", 1, 42, "")
+		testutil.AssertEqual(t, got, "test-topic-1-42")
+	})
+
+	t.Run("reports duplicate events", func(t *testing.T) {
+		inbox := events.NewInMemoryInboxStore()
+
+		processed, err := inbox.IsProcessed("3f1c")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, processed, false)
+
+		err = inbox.MarkProcessed("3f1c")
+		testutil.AssertNoErr(t, err)
+
+		processed, err = inbox.IsProcessed("3f1c")
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, processed, true)
+
+		err = inbox.MarkProcessed("3f1c")
+		testutil.AssertError(t, err, events.ErrDuplicateEvent)
+	})
+	t.Run("handles an event only once", func(t *testing.T) {
+		inbox := events.NewInMemoryInboxStore()
+		event := events.InterfaceEvent{ID: "3f1c"}
+
+		calls := 0
+		handler := func(events.InterfaceEvent) error {
+			calls++
+			return nil
+		}
+
+		err := events.HandleOnce(context.Background(), inbox, event.ID, event, handler)
+		testutil.AssertNoErr(t, err)
+
+		err = events.HandleOnce(context.Background(), inbox, event.ID, event, handler)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, calls, 1)
+	})
+
+	t.Run("doesn't record an event whose handler failed", func(t *testing.T) {
+		inbox := events.NewInMemoryInboxStore()
+		event := events.InterfaceEvent{ID: "3f1c"}
+		handlerErr := errors.New("handler failed")
+
+		err := events.HandleOnce(context.Background(), inbox, event.ID, event, func(events.InterfaceEvent) error {
+			return handlerErr
+		})
+		testutil.AssertError(t, err, handlerErr)
+
+		processed, err := inbox.IsProcessed(event.ID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, processed, false)
+	})
+}
@@ -0,0 +1,31 @@
+package events
+
+import "sync"
+
+type InMemoryInboxStore struct {
+	mu        sync.Mutex
+	processed map[string]bool
+}
+
+func NewInMemoryInboxStore() *InMemoryInboxStore {
+	return &InMemoryInboxStore{processed: map[string]bool{}}
+}
+
+func (i *InMemoryInboxStore) IsProcessed(key string) (bool, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.processed[key], nil
+}
+
+func (i *InMemoryInboxStore) MarkProcessed(key string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.processed[key] {
+		return ErrDuplicateEvent
+	}
+	i.processed[key] = true
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrOutboxMessageNotFound = errors.New("outbox message not found")
+
+type InMemoryOutboxStore struct {
+	mu       sync.Mutex
+	messages []OutboxMessage
+}
+
+func NewInMemoryOutboxStore() *InMemoryOutboxStore {
+	return &InMemoryOutboxStore{messages: []OutboxMessage{}}
+}
+
+func (i *InMemoryOutboxStore) CreateMessage(message *OutboxMessage) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	message.ID = len(i.messages) + 1
+	i.messages = append(i.messages, *message)
+
+	return nil
+}
+
+func (i *InMemoryOutboxStore) GetPendingMessages(limit int) ([]OutboxMessage, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	pending := []OutboxMessage{}
+	for _, message := range i.messages {
+		if len(pending) == limit {
+			break
+		}
+		if !message.Sent {
+			pending = append(pending, message)
+		}
+	}
+
+	return pending, nil
+}
+
+func (i *InMemoryOutboxStore) MarkMessageSent(id int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for j := range i.messages {
+		if i.messages[j].ID == id {
+			i.messages[j].Sent = true
+			return nil
+		}
+	}
+	return ErrOutboxMessageNotFound
+}
+
+func (i *InMemoryOutboxStore) IncrementAttempts(id int) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for j := range i.messages {
+		if i.messages[j].ID == id {
+			i.messages[j].Attempts++
+			return nil
+		}
+	}
+	return ErrOutboxMessageNotFound
+}
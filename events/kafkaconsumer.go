@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/IBM/sarama"
 )
 
+const consumeRetryInterval = time.Second
+
 type KafkaEventConsumer struct {
 	group                sarama.ConsumerGroup
 	groupHandler         KafkaConsumerGroupHandler
 	topicRegistry        TopicRegistry
 	eventHandlerRegistry map[RegistryKey]RegistryEntry
+	inbox                InboxStore
+	deadLetterPublisher  DeadLetterPublisher
 
 	ErrorsChan chan error
 }
@@ -47,15 +52,60 @@ func (k *KafkaEventConsumer) RegisterEventHandler(topic string, eventID EventID,
 	entry := RegistryEntry{
 		eventHandler: eventHandler,
 		eventType:    eventType,
+		retryPolicy:  DefaultRetryPolicy,
 	}
 	k.eventHandlerRegistry[GetRegistryKey(topic, eventID)] = entry
 
 	return nil
 }
 
+func (k *KafkaEventConsumer) SetRetryPolicy(topic string, eventID EventID, retryPolicy RetryPolicy) error {
+	key := GetRegistryKey(topic, eventID)
+
+	entry, ok := k.eventHandlerRegistry[key]
+	if !ok {
+		return ErrHandlerNotRegistered
+	}
+
+	entry.retryPolicy = retryPolicy
+	k.eventHandlerRegistry[key] = entry
+
+	return nil
+}
+
+func (k *KafkaEventConsumer) RegisterUpcaster(topic string, eventID EventID, fromVersion int, upcaster Upcaster) error {
+	key := GetRegistryKey(topic, eventID)
+
+	entry, ok := k.eventHandlerRegistry[key]
+	if !ok {
+		return ErrHandlerNotRegistered
+	}
+
+	upcasters := Upcasters{}
+	for version, u := range entry.upcasters {
+		upcasters[version] = u
+	}
+	upcasters[fromVersion] = upcaster
+
+	entry.upcasters = upcasters
+	k.eventHandlerRegistry[key] = entry
+
+	return nil
+}
+
+func (k *KafkaEventConsumer) SetDeadLetterPublisher(deadLetterPublisher DeadLetterPublisher) {
+	k.deadLetterPublisher = deadLetterPublisher
+}
+
+func (k *KafkaEventConsumer) SetInboxStore(inbox InboxStore) {
+	k.inbox = inbox
+}
+
 func (k *KafkaEventConsumer) Run(ctx context.Context) {
 	topics := k.topicRegistry.GetTopics()
 	k.groupHandler.eventHandlerRegistry = k.eventHandlerRegistry
+	k.groupHandler.inbox = k.inbox
+	k.groupHandler.deadLetterPublisher = k.deadLetterPublisher
 
 	for {
 		select {
@@ -67,8 +117,16 @@ func (k *KafkaEventConsumer) Run(ctx context.Context) {
 			}
 		default:
 			err := k.group.Consume(ctx, topics, &k.groupHandler)
-			if err != nil {
-				// figure out what to do when group.Consume returns an error
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return
+			} else if err != nil {
+				k.handleConsumerError(err)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(consumeRetryInterval):
+				}
 			}
 		}
 	}
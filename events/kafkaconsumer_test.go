@@ -105,6 +105,54 @@ func TestKafkaEventConsumer(t *testing.T) {
 		testutil.AssertEqual(t, handlerB.message, payloadB.Message)
 	})
 
+	t.Run("upcasts payload of older schema version", func(t *testing.T) {
+		topic := "topic-upcast"
+		var got DummyEventV3
+		dummyEventType := events.NewEventType[DummyEventV3](topic, 1).
+			WithUpcaster(1, renameMessageToText).
+			WithUpcaster(2, addAuthor)
+		dummyEventType.Register(kafkaEventConsumer, func(event events.Event[DummyEventV3]) error {
+			got = event.Payload
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go kafkaEventConsumer.Run(ctx)
+		t.Cleanup(cancel)
+
+		message := NewMarshaledEvent(1, 1, DummyEvent{"Hello, World"})
+		produceMessage(t, containerID, topic, string(message))
+
+		testutil.AssertEqual(t, got, DummyEventV3{Text: "Hello, World", Author: "unknown"})
+	})
+
+	t.Run("writes ErrMalformedEvent on undecodable payload to ErrorsChan", func(t *testing.T) {
+		malformedTopic := "topic-malformed"
+		spy := SpyHandler{}
+		events.Register(kafkaEventConsumer, malformedTopic, 1, spy.EventHandler)
+
+		kafkaCtx, kafkaCancel := context.WithCancel(context.Background())
+		go kafkaEventConsumer.Run(kafkaCtx)
+		t.Cleanup(kafkaCancel)
+
+		message := NewMarshaledEvent(1, 1, DummyEventV3{Text: "Hello, World"})
+		produceMessage(t, containerID, malformedTopic, string(message))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(cancel)
+
+		select {
+		case err := <-kafkaEventConsumer.ErrorsChan:
+			if !errors.Is(err, events.ErrMalformedEvent) {
+				t.Errorf("got error %v want %v", err, events.ErrMalformedEvent)
+			}
+		case <-ctx.Done():
+			t.Fatalf("didn't receive error before timeout")
+		}
+
+		testutil.AssertEqual(t, spy.message, "")
+	})
+
 	t.Run("writes error from event handler to ErrorsChan", func(t *testing.T) {
 		errTopic := "topic-err"
 		errHandler := SpyHandler{err: DummyError}
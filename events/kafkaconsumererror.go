@@ -7,7 +7,11 @@ import (
 	"github.com/IBM/sarama"
 )
 
-var ErrRegisterHanlderNotPermited = errors.New("cannot register event handler while consumer is running")
+var (
+	ErrRegisterHanlderNotPermited = errors.New("cannot register event handler while consumer is running")
+	ErrHandlerNotRegistered       = errors.New("no event handler registered for this topic and event")
+	ErrMissingOriginalTopic       = errors.New("dead letter is missing its original topic header")
+)
 
 type ConsumerError struct {
 	Topic     string
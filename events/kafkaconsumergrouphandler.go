@@ -2,37 +2,77 @@ package events
 
 import (
 	"encoding/json"
-	"reflect"
+	"fmt"
+	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/VitoNaychev/food-app/metrics"
 )
 
 type KafkaConsumerGroupHandler struct {
 	eventHandlerRegistry map[RegistryKey]RegistryEntry
+	inbox                InboxStore
+	deadLetterPublisher  DeadLetterPublisher
 }
 
 func (b *KafkaConsumerGroupHandler) Setup(sarama.ConsumerGroupSession) error { return nil }
 
 func (b *KafkaConsumerGroupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claims sarama.ConsumerGroupClaim) error {
 	for message := range claims.Messages() {
+		metrics.SetConsumerLag(message.Topic, message.Partition, claims.HighWaterMarkOffset()-message.Offset-1)
+
 		var rawPayloadEvent RawPayloadEvent
-		json.Unmarshal(message.Value, &rawPayloadEvent)
+		err := json.Unmarshal(message.Value, &rawPayloadEvent)
+		if err != nil {
+			err = b.handleMalformedMessage(sess, message, err)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
 		if registryEntry, ok := b.eventHandlerRegistry[GetRegistryKey(claims.Topic(), rawPayloadEvent.EventID)]; ok {
-			payloadPtr := reflect.New(registryEntry.eventType).Interface()
-			json.Unmarshal(rawPayloadEvent.Payload, payloadPtr)
+			payload, err := decodeRegisteredPayload(rawPayloadEvent, registryEntry)
+			if err != nil {
+				err = b.handleMalformedMessage(sess, message, err)
+				if err != nil {
+					return err
+				}
+				continue
+			}
 
-			payload := reflect.ValueOf(payloadPtr).Elem().Interface()
 			event := InterfaceEvent{
+				ID:          rawPayloadEvent.ID,
 				EventID:     rawPayloadEvent.EventID,
+				Version:     registryEntry.upcasters.Version(),
 				AggregateID: rawPayloadEvent.AggregateID,
 				Timestamp:   rawPayloadEvent.Timestamp,
 				Payload:     payload,
+				Headers:     ParseKafkaHeaders(message.Headers, rawPayloadEvent),
+			}
+
+			inboxKey := GetInboxKey(message.Topic, message.Partition, message.Offset, rawPayloadEvent.ID)
+			handler := func(event InterfaceEvent) error {
+				return runTracedEventHandler(registryEntry.eventHandler, event, message.Topic)
 			}
 
-			err := registryEntry.eventHandler(event)
+			start := time.Now()
+			attempts, err := registryEntry.retryPolicy.Run(sess.Context(), func() error {
+				return HandleOnce(sess.Context(), b.inbox, inboxKey, event, handler)
+			})
+			metrics.ObserveEventHandled(message.Topic, int(event.EventID), time.Since(start), err)
 			if err != nil {
-				return err
+				if b.deadLetterPublisher == nil || sess.Context().Err() != nil {
+					return err
+				}
+
+				dlqErr := b.deadLetterPublisher.PublishDeadLetter(message, attempts, err)
+				if dlqErr != nil {
+					return dlqErr
+				}
+
+				sess.MarkMessage(message, "")
+				continue
 			}
 		}
 		sess.MarkMessage(message, "")
@@ -40,4 +80,32 @@ func (b *KafkaConsumerGroupHandler) ConsumeClaim(sess sarama.ConsumerGroupSessio
 	return nil
 }
 
+// Messages that can't be decoded will never be handled successfully, so
+// they are dead-lettered without being retried.
+func (b *KafkaConsumerGroupHandler) handleMalformedMessage(sess sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, err error) error {
+	metrics.ObserveMalformedEvent(message.Topic)
+
+	err = fmt.Errorf("%w: %w", ErrMalformedEvent, err)
+	if b.deadLetterPublisher == nil {
+		return err
+	}
+
+	dlqErr := b.deadLetterPublisher.PublishDeadLetter(message, 0, err)
+	if dlqErr != nil {
+		return dlqErr
+	}
+
+	sess.MarkMessage(message, "")
+	return nil
+}
+
+func decodeRegisteredPayload(rawPayloadEvent RawPayloadEvent, registryEntry RegistryEntry) (interface{}, error) {
+	payload, err := registryEntry.upcasters.Upcast(rawPayloadEvent.Version, rawPayloadEvent.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePayload(payload, registryEntry.eventType)
+}
+
 func (b *KafkaConsumerGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
@@ -5,10 +5,14 @@ import (
 	"strconv"
 
 	"github.com/IBM/sarama"
+	"github.com/VitoNaychev/food-app/metrics"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type KafkaEventPublisher struct {
-	producer sarama.SyncProducer
+	producer     sarama.SyncProducer
+	producerName string
 }
 
 func NewKafkaEventPublisher(brokersAddrs []string) (*KafkaEventPublisher, error) {
@@ -24,6 +28,12 @@ func NewKafkaEventPublisher(brokersAddrs []string) (*KafkaEventPublisher, error)
 	return &KafkaEventPublisher{producer: producer}, nil
 }
 
+// SetProducer sets the name of the service published events are marked
+// as produced by.
+func (k *KafkaEventPublisher) SetProducer(producerName string) {
+	k.producerName = producerName
+}
+
 func (k *KafkaEventPublisher) Close() {
 	k.producer.Close()
 }
@@ -34,8 +44,19 @@ func (k *KafkaEventPublisher) Publish(topic string, event InterfaceEvent) error
 	key := sarama.StringEncoder(strconv.Itoa(event.AggregateID))
 	value := sarama.ByteEncoder(eventJSON)
 
-	message := &sarama.ProducerMessage{Topic: topic, Key: key, Value: value}
+	ctx, span := startEventSpan(event, topic, "publish", trace.SpanKindProducer)
+	defer span.End()
+
+	headers := newPublishedHeaders(event, k.producerName)
+	headers.setTraceContext(ctx)
+
+	message := &sarama.ProducerMessage{Topic: topic, Key: key, Value: value, Headers: NewKafkaHeaders(headers)}
 	_, _, err := k.producer.SendMessage(message)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	metrics.ObserveEventPublished(topic, int(event.EventID), err)
 
 	return err
 }
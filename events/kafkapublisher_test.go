@@ -38,6 +38,7 @@ func UnmarshalEvent[T any](data []byte) (event events.Event[T]) {
 
 func EventToInterfaceEvent[T any](generic events.Event[T]) events.InterfaceEvent {
 	event := events.InterfaceEvent{
+		ID:          generic.ID,
 		EventID:     generic.EventID,
 		AggregateID: generic.AggregateID,
 		Timestamp:   generic.Timestamp,
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type OutboxMessage struct {
+	ID          int
+	Topic       string
+	AggregateID int `db:"aggregate_id"`
+	Payload     []byte
+	Headers     []byte
+	Attempts    int
+	Sent        bool
+}
+
+type OutboxStore interface {
+	CreateMessage(*OutboxMessage) error
+	GetPendingMessages(limit int) ([]OutboxMessage, error)
+	MarkMessageSent(id int) error
+	IncrementAttempts(id int) error
+}
+
+type OutboxPublisher struct {
+	store OutboxStore
+}
+
+func NewOutboxPublisher(store OutboxStore) *OutboxPublisher {
+	return &OutboxPublisher{store: store}
+}
+
+// WithTx returns a publisher that stores messages through tx, so an event
+// is only published if the writes it describes are committed.
+func (o *OutboxPublisher) WithTx(tx pgx.Tx) EventPublisher {
+	return NewOutboxPublisher(pgconfig.WithTx(o.store, tx))
+}
+
+func (o *OutboxPublisher) Publish(topic string, event InterfaceEvent) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	headersJSON, err := json.Marshal(event.Headers)
+	if err != nil {
+		return err
+	}
+
+	message := OutboxMessage{
+		Topic:       topic,
+		AggregateID: event.AggregateID,
+		Payload:     eventJSON,
+		Headers:     headersJSON,
+	}
+
+	return o.store.CreateMessage(&message)
+}
+
+func OutboxMessageToInterfaceEvent(message OutboxMessage) (InterfaceEvent, error) {
+	var rawEvent RawPayloadEvent
+	err := json.Unmarshal(message.Payload, &rawEvent)
+	if err != nil {
+		return InterfaceEvent{}, err
+	}
+
+	var headers EventHeaders
+	if len(message.Headers) != 0 {
+		err = json.Unmarshal(message.Headers, &headers)
+		if err != nil {
+			return InterfaceEvent{}, err
+		}
+	}
+
+	event := InterfaceEvent{
+		ID:          rawEvent.ID,
+		EventID:     rawEvent.EventID,
+		Version:     rawEvent.Version,
+		AggregateID: rawEvent.AggregateID,
+		Timestamp:   rawEvent.Timestamp,
+		Payload:     rawEvent.Payload,
+		Headers:     headers,
+	}
+
+	return event, nil
+}
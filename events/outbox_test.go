@@ -0,0 +1,124 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+type SpyPublisher struct {
+	Topics []string
+	Events []events.InterfaceEvent
+	Err    error
+}
+
+func (s *SpyPublisher) Publish(topic string, event events.InterfaceEvent) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.Topics = append(s.Topics, topic)
+	s.Events = append(s.Events, event)
+	return nil
+}
+
+type FailingIncrementOutboxStore struct {
+	*events.InMemoryOutboxStore
+	Err error
+}
+
+func (f *FailingIncrementOutboxStore) IncrementAttempts(id int) error {
+	return f.Err
+}
+
+func TestOutbox(t *testing.T) {
+	topic := "test-topic"
+
+	t.Run("publishes outbox messages and marks them sent", func(t *testing.T) {
+		store := events.NewInMemoryOutboxStore()
+		publisher := events.NewOutboxPublisher(store)
+		spy := &SpyPublisher{}
+		relay := events.NewOutboxRelay(store, spy, events.DefaultOutboxRelayConfig)
+
+		want := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{"Hello, World!"})
+		err := publisher.Publish(topic, want)
+		testutil.AssertNoErr(t, err)
+
+		err = relay.Relay()
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, len(spy.Events), 1)
+		testutil.AssertEqual(t, spy.Topics[0], topic)
+
+		var got DummyEvent
+		json.Unmarshal(spy.Events[0].Payload.(json.RawMessage), &got)
+		testutil.AssertEqual(t, got, want.Payload.(DummyEvent))
+		testutil.AssertEqual(t, spy.Events[0].EventID, want.EventID)
+		testutil.AssertEqual(t, spy.Events[0].AggregateID, want.AggregateID)
+
+		pending, err := store.GetPendingMessages(10)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(pending), 0)
+	})
+
+	t.Run("keeps messages pending when publishing fails", func(t *testing.T) {
+		store := events.NewInMemoryOutboxStore()
+		publisher := events.NewOutboxPublisher(store)
+		spy := &SpyPublisher{Err: errors.New("broker unavailable")}
+		relay := events.NewOutboxRelay(store, spy, events.DefaultOutboxRelayConfig)
+
+		err := publisher.Publish(topic, events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{"Hello, World!"}))
+		testutil.AssertNoErr(t, err)
+
+		err = relay.Relay()
+		if err == nil {
+			t.Fatalf("expected error but didn't get one")
+		}
+
+		pending, err := store.GetPendingMessages(10)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(pending), 1)
+		testutil.AssertEqual(t, pending[0].Attempts, 1)
+
+		spy.Err = nil
+		err = relay.Relay()
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(spy.Events), 1)
+	})
+
+	t.Run("reports a failure to increment attempts", func(t *testing.T) {
+		incrementErr := errors.New("database unavailable")
+		store := &FailingIncrementOutboxStore{events.NewInMemoryOutboxStore(), incrementErr}
+		publisher := events.NewOutboxPublisher(store)
+		publishErr := errors.New("broker unavailable")
+		relay := events.NewOutboxRelay(store, &SpyPublisher{Err: publishErr}, events.DefaultOutboxRelayConfig)
+
+		err := publisher.Publish(topic, events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{"Hello, World!"}))
+		testutil.AssertNoErr(t, err)
+
+		err = relay.Relay()
+		if !errors.Is(err, publishErr) || !errors.Is(err, incrementErr) {
+			t.Errorf("got error %v, want it to wrap %v and %v", err, publishErr, incrementErr)
+		}
+	})
+
+	t.Run("keeps headers of outbox messages", func(t *testing.T) {
+		store := events.NewInMemoryOutboxStore()
+		publisher := events.NewOutboxPublisher(store)
+		spy := &SpyPublisher{}
+		relay := events.NewOutboxRelay(store, spy, events.DefaultOutboxRelayConfig)
+
+		cause := events.EventHeaders{EventID: "cause-id", CorrelationID: "correlation-id"}
+		want := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{"Hello, World!"}).CausedBy(cause)
+		err := publisher.Publish(topic, want)
+		testutil.AssertNoErr(t, err)
+
+		err = relay.Relay()
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, spy.Events[0].Headers, want.Headers)
+	})
+}
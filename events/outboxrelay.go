@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+type OutboxRelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxBackoff   time.Duration
+}
+
+var DefaultOutboxRelayConfig = OutboxRelayConfig{
+	PollInterval: 500 * time.Millisecond,
+	BatchSize:    100,
+	MaxBackoff:   30 * time.Second,
+}
+
+type OutboxRelay struct {
+	store     OutboxStore
+	publisher EventPublisher
+	config    OutboxRelayConfig
+}
+
+func NewOutboxRelay(store OutboxStore, publisher EventPublisher, config OutboxRelayConfig) *OutboxRelay {
+	return &OutboxRelay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+func (o *OutboxRelay) Run(ctx context.Context) {
+	backoff := o.config.PollInterval
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+			err := o.Relay()
+			if err != nil {
+				log.Println("Outbox Relay: ", err)
+				backoff = min(backoff*2, o.config.MaxBackoff)
+			} else {
+				backoff = o.config.PollInterval
+			}
+		}
+	}
+}
+
+// Relay publishes pending messages in insertion order and stops at the first
+// failure so that per-aggregate ordering is preserved across retries.
+func (o *OutboxRelay) Relay() error {
+	messages, err := o.store.GetPendingMessages(o.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		event, err := OutboxMessageToInterfaceEvent(message)
+		if err != nil {
+			return fmt.Errorf("outbox message %d: %w", message.ID, err)
+		}
+
+		err = o.publisher.Publish(message.Topic, event)
+		if err != nil {
+			incrementErr := o.store.IncrementAttempts(message.ID)
+			if incrementErr != nil {
+				return fmt.Errorf("outbox message %d: %w, incrementing attempts: %w", message.ID, err, incrementErr)
+			}
+			return fmt.Errorf("outbox message %d: %w", message.ID, err)
+		}
+
+		err = o.store.MarkMessageSent(message.ID)
+		if err != nil {
+			return fmt.Errorf("outbox message %d: %w", message.ID, err)
+		}
+	}
+
+	return nil
+}
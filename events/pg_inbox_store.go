@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgInboxStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgInboxStore(ctx context.Context, connString string) (*PgInboxStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgInboxStore{conn}, nil
+}
+
+func (p *PgInboxStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+// WithTx returns a store that records processed events through tx. Marking
+// an event processed before the handler's writes in the same transaction
+// gives exactly-once effects, since a duplicate aborts the transaction.
+func (p *PgInboxStore) WithTx(tx pgx.Tx) InboxStore {
+	return &PgInboxStore{tx}
+}
+
+func (p *PgInboxStore) IsProcessed(key string) (bool, error) {
+	query := `select exists(select 1 from inbox where key=@key)`
+	args := pgx.NamedArgs{
+		"key": key,
+	}
+
+	var processed bool
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&processed)
+
+	return processed, err
+}
+
+func (p *PgInboxStore) MarkProcessed(key string) error {
+	query := `insert into inbox(key) values (@key) on conflict do nothing`
+	args := pgx.NamedArgs{
+		"key": key,
+	}
+
+	tag, err := p.conn.Exec(context.Background(), query, args)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrDuplicateEvent
+	}
+
+	return nil
+}
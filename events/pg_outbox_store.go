@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgOutboxStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgOutboxStore(ctx context.Context, connString string) (*PgOutboxStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgOutboxStore{conn}, nil
+}
+
+func (p *PgOutboxStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+// WithTx returns a store that writes through tx, so that outbox messages are
+// committed or rolled back together with the aggregate they describe.
+func (p *PgOutboxStore) WithTx(tx pgx.Tx) OutboxStore {
+	return &PgOutboxStore{tx}
+}
+
+func (p *PgOutboxStore) CreateMessage(message *OutboxMessage) error {
+	query := `insert into outbox(topic, aggregate_id, payload, headers) 
+		values (@topic, @aggregate_id, @payload, @headers) returning id`
+	args := pgx.NamedArgs{
+		"topic":        message.Topic,
+		"aggregate_id": message.AggregateID,
+		"payload":      message.Payload,
+		"headers":      message.Headers,
+	}
+
+	return p.conn.QueryRow(context.Background(), query, args).Scan(&message.ID)
+}
+
+func (p *PgOutboxStore) GetPendingMessages(limit int) ([]OutboxMessage, error) {
+	query := `select * from outbox where sent=false order by id limit @limit`
+	args := pgx.NamedArgs{
+		"limit": limit,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	return pgx.CollectRows(rows, pgx.RowToStructByName[OutboxMessage])
+}
+
+func (p *PgOutboxStore) MarkMessageSent(id int) error {
+	query := `update outbox set sent=true where id=@id`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return err
+}
+
+func (p *PgOutboxStore) IncrementAttempts(id int) error {
+	query := `update outbox set attempts=attempts+1 where id=@id`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return err
+}
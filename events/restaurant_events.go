@@ -8,10 +8,24 @@ const (
 	MENU_ITEM_CREATED_EVENT_ID
 	MENU_ITEM_DELETED_EVENT_ID
 	MENU_ITEM_UPDATED_EVENT_ID
+	RESTAURANT_ADDRESS_CREATED_EVENT_ID
+	RESTAURANT_ADDRESS_UPDATED_EVENT_ID
+	RESTAURANT_HOURS_SET_EVENT_ID
+	RESTAURANT_STATUS_UPDATED_EVENT_ID
+	RESTAURANT_UPDATED_EVENT_ID
 )
 
 type RestaurantCreatedEvent struct {
-	ID int `validate:"min=1"             json:"id"`
+	ID   int    `validate:"min=1"             json:"id"`
+	Name string `validate:"max=40"            json:"name"`
+	IBAN string `validate:"max=34"            json:"iban"`
+}
+
+type RestaurantUpdatedEvent struct {
+	ID       int    `validate:"min=1"             json:"id"`
+	Name     string `validate:"max=40"            json:"name"`
+	IBAN     string `validate:"max=34"            json:"iban"`
+	Timezone string `validate:"max=64"            json:"timezone"`
 }
 
 type RestaurantDeletedEvent struct {
@@ -35,3 +49,42 @@ type MenuItemCreatedEvent struct {
 	Name         string  `validate:"min=2,max=20"      json:"name"`
 	Price        float32 `validate:"required,max=1000" json:"price"`
 }
+
+type RestaurantAddressCreatedEvent struct {
+	ID           int     `validate:"min=1"             json:"id"`
+	RestaurantID int     `validate:"min=1"             json:"restaurant_id"`
+	Lat          float64 `validate:"latitude"          json:"lat"`
+	Lon          float64 `validate:"longitude"         json:"lon"`
+	AddressLine1 string  `validate:"required,max=100"  json:"address_line1"`
+	AddressLine2 string  `validate:"max=100"           json:"address_line2"`
+	City         string  `validate:"required,max=70"   json:"city"`
+	Country      string  `validate:"required,max=60"   json:"country"`
+}
+
+type RestaurantAddressUpdatedEvent struct {
+	ID           int     `validate:"min=1"             json:"id"`
+	RestaurantID int     `validate:"min=1"             json:"restaurant_id"`
+	Lat          float64 `validate:"latitude"          json:"lat"`
+	Lon          float64 `validate:"longitude"         json:"lon"`
+	AddressLine1 string  `validate:"required,max=100"  json:"address_line1"`
+	AddressLine2 string  `validate:"max=100"           json:"address_line2"`
+	City         string  `validate:"required,max=70"   json:"city"`
+	Country      string  `validate:"required,max=60"   json:"country"`
+}
+
+type RestaurantHoursSetEvent struct {
+	RestaurantID int                          `validate:"min=1"                 json:"restaurant_id"`
+	Timezone     string                       `validate:"required"              json:"timezone"`
+	Hours        []RestaurantHoursSetEventDay `validate:"required,len=7,dive"   json:"hours"`
+}
+
+type RestaurantHoursSetEventDay struct {
+	Day     int    `validate:"min=1,max=7"           json:"day"`
+	Opening string `validate:"required,workinghours" json:"opening"`
+	Closing string `validate:"required,workinghours" json:"closing"`
+}
+
+type RestaurantStatusUpdatedEvent struct {
+	ID     int `validate:"min=1"             json:"id"`
+	Status int `validate:"min=0"             json:"status"`
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// Backoff returns the delay before the given retry, doubling from
+// InitialBackoff and capped at MaxBackoff.
+func (r RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := r.InitialBackoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if backoff >= r.MaxBackoff {
+			return r.MaxBackoff
+		}
+	}
+
+	return min(backoff, r.MaxBackoff)
+}
+
+func (r RetryPolicy) Run(ctx context.Context, fn func() error) (attempts int, err error) {
+	for attempts = 1; ; attempts++ {
+		err = fn()
+		if err == nil || attempts >= r.MaxAttempts {
+			return attempts, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(r.Backoff(attempts)):
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := events.RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     3 * time.Millisecond,
+	}
+
+	t.Run("backs off exponentially up to MaxBackoff", func(t *testing.T) {
+		testutil.AssertEqual(t, policy.Backoff(1), time.Millisecond)
+		testutil.AssertEqual(t, policy.Backoff(2), 2*time.Millisecond)
+		testutil.AssertEqual(t, policy.Backoff(3), 3*time.Millisecond)
+		testutil.AssertEqual(t, policy.Backoff(10), 3*time.Millisecond)
+	})
+
+	t.Run("stops retrying on success", func(t *testing.T) {
+		calls := 0
+		attempts, err := policy.Run(context.Background(), func() error {
+			calls++
+			if calls < 2 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, attempts, 2)
+	})
+
+	t.Run("returns last error after MaxAttempts", func(t *testing.T) {
+		wantErr := errors.New("poison")
+		attempts, err := policy.Run(context.Background(), func() error {
+			return wantErr
+		})
+
+		testutil.AssertError(t, err, wantErr)
+		testutil.AssertEqual(t, attempts, policy.MaxAttempts)
+	})
+}
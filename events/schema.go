@@ -0,0 +1,83 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Events published before payloads were versioned carry no version and
+// are treated as the initial version.
+const InitialSchemaVersion = 1
+
+var (
+	ErrMalformedEvent        = errors.New("malformed event")
+	ErrUnknownSchemaVersion  = errors.New("unknown event schema version")
+	ErrUpcasterNotRegistered = errors.New("no upcaster registered for event schema version")
+	ErrUpcastingNotSupported = errors.New("event consumer doesn't support upcasting")
+)
+
+// Upcaster converts a payload of a schema version to the next version.
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// Upcasters are keyed by the schema version they convert from. The current
+// version of a schema is the one after the last upcaster.
+type Upcasters map[int]Upcaster
+
+func (u Upcasters) Version() int {
+	version := InitialSchemaVersion
+	for from := range u {
+		version = max(version, from+1)
+	}
+
+	return version
+}
+
+// Upcast converts payload from version to the current schema version.
+func (u Upcasters) Upcast(version int, payload json.RawMessage) (json.RawMessage, error) {
+	if version == 0 {
+		version = InitialSchemaVersion
+	}
+
+	current := u.Version()
+	if version < InitialSchemaVersion || version > current {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSchemaVersion, version)
+	}
+
+	for ; version < current; version++ {
+		upcaster, ok := u[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUpcasterNotRegistered, version)
+		}
+
+		var err error
+		payload, err = upcaster(payload)
+		if err != nil {
+			return nil, fmt.Errorf("upcasting from version %d: %w", version, err)
+		}
+	}
+
+	return payload, nil
+}
+
+// DecodePayload strictly decodes payload into a value of eventType, so a
+// payload that doesn't match the schema isn't handled with zeroed fields.
+func DecodePayload(payload json.RawMessage, eventType reflect.Type) (interface{}, error) {
+	payloadPtr := reflect.New(eventType).Interface()
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(payloadPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after payload")
+	}
+
+	return reflect.ValueOf(payloadPtr).Elem().Interface(), nil
+}
@@ -0,0 +1,129 @@
+package events_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+type DummyEventV3 struct {
+	Text   string
+	Author string
+}
+
+func renameMessageToText(payload json.RawMessage) (json.RawMessage, error) {
+	var v1 struct{ Message string }
+	err := json.Unmarshal(payload, &v1)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct{ Text string }{v1.Message})
+}
+
+func addAuthor(payload json.RawMessage) (json.RawMessage, error) {
+	var v2 struct{ Text string }
+	err := json.Unmarshal(payload, &v2)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(DummyEventV3{Text: v2.Text, Author: "unknown"})
+}
+
+func TestUpcasters(t *testing.T) {
+	upcasters := events.Upcasters{
+		1: renameMessageToText,
+		2: addAuthor,
+	}
+
+	t.Run("current version is the one after the last upcaster", func(t *testing.T) {
+		testutil.AssertEqual(t, events.Upcasters{}.Version(), events.InitialSchemaVersion)
+		testutil.AssertEqual(t, upcasters.Version(), 3)
+	})
+
+	t.Run("upcasts payload to current version", func(t *testing.T) {
+		payload, err := upcasters.Upcast(1, json.RawMessage(`{"Message":"Hello, World!"}`))
+		testutil.AssertNoErr(t, err)
+
+		got, err := events.DecodePayload(payload, reflect.TypeOf(DummyEventV3{}))
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, interface{}(DummyEventV3{Text: "Hello, World!", Author: "unknown"}))
+	})
+
+	t.Run("treats missing version as initial version", func(t *testing.T) {
+		payload, err := upcasters.Upcast(0, json.RawMessage(`{"Message":"Hello, World!"}`))
+		testutil.AssertNoErr(t, err)
+
+		got, err := events.DecodePayload(payload, reflect.TypeOf(DummyEventV3{}))
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, interface{}(DummyEventV3{Text: "Hello, World!", Author: "unknown"}))
+	})
+
+	t.Run("doesn't change payload of current version", func(t *testing.T) {
+		payload := json.RawMessage(`{"Text":"Hello, World!","Author":"Vito"}`)
+
+		got, err := upcasters.Upcast(3, payload)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, string(got), string(payload))
+	})
+
+	t.Run("returns ErrUnknownSchemaVersion on newer version", func(t *testing.T) {
+		_, err := upcasters.Upcast(4, json.RawMessage(`{}`))
+
+		if !errors.Is(err, events.ErrUnknownSchemaVersion) {
+			t.Errorf("got error %v want %v", err, events.ErrUnknownSchemaVersion)
+		}
+	})
+
+	t.Run("returns ErrUpcasterNotRegistered on gap in upcasters", func(t *testing.T) {
+		upcasters := events.Upcasters{2: addAuthor}
+
+		_, err := upcasters.Upcast(1, json.RawMessage(`{}`))
+
+		if !errors.Is(err, events.ErrUpcasterNotRegistered) {
+			t.Errorf("got error %v want %v", err, events.ErrUpcasterNotRegistered)
+		}
+	})
+}
+
+func TestDecodePayload(t *testing.T) {
+	t.Run("decodes payload", func(t *testing.T) {
+		got, err := events.DecodePayload(json.RawMessage(`{"Message":"Hello, World!"}`), reflect.TypeOf(DummyEvent{}))
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, got, interface{}(DummyEvent{Message: "Hello, World!"}))
+	})
+
+	t.Run("returns error on unknown field", func(t *testing.T) {
+		_, err := events.DecodePayload(json.RawMessage(`{"Text":"Hello, World!"}`), reflect.TypeOf(DummyEvent{}))
+
+		if err == nil || !strings.Contains(err.Error(), "unknown field") {
+			t.Errorf("got error %v want unknown field error", err)
+		}
+	})
+
+	t.Run("returns error on mismatched type", func(t *testing.T) {
+		_, err := events.DecodePayload(json.RawMessage(`{"Message":10}`), reflect.TypeOf(DummyEvent{}))
+
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+
+	t.Run("returns error on missing payload", func(t *testing.T) {
+		_, err := events.DecodePayload(nil, reflect.TypeOf(DummyEvent{}))
+
+		if err == nil {
+			t.Errorf("did not get error but expected one")
+		}
+	})
+}
@@ -7,11 +7,25 @@ const COURIER_EVENTS_TOPIC = "courier-events-topic"
 const (
 	COURIER_CREATED_EVENT_ID events.EventID = iota
 	COURIER_DELETED_EVENT_ID
+	COURIER_UPDATED_EVENT_ID
+)
+
+var (
+	CourierCreated = events.NewEventType[CourierCreatedEvent](COURIER_EVENTS_TOPIC, COURIER_CREATED_EVENT_ID)
+	CourierDeleted = events.NewEventType[CourierDeletedEvent](COURIER_EVENTS_TOPIC, COURIER_DELETED_EVENT_ID)
+	CourierUpdated = events.NewEventType[CourierUpdatedEvent](COURIER_EVENTS_TOPIC, COURIER_UPDATED_EVENT_ID)
 )
 
 type CourierCreatedEvent struct {
 	ID   int
 	Name string
+	IBAN string
+}
+
+type CourierUpdatedEvent struct {
+	ID   int
+	Name string
+	IBAN string
 }
 
 type CourierDeletedEvent struct {
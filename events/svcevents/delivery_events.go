@@ -0,0 +1,43 @@
+package svcevents
+
+import "github.com/VitoNaychev/food-app/events"
+
+const DELIVERY_EVENTS_TOPIC = "delivery-events-topic"
+
+const (
+	DELIVERY_PICKED_UP_EVENT_ID events.EventID = iota
+	DELIVERY_COMPLETED_EVENT_ID
+	DELIVERY_CANCELED_EVENT_ID
+	COURIER_ASSIGNED_EVENT_ID
+	DELIVERY_HANDOVER_REJECTED_EVENT_ID
+)
+
+var (
+	DeliveryPickedUp         = events.NewEventType[DeliveryPickedUpEvent](DELIVERY_EVENTS_TOPIC, DELIVERY_PICKED_UP_EVENT_ID)
+	DeliveryCompleted        = events.NewEventType[DeliveryCompletedEvent](DELIVERY_EVENTS_TOPIC, DELIVERY_COMPLETED_EVENT_ID)
+	DeliveryCanceled         = events.NewEventType[DeliveryCanceledEvent](DELIVERY_EVENTS_TOPIC, DELIVERY_CANCELED_EVENT_ID)
+	CourierAssigned          = events.NewEventType[CourierAssignedEvent](DELIVERY_EVENTS_TOPIC, COURIER_ASSIGNED_EVENT_ID)
+	DeliveryHandoverRejected = events.NewEventType[DeliveryHandoverRejectedEvent](DELIVERY_EVENTS_TOPIC, DELIVERY_HANDOVER_REJECTED_EVENT_ID)
+)
+
+type DeliveryPickedUpEvent struct {
+	ID int
+}
+
+type DeliveryCompletedEvent struct {
+	ID        int
+	CourierID int
+}
+
+type DeliveryCanceledEvent struct {
+	ID int
+}
+
+type CourierAssignedEvent struct {
+	ID        int
+	CourierID int
+}
+
+type DeliveryHandoverRejectedEvent struct {
+	ID int
+}
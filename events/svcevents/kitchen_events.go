@@ -12,6 +12,20 @@ const (
 	TICKET_BEGIN_PREPARING_EVENT_ID events.EventID = iota
 	TICKET_FINISH_PREPARING_EVENT_ID
 	TICKET_CANCEL_EVENT_ID
+	TICKET_APPROVED_EVENT_ID
+	TICKET_REJECTED_EVENT_ID
+	TICKET_DECLINED_EVENT_ID
+	TICKET_CANCEL_REJECTED_EVENT_ID
+)
+
+var (
+	TicketBeginPreparing  = events.NewEventType[TicketBeginPreparingEvent](KITCHEN_EVENTS_TOPIC, TICKET_BEGIN_PREPARING_EVENT_ID)
+	TicketFinishPreparing = events.NewEventType[TicketFinishPreparingEvent](KITCHEN_EVENTS_TOPIC, TICKET_FINISH_PREPARING_EVENT_ID)
+	TicketCancel          = events.NewEventType[TicketCancelEvent](KITCHEN_EVENTS_TOPIC, TICKET_CANCEL_EVENT_ID)
+	TicketApproved        = events.NewEventType[TicketApprovedEvent](KITCHEN_EVENTS_TOPIC, TICKET_APPROVED_EVENT_ID)
+	TicketRejected        = events.NewEventType[TicketRejectedEvent](KITCHEN_EVENTS_TOPIC, TICKET_REJECTED_EVENT_ID)
+	TicketDeclined        = events.NewEventType[TicketDeclinedEvent](KITCHEN_EVENTS_TOPIC, TICKET_DECLINED_EVENT_ID)
+	TicketCancelRejected  = events.NewEventType[TicketCancelRejectedEvent](KITCHEN_EVENTS_TOPIC, TICKET_CANCEL_REJECTED_EVENT_ID)
 )
 
 type TicketBeginPreparingEvent struct {
@@ -26,3 +40,50 @@ type TicketFinishPreparingEvent struct {
 type TicketCancelEvent struct {
 	ID int
 }
+
+// TicketCancelRejectedEvent is published when an order is canceled after the
+// kitchen has begun preparing its ticket. Prepared tells whether the ticket
+// is already done.
+type TicketCancelRejectedEvent struct {
+	ID       int
+	Prepared bool
+}
+
+type TicketApprovedEvent struct {
+	ID int
+}
+
+type TicketRejectedEvent struct {
+	ID     int
+	Reason string
+}
+
+type TicketDeclineReason int
+
+const (
+	DECLINE_REASON_OTHER TicketDeclineReason = iota
+	DECLINE_REASON_ITEMS_UNAVAILABLE
+	DECLINE_REASON_TOO_BUSY
+	DECLINE_REASON_CLOSING
+)
+
+var declineReasonNames = map[TicketDeclineReason]string{
+	DECLINE_REASON_OTHER:             "other",
+	DECLINE_REASON_ITEMS_UNAVAILABLE: "items_unavailable",
+	DECLINE_REASON_TOO_BUSY:          "too_busy",
+	DECLINE_REASON_CLOSING:           "closing",
+}
+
+func (t TicketDeclineReason) String() string {
+	return declineReasonNames[t]
+}
+
+func (t TicketDeclineReason) IsValid() bool {
+	_, ok := declineReasonNames[t]
+	return ok
+}
+
+type TicketDeclinedEvent struct {
+	ID     int
+	Reason TicketDeclineReason
+}
@@ -6,10 +6,19 @@ const ORDER_EVENTS_TOPIC = "order-events-topic"
 
 const (
 	ORDER_CREATED_EVENT_ID events.EventID = iota
+	ORDER_CANCELED_EVENT_ID
+	REFUND_REQUESTED_EVENT_ID
+)
+
+var (
+	OrderCreated    = events.NewEventType[OrderCreatedEvent](ORDER_EVENTS_TOPIC, ORDER_CREATED_EVENT_ID)
+	OrderCanceled   = events.NewEventType[OrderCanceledEvent](ORDER_EVENTS_TOPIC, ORDER_CANCELED_EVENT_ID)
+	RefundRequested = events.NewEventType[RefundRequestedEvent](ORDER_EVENTS_TOPIC, REFUND_REQUESTED_EVENT_ID)
 )
 
 type OrderCreatedEvent struct {
 	ID              int
+	CustomerID      int
 	RestaurantID    int
 	Items           []OrderCreatedEventItem
 	Total           float32
@@ -32,3 +41,14 @@ type OrderCreatedEventAddress struct {
 	City         string
 	Country      string
 }
+
+type OrderCanceledEvent struct {
+	ID int
+}
+
+type RefundRequestedEvent struct {
+	ID         int
+	CustomerID int
+	Amount     float32
+	Reason     string
+}
@@ -0,0 +1,40 @@
+package svcevents
+
+import "github.com/VitoNaychev/food-app/events"
+
+const PAYMENT_EVENTS_TOPIC = "payment-events-topic"
+
+const (
+	PAYMENT_AUTHORIZED_EVENT_ID events.EventID = iota
+	PAYMENT_AUTHORIZATION_FAILED_EVENT_ID
+	PAYMENT_CAPTURED_EVENT_ID
+	PAYMENT_REFUNDED_EVENT_ID
+)
+
+var (
+	PaymentAuthorized          = events.NewEventType[PaymentAuthorizedEvent](PAYMENT_EVENTS_TOPIC, PAYMENT_AUTHORIZED_EVENT_ID)
+	PaymentAuthorizationFailed = events.NewEventType[PaymentAuthorizationFailedEvent](PAYMENT_EVENTS_TOPIC, PAYMENT_AUTHORIZATION_FAILED_EVENT_ID)
+	PaymentCaptured            = events.NewEventType[PaymentCapturedEvent](PAYMENT_EVENTS_TOPIC, PAYMENT_CAPTURED_EVENT_ID)
+	PaymentRefunded            = events.NewEventType[PaymentRefundedEvent](PAYMENT_EVENTS_TOPIC, PAYMENT_REFUNDED_EVENT_ID)
+)
+
+type PaymentAuthorizedEvent struct {
+	ID     int
+	Amount float32
+}
+
+type PaymentAuthorizationFailedEvent struct {
+	ID     int
+	Reason string
+}
+
+type PaymentCapturedEvent struct {
+	ID           int
+	RestaurantID int
+	Amount       float32
+}
+
+type PaymentRefundedEvent struct {
+	ID     int
+	Amount float32
+}
@@ -0,0 +1,17 @@
+package svcevents
+
+import "github.com/VitoNaychev/food-app/events"
+
+// The restaurant events are defined in the events package.
+var (
+	RestaurantCreated        = events.NewEventType[events.RestaurantCreatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_CREATED_EVENT_ID)
+	RestaurantDeleted        = events.NewEventType[events.RestaurantDeletedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_DELETED_EVENT_ID)
+	MenuItemCreated          = events.NewEventType[events.MenuItemCreatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.MENU_ITEM_CREATED_EVENT_ID)
+	MenuItemDeleted          = events.NewEventType[events.MenuItemDeletedEvent](events.RESTAURANT_EVENTS_TOPIC, events.MENU_ITEM_DELETED_EVENT_ID)
+	MenuItemUpdated          = events.NewEventType[events.MenuItemUpdatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.MENU_ITEM_UPDATED_EVENT_ID)
+	RestaurantAddressCreated = events.NewEventType[events.RestaurantAddressCreatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_ADDRESS_CREATED_EVENT_ID)
+	RestaurantAddressUpdated = events.NewEventType[events.RestaurantAddressUpdatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_ADDRESS_UPDATED_EVENT_ID)
+	RestaurantHoursSet       = events.NewEventType[events.RestaurantHoursSetEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_HOURS_SET_EVENT_ID)
+	RestaurantStatusUpdated  = events.NewEventType[events.RestaurantStatusUpdatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_STATUS_UPDATED_EVENT_ID)
+	RestaurantUpdated        = events.NewEventType[events.RestaurantUpdatedEvent](events.RESTAURANT_EVENTS_TOPIC, events.RESTAURANT_UPDATED_EVENT_ID)
+)
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/VitoNaychev/food-app/events"
+
+const (
+	TRACEPARENT_HEADER = "traceparent"
+	TRACESTATE_HEADER  = "tracestate"
+)
+
+// traceCarrier exposes the W3C trace context fields of EventHeaders to the
+// propagator.
+type traceCarrier struct {
+	headers *EventHeaders
+}
+
+func (t traceCarrier) Get(key string) string {
+	switch key {
+	case TRACEPARENT_HEADER:
+		return t.headers.TraceParent
+	case TRACESTATE_HEADER:
+		return t.headers.TraceState
+	default:
+		return ""
+	}
+}
+
+func (t traceCarrier) Set(key string, value string) {
+	switch key {
+	case TRACEPARENT_HEADER:
+		t.headers.TraceParent = value
+	case TRACESTATE_HEADER:
+		t.headers.TraceState = value
+	}
+}
+
+func (t traceCarrier) Keys() []string {
+	return []string{TRACEPARENT_HEADER, TRACESTATE_HEADER}
+}
+
+func (h EventHeaders) traceContext(ctx context.Context) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, traceCarrier{&h})
+}
+
+func (h *EventHeaders) setTraceContext(ctx context.Context) {
+	otel.GetTextMapPropagator().Inject(ctx, traceCarrier{h})
+}
+
+// WithTraceContext returns a copy of event that continues the trace of ctx
+// when it's published.
+func (e InterfaceEvent) WithTraceContext(ctx context.Context) InterfaceEvent {
+	e.Headers.setTraceContext(ctx)
+	return e
+}
+
+func startEventSpan(event InterfaceEvent, topic string, operation string, kind trace.SpanKind) (context.Context, trace.Span) {
+	ctx := event.Headers.traceContext(context.Background())
+
+	return otel.Tracer(tracerName).Start(ctx, topic+" "+operation,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			semconv.MessagingMessageID(event.ID),
+			attribute.Int("messaging.event_id", int(event.EventID)),
+		),
+	)
+}
+
+// Runs eventHandler in a span that continues the trace of the event. The
+// span is passed on to the handler through the event headers, so events
+// it publishes with CausedBy are part of the same trace.
+func runTracedEventHandler(eventHandler InterfaceEventHandler, event InterfaceEvent, topic string) error {
+	ctx, span := startEventSpan(event, topic, "process", trace.SpanKindConsumer)
+	defer span.End()
+
+	event.Headers.setTraceContext(ctx)
+
+	err := eventHandler(event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
@@ -0,0 +1,48 @@
+package events_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/testutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestEventTraceContext(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+	traceID := span.SpanContext().TraceID().String()
+
+	t.Run("stores trace context of ctx in headers", func(t *testing.T) {
+		event := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).WithTraceContext(ctx)
+
+		if !strings.Contains(event.Headers.TraceParent, traceID) {
+			t.Errorf("got traceparent %q want it to contain trace ID %q", event.Headers.TraceParent, traceID)
+		}
+	})
+
+	t.Run("continues trace of cause", func(t *testing.T) {
+		cause := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).WithTraceContext(ctx)
+
+		event := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).CausedBy(cause.Headers)
+
+		testutil.AssertEqual(t, event.Headers.TraceParent, cause.Headers.TraceParent)
+	})
+
+	t.Run("carries trace context in Kafka headers", func(t *testing.T) {
+		event := events.NewEvent(DUMMY_EVENT_ID, 1, DummyEvent{}).WithTraceContext(ctx)
+
+		kafkaHeaders := events.NewKafkaHeaders(event.Headers)
+		got := events.ParseKafkaHeaders(toConsumedHeaders(kafkaHeaders), events.RawPayloadEvent{})
+
+		testutil.AssertEqual(t, got.TraceParent, event.Headers.TraceParent)
+	})
+}
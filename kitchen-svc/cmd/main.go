@@ -8,15 +8,19 @@ import (
 	"strings"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/kitchen-svc/handlers"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func main() {
 	env := appenv.Enviornment{
 		SecretKey: []byte(os.Getenv("SECRET")),
+		JWKSURL:   os.Getenv("JWKS_URL"),
 
 		Dbhost: "kitchen-db",
 		Dbport: "5432",
@@ -27,6 +31,12 @@ func main() {
 		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "kitchen-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -50,26 +60,76 @@ func main() {
 		log.Fatalf("Ticket Item Store error: %v\n", err)
 	}
 
-	eventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
 	if err != nil {
 		log.Fatalf("Kafka Event Publisher error: %v\n", err)
 	}
+	kafkaEventPublisher.SetProducer("kitchen-svc")
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(context.Background())
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
 
 	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "kitchen-svc")
 	if err != nil {
 		log.Fatalf("Kafka Event Consumer error: %v\n", err)
 	}
 
+	inboxStore, err := events.NewPgInboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Inbox Store error: %v\n", err)
+	}
+	eventConsumer.SetInboxStore(inboxStore)
+	eventConsumer.SetDeadLetterPublisher(kafkaEventPublisher)
+
 	restaurantEventhandler := handlers.NewRestaurantEventHandler(restaurantStore, menuItemStore)
-	orderEventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore)
+	orderEventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, eventPublisher)
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+	err = handlers.RegisterRestaurantEventHandlers(eventConsumer, restaurantEventhandler)
+	if err != nil {
+		log.Fatalf("Restaurant Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterOrderEventHandlers(eventConsumer, orderEventHandler)
+	if err != nil {
+		log.Fatalf("Order Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterDeliveryEventHandlers(eventConsumer, deliveryEventHandler)
+	if err != nil {
+		log.Fatalf("Delivery Event Handlers error: %v\n", err)
+	}
 
-	handlers.RegisterRestaurantEventHandlers(eventConsumer, restaurantEventhandler)
-	handlers.RegisterOrderEventHandlers(eventConsumer, orderEventHandler)
 	go eventConsumer.Run(context.Background())
 	go events.LogEventConsumerErrors(context.Background(), eventConsumer)
 
-	kitchenServer := handlers.NewTicketServer(env.SecretKey, ticketStore, ticketItemStore, menuItemStore, restaurantStore, eventPublisher)
+	authConfig := auth.DefaultRemoteAuthConfig
+	authConfig.URL = os.Getenv("RESTAURANT_AUTH_URL")
+	if authConfig.URL == "" {
+		authConfig.URL = "http://restaurant-svc:8080/restaurant/auth/"
+	}
+	authClient := auth.NewRemoteAuthClient(authConfig)
+
+	keys := auth.NewKeySet(env.SecretKey, env.JWKSURL)
+	kitchenServer := handlers.NewTicketServer(keys, ticketStore, ticketItemStore, menuItemStore, restaurantStore, eventPublisher, authClient.VerifyJWT)
+
+	router := http.NewServeMux()
+	router.Handle("/tickets", kitchenServer)
 
 	log.Println("kitchen service listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", kitchenServer))
+	log.Fatal(http.ListenAndServe(":8080", tracing.Middleware(metrics.Middleware(router), "kitchen-svc")))
 }
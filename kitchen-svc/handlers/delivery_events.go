@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type DeliveryEventHandler struct {
+	ticketStore models.TicketStore
+}
+
+func NewDeliveryEventHandler(ticketStore models.TicketStore) *DeliveryEventHandler {
+	deliveryEventHandler := DeliveryEventHandler{
+		ticketStore: ticketStore,
+	}
+
+	return &deliveryEventHandler
+}
+
+func RegisterDeliveryEventHandlers(eventConsumer events.EventConsumer, deliveryEventHandler *DeliveryEventHandler) error {
+	err := svcevents.DeliveryPickedUp.Register(eventConsumer, deliveryEventHandler.HandleDeliveryPickedUpEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.DeliveryHandoverRejected.Register(eventConsumer, deliveryEventHandler.HandleDeliveryHandoverRejectedEvent)
+}
+
+// The courier picking up the order is the handover itself, so a ticket the
+// restaurant hasn't marked as complete yet is completed on its behalf.
+func (d *DeliveryEventHandler) HandleDeliveryPickedUpEvent(event events.Event[svcevents.DeliveryPickedUpEvent]) error {
+	return d.inTx(event.Context(), func(d *DeliveryEventHandler) error {
+		ticket, err := d.ticketStore.GetTicketByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		if ticket.State == models.COMPLETED {
+			return nil
+		}
+
+		ticketSM := models.NewTicketSM(ticket.State)
+		if ticket.State == models.READY_FOR_PICKUP {
+			err = ticketSM.Exec(models.COMPLETE_TICKET)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = ticketSM.Exec(models.COMPLETE_CONFIRMED)
+		if err != nil {
+			return err
+		}
+
+		return d.ticketStore.UpdateTicketState(ticket.ID, ticketSM.Current())
+	})
+}
+
+func (d *DeliveryEventHandler) HandleDeliveryHandoverRejectedEvent(event events.Event[svcevents.DeliveryHandoverRejectedEvent]) error {
+	return d.inTx(event.Context(), func(d *DeliveryEventHandler) error {
+		ticket, err := d.ticketStore.GetTicketByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		if ticket.State == models.READY_FOR_PICKUP {
+			return nil
+		}
+
+		ticketSM := models.NewTicketSM(ticket.State)
+		err = ticketSM.Exec(models.COMPLETE_REJECTED)
+		if err != nil {
+			return err
+		}
+
+		return d.ticketStore.UpdateTicketState(ticket.ID, ticketSM.Current())
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (d *DeliveryEventHandler) inTx(ctx context.Context, fn func(d *DeliveryEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, d.ticketStore, func(tx pgx.Tx) error {
+		txHandler := *d
+		txHandler.ticketStore = pgconfig.WithTx(d.ticketStore, tx)
+
+		return fn(&txHandler)
+	})
+}
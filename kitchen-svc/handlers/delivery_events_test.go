@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/kitchen-svc/handlers"
+	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/kitchen-svc/stubs"
+	"github.com/VitoNaychev/food-app/kitchen-svc/testdata"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestDeliveryEventHandler(t *testing.T) {
+	completePendingTicket := testdata.ReadyForPickupShackTicket
+	completePendingTicket.State = models.COMPLETE_PENDING
+
+	t.Run("confirms completion of pending ticket on DELIVERY_PICKED_UP event", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: completePendingTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: completePendingTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryPickedUpEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.COMPLETED)
+	})
+
+	t.Run("completes ticket ready for pickup on DELIVERY_PICKED_UP event", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.ReadyForPickupShackTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: testdata.ReadyForPickupShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryPickedUpEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.COMPLETED)
+	})
+
+	t.Run("ignores redelivered DELIVERY_PICKED_UP event for completed ticket", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.CompletedShackTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: testdata.CompletedShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryPickedUpEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.COMPLETED)
+	})
+
+	t.Run("returns error on DELIVERY_PICKED_UP event for ticket in progress", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.InProgressShackTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: testdata.InProgressShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryPickedUpEvent(event)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.IN_PROGRESS)
+	})
+
+	t.Run("returns pending ticket to READY_FOR_PICKUP on DELIVERY_HANDOVER_REJECTED event", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: completePendingTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryHandoverRejectedEvent{ID: completePendingTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_HANDOVER_REJECTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryHandoverRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.READY_FOR_PICKUP)
+	})
+
+	t.Run("ignores DELIVERY_HANDOVER_REJECTED event for ticket ready for pickup", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.ReadyForPickupShackTicket}
+		eventHandler := handlers.NewDeliveryEventHandler(ticketStore)
+
+		payload := svcevents.DeliveryHandoverRejectedEvent{ID: testdata.ReadyForPickupShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_HANDOVER_REJECTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryHandoverRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.READY_FOR_PICKUP)
+	})
+}
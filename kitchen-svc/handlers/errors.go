@@ -5,6 +5,12 @@ import "errors"
 var (
 	ErrUnathorizedAction         = errors.New("restaurant doesn't have permission to perform this action")
 	ErrUnsuportedStateTransition = errors.New("current ticket state doesn't support this transition")
+	ErrForbiddenTicketEvent      = errors.New("restaurant can't trigger this ticket event")
 	ErrInvalidTimeFormat         = errors.New("time string has invalid format")
 	ErrInvalidTime               = errors.New("time string must represent a moment in the future")
+	ErrRestaurantNotFound        = errors.New("restaurant doesn't exist")
+	ErrMenuItemNotFound          = errors.New("menu item doesn't exist")
+	ErrMenuItemNotInRestaurant   = errors.New("menu item doesn't belong to restaurant")
+	ErrInvalidDeclineReason      = errors.New("decline reason is not supported")
+	ErrTicketRejected            = errors.New("ticket was rejected")
 )
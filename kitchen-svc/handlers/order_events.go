@@ -1,43 +1,196 @@
 package handlers
 
 import (
-	"reflect"
+	"context"
+	"errors"
 
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
 )
 
 type OrderEventHandler struct {
 	ticketStore     models.TicketStore
 	ticketItemStore models.TicketItemStore
+	menuItemStore   models.MenuItemStore
+	restaurantStore models.RestaurantStore
+
+	publisher events.EventPublisher
 }
 
-func NewOrderEventHandler(ticketStore models.TicketStore, ticketItemStore models.TicketItemStore) *OrderEventHandler {
+func NewOrderEventHandler(ticketStore models.TicketStore, ticketItemStore models.TicketItemStore,
+	menuItemStore models.MenuItemStore, restaurantStore models.RestaurantStore, publisher events.EventPublisher) *OrderEventHandler {
 	orderEventHandler := OrderEventHandler{
 		ticketStore:     ticketStore,
 		ticketItemStore: ticketItemStore,
+		menuItemStore:   menuItemStore,
+		restaurantStore: restaurantStore,
+
+		publisher: publisher,
 	}
 
 	return &orderEventHandler
 }
 
-func RegisterOrderEventHandlers(eventConsumer events.EventConsumer, orderEventHandler *OrderEventHandler) {
-	eventConsumer.RegisterEventHandler(svcevents.ORDER_EVENTS_TOPIC,
-		svcevents.ORDER_CREATED_EVENT_ID,
-		events.EventHandlerWrapper(orderEventHandler.HandleOrderCreatedEvent),
-		reflect.TypeOf(svcevents.OrderCreatedEvent{}))
+func RegisterOrderEventHandlers(eventConsumer events.EventConsumer, orderEventHandler *OrderEventHandler) error {
+	err := svcevents.OrderCreated.Register(eventConsumer, orderEventHandler.HandleOrderCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.OrderCanceled.Register(eventConsumer, orderEventHandler.HandleOrderCanceledEvent)
 }
 
 func (o *OrderEventHandler) HandleOrderCreatedEvent(event events.Event[svcevents.OrderCreatedEvent]) error {
-	ticket := TicketFromOrderCreatedEvent(event.Payload)
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		ticket, err := o.ticketStore.GetTicketByID(event.Payload.ID)
+		if errors.Is(err, storeerrors.ErrNotFound) {
+			ticket, err = o.createTicket(event.Payload, event.Headers.CorrelationID)
+		} else if err == nil && ticket.State == models.CREATE_PENDING {
+			err = o.createMissingTicketItems(event.Payload)
+		}
+		if err != nil {
+			return err
+		}
+
+		// A ticket canceled before it was decided has no outcome to publish.
+		if ticket.State == models.CANCELED {
+			return nil
+		}
+
+		var verificationErr error
+		if ticket.State == models.CREATE_PENDING || ticket.State == models.REJECTED {
+			ticketItems := TicketItemsFromOrderCreatedEvent(event.Payload)
+			verificationErr = o.verifyTicket(ticket, ticketItems)
+			if verificationErr != nil && !isTicketRejection(verificationErr) {
+				return verificationErr
+			}
+		}
+
+		if ticket.State == models.CREATE_PENDING {
+			ticketSM := models.NewTicketSM(ticket.State)
+			if verificationErr == nil {
+				err = ticketSM.Exec(models.APPROVE_TICKET)
+			} else {
+				err = ticketSM.Exec(models.REJECT_TICKET)
+			}
+			if err != nil {
+				return err
+			}
+
+			err = o.ticketStore.UpdateTicketState(ticket.ID, ticketSM.Current())
+			if err != nil {
+				return err
+			}
+			ticket.State = ticketSM.Current()
+		}
+
+		// A redelivered event finds the ticket already decided, but the outcome
+		// is published again in case it failed the first time around.
+		var outEvent events.InterfaceEvent
+		if ticket.State != models.REJECTED {
+			payload := svcevents.TicketApprovedEvent{ID: ticket.ID}
+			outEvent = svcevents.TicketApproved.New(ticket.ID, payload).CausedBy(event.Headers)
+		} else {
+			if verificationErr == nil {
+				verificationErr = ErrTicketRejected
+			}
+			payload := svcevents.TicketRejectedEvent{ID: ticket.ID, Reason: verificationErr.Error()}
+			outEvent = svcevents.TicketRejected.New(ticket.ID, payload).CausedBy(event.Headers)
+		}
+
+		return o.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, outEvent)
+	})
+}
+
+func (o *OrderEventHandler) HandleOrderCanceledEvent(event events.Event[svcevents.OrderCanceledEvent]) error {
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		ticket, err := o.ticketStore.GetTicketByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		// A redelivered event finds the ticket already canceled, but the
+		// cancelation is published again in case it failed the first time around.
+		if ticket.State != models.CANCELED {
+			ticketSM := models.NewTicketSM(ticket.State)
+			err = ticketSM.Exec(models.CANCEL_TICKET)
+			if errors.Is(err, sm.ErrInvalidEvent) {
+				return o.rejectCancel(ticket, event.Headers)
+			} else if err != nil {
+				return err
+			}
+
+			err = o.ticketStore.UpdateTicketState(ticket.ID, ticketSM.Current())
+			if err != nil {
+				return err
+			}
+		}
+
+		payload := svcevents.TicketCancelEvent{ID: ticket.ID}
+		outEvent := svcevents.TicketCancel.New(ticket.ID, payload).CausedBy(event.Headers)
+
+		return o.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, outEvent)
+	})
+}
+
+// A ticket that was rejected or declined has already ended its order, while
+// one the kitchen has begun preparing can't be canceled anymore, so the order
+// is told to resume.
+func (o *OrderEventHandler) rejectCancel(ticket models.Ticket, cause events.EventHeaders) error {
+	if ticket.State == models.REJECTED || ticket.State == models.DECLINED {
+		return nil
+	}
+
+	payload := svcevents.TicketCancelRejectedEvent{
+		ID:       ticket.ID,
+		Prepared: ticket.State != models.IN_PROGRESS,
+	}
+	outEvent := svcevents.TicketCancelRejected.New(ticket.ID, payload).CausedBy(cause)
+
+	return o.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, outEvent)
+}
+
+func (o *OrderEventHandler) createTicket(orderCreatedEvent svcevents.OrderCreatedEvent, correlationID string) (models.Ticket, error) {
+	ticket := TicketFromOrderCreatedEvent(orderCreatedEvent)
+	ticket.CorrelationID = correlationID
 	err := o.ticketStore.CreateTicket(&ticket)
+	if err != nil {
+		return models.Ticket{}, err
+	}
+
+	err = o.createMissingTicketItems(orderCreatedEvent)
+	if err != nil {
+		return models.Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+// createMissingTicketItems creates the ticket items that aren't stored yet,
+// so that a redelivered event completes a ticket whose items were only
+// partially created.
+func (o *OrderEventHandler) createMissingTicketItems(orderCreatedEvent svcevents.OrderCreatedEvent) error {
+	storedTicketItems, err := o.ticketItemStore.GetTicketItemsByTicketID(orderCreatedEvent.ID)
 	if err != nil {
 		return err
 	}
 
-	ticketItems := TicketItemsFromOrderCreatedEvent(event.Payload)
+	stored := map[int]bool{}
+	for _, ticketItem := range storedTicketItems {
+		stored[ticketItem.ID] = true
+	}
+
+	ticketItems := TicketItemsFromOrderCreatedEvent(orderCreatedEvent)
 	for _, ticketItem := range ticketItems {
+		if stored[ticketItem.ID] {
+			continue
+		}
+
 		err := o.ticketItemStore.CreateTicketItem(&ticketItem)
 		if err != nil {
 			return err
@@ -47,10 +200,40 @@ func (o *OrderEventHandler) HandleOrderCreatedEvent(event events.Event[svcevents
 	return nil
 }
 
+func (o *OrderEventHandler) verifyTicket(ticket models.Ticket, ticketItems []models.TicketItem) error {
+	_, err := o.restaurantStore.GetRestaurantByID(ticket.RestaurantID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		return ErrRestaurantNotFound
+	} else if err != nil {
+		return err
+	}
+
+	for _, ticketItem := range ticketItems {
+		menuItem, err := o.menuItemStore.GetMenuItemByID(ticketItem.MenuItemID)
+		if errors.Is(err, storeerrors.ErrNotFound) {
+			return ErrMenuItemNotFound
+		} else if err != nil {
+			return err
+		}
+
+		if menuItem.RestaurantID != ticket.RestaurantID {
+			return ErrMenuItemNotInRestaurant
+		}
+	}
+
+	return nil
+}
+
+func isTicketRejection(err error) bool {
+	return errors.Is(err, ErrRestaurantNotFound) ||
+		errors.Is(err, ErrMenuItemNotFound) ||
+		errors.Is(err, ErrMenuItemNotInRestaurant)
+}
+
 func TicketFromOrderCreatedEvent(orderCreatedEvent svcevents.OrderCreatedEvent) models.Ticket {
 	ticket := models.Ticket{
 		ID:           orderCreatedEvent.ID,
-		State:        models.OPEN,
+		State:        models.CREATE_PENDING,
 		RestaurantID: orderCreatedEvent.RestaurantID,
 		Total:        orderCreatedEvent.Total,
 		ReadyBy:      models.ZeroTime,
@@ -74,3 +257,17 @@ func TicketItemsFromOrderCreatedEvent(orderCreatedEvent svcevents.OrderCreatedEv
 
 	return ticketItems
 }
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (o *OrderEventHandler) inTx(ctx context.Context, fn func(o *OrderEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, o.ticketStore, func(tx pgx.Tx) error {
+		txHandler := *o
+		txHandler.ticketStore = pgconfig.WithTx(o.ticketStore, tx)
+		txHandler.ticketItemStore = pgconfig.WithTx(o.ticketItemStore, tx)
+		txHandler.publisher = pgconfig.WithTx(o.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
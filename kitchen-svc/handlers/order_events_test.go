@@ -14,12 +14,15 @@ import (
 )
 
 func TestOrderCreatedEventHandler(t *testing.T) {
-	ticketStore := &stubs.StubTicketStore{}
-	ticketItemStore := &stubs.StubTicketItemStore{}
+	menuItemStore := &stubs.StubMenuItemStore{MenuItems: []models.MenuItem{testdata.ShackMenuItem}}
+	restaurantStore := &stubs.StubRestaurantStore{Restaurants: []models.Restaurant{testdata.ShackRestaurant}}
 
-	eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore)
+	t.Run("creates corresponding ticket and approves it", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
 
-	t.Run("creates corresponding delivery", func(t *testing.T) {
 		wantTicket := testdata.OpenShackTicket
 		wantTicketItems := []models.TicketItem{testdata.OpenShackTicketItems}
 
@@ -31,5 +34,312 @@ func TestOrderCreatedEventHandler(t *testing.T) {
 		testutil.AssertNoErr(t, err)
 		testutil.AssertEqual(t, ticketStore.SpyTicket, wantTicket)
 		testutil.AssertEqual(t, ticketItemStore.SpyTicketItems, wantTicketItems)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_APPROVED_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload:     svcevents.TicketApprovedEvent{ID: testdata.OpenShackTicket.ID},
+		}
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.KITCHEN_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("correlates published event with order event", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+		event.Headers = events.EventHeaders{EventID: event.ID, CorrelationID: "correlation-id"}
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, publisher.SpyEvent.Headers.CorrelationID, "correlation-id")
+		testutil.AssertEqual(t, publisher.SpyEvent.Headers.CausationID, event.ID)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.CorrelationID, "correlation-id")
+	})
+
+	t.Run("rejects ticket for a restaurant that doesn't exist", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, &stubs.StubRestaurantStore{}, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.REJECTED)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_REJECTED_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload: svcevents.TicketRejectedEvent{
+				ID:     testdata.OpenShackTicket.ID,
+				Reason: handlers.ErrRestaurantNotFound.Error(),
+			},
+		}
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("rejects ticket with a menu item that doesn't exist", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, &stubs.StubMenuItemStore{}, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.REJECTED)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_REJECTED_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload: svcevents.TicketRejectedEvent{
+				ID:     testdata.OpenShackTicket.ID,
+				Reason: handlers.ErrMenuItemNotFound.Error(),
+			},
+		}
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("rejects ticket with a menu item from another restaurant", func(t *testing.T) {
+		foreignMenuItem := testdata.ShackMenuItem
+		foreignMenuItem.RestaurantID = 2
+
+		ticketStore := &stubs.StubTicketStore{}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore,
+			&stubs.StubMenuItemStore{MenuItems: []models.MenuItem{foreignMenuItem}}, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.REJECTED)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_REJECTED_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload: svcevents.TicketRejectedEvent{
+				ID:     testdata.OpenShackTicket.ID,
+				Reason: handlers.ErrMenuItemNotInRestaurant.Error(),
+			},
+		}
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("finishes approval of redelivered event for pending ticket", func(t *testing.T) {
+		pendingTicket := testdata.OpenShackTicket
+		pendingTicket.State = models.CREATE_PENDING
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: pendingTicket}
+		ticketItemStore := &stubs.StubTicketItemStore{TicketItems: []models.TicketItem{testdata.OpenShackTicketItems}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(ticketItemStore.SpyTicketItems), 0)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.OPEN)
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.TICKET_APPROVED_EVENT_ID)
+	})
+
+	t.Run("creates missing items of redelivered event for pending ticket", func(t *testing.T) {
+		pendingTicket := testdata.OpenShackTicket
+		pendingTicket.State = models.CREATE_PENDING
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: pendingTicket}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketItemStore.SpyTicketItems, []models.TicketItem{testdata.OpenShackTicketItems})
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.OPEN)
+	})
+
+	t.Run("republishes TICKET_APPROVED on redelivered event for approved ticket", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.OpenShackTicket}
+		ticketItemStore := &stubs.StubTicketItemStore{}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(ticketItemStore.SpyTicketItems), 0)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.OPEN)
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.TICKET_APPROVED_EVENT_ID)
+	})
+
+	t.Run("republishes TICKET_REJECTED on redelivered event for rejected ticket", func(t *testing.T) {
+		rejectedTicket := testdata.OpenShackTicket
+		rejectedTicket.State = models.REJECTED
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: rejectedTicket}
+		publisher := &stubs.StubEventPublisher{}
+		restaurantStore := &stubs.StubRestaurantStore{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_REJECTED_EVENT_ID,
+			AggregateID: rejectedTicket.ID,
+			Payload:     svcevents.TicketRejectedEvent{ID: rejectedTicket.ID, Reason: handlers.ErrRestaurantNotFound.Error()},
+		}
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("ignores redelivered event for ticket canceled before approval", func(t *testing.T) {
+		canceledTicket := testdata.OpenShackTicket
+		canceledTicket.State = models.CANCELED
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: canceledTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, testdata.PeterOrderCreatedEvent.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, publisher.SpyTopic, "")
+	})
+}
+
+func TestOrderCanceledEventHandler(t *testing.T) {
+	menuItemStore := &stubs.StubMenuItemStore{MenuItems: []models.MenuItem{testdata.ShackMenuItem}}
+	restaurantStore := &stubs.StubRestaurantStore{Restaurants: []models.Restaurant{testdata.ShackRestaurant}}
+
+	t.Run("cancels open ticket and publishes TICKET_CANCEL event", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.OpenShackTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: testdata.OpenShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.CANCELED)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_CANCEL_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload:     svcevents.TicketCancelEvent{ID: testdata.OpenShackTicket.ID},
+		}
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.KITCHEN_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("republishes TICKET_CANCEL on redelivered event for canceled ticket", func(t *testing.T) {
+		canceledTicket := testdata.OpenShackTicket
+		canceledTicket.State = models.CANCELED
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: canceledTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: canceledTicket.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.CANCELED)
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.TICKET_CANCEL_EVENT_ID)
+	})
+
+	t.Run("publishes TICKET_CANCEL_REJECTED on ticket that is already being prepared", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.InProgressShackTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: testdata.InProgressShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.IN_PROGRESS)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_CANCEL_REJECTED_EVENT_ID,
+			AggregateID: testdata.InProgressShackTicket.ID,
+			Payload:     svcevents.TicketCancelRejectedEvent{ID: testdata.InProgressShackTicket.ID, Prepared: false},
+		}
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.KITCHEN_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("publishes TICKET_CANCEL_REJECTED on ticket that is ready for pickup", func(t *testing.T) {
+		ticketStore := &stubs.StubTicketStore{SpyTicket: testdata.ReadyForPickupShackTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: testdata.ReadyForPickupShackTicket.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_CANCEL_REJECTED_EVENT_ID,
+			AggregateID: testdata.ReadyForPickupShackTicket.ID,
+			Payload:     svcevents.TicketCancelRejectedEvent{ID: testdata.ReadyForPickupShackTicket.ID, Prepared: true},
+		}
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("ignores event for declined ticket", func(t *testing.T) {
+		declinedTicket := testdata.OpenShackTicket
+		declinedTicket.State = models.DECLINED
+
+		ticketStore := &stubs.StubTicketStore{SpyTicket: declinedTicket}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(ticketStore, &stubs.StubTicketItemStore{}, menuItemStore, restaurantStore, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: declinedTicket.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.DECLINED)
+		testutil.AssertEqual(t, publisher.SpyEvent, events.InterfaceEvent{})
 	})
 }
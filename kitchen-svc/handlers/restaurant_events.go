@@ -1,10 +1,13 @@
 package handlers
 
 import (
-	"reflect"
+	"context"
 
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
 )
 
 type RestaurantEventHandler struct {
@@ -21,68 +24,91 @@ func NewRestaurantEventHandler(restaurantStore models.RestaurantStore, menuItemS
 	return &endpoint
 }
 
-func RegisterRestaurantEventHandlers(eventConsumer events.EventConsumer, restaurantEventHandler *RestaurantEventHandler) {
-	eventConsumer.RegisterEventHandler(events.RESTAURANT_EVENTS_TOPIC,
-		events.RESTAURANT_CREATED_EVENT_ID,
-		events.EventHandlerWrapper(restaurantEventHandler.HandleRestaurantCreatedEvent),
-		reflect.TypeOf(events.RestaurantCreatedEvent{}))
-	eventConsumer.RegisterEventHandler(events.RESTAURANT_EVENTS_TOPIC,
-		events.RESTAURANT_DELETED_EVENT_ID,
-		events.EventHandlerWrapper(restaurantEventHandler.HandleRestaurantDeletedEvent),
-		reflect.TypeOf(events.RestaurantDeletedEvent{}))
-	eventConsumer.RegisterEventHandler(events.RESTAURANT_EVENTS_TOPIC,
-		events.MENU_ITEM_CREATED_EVENT_ID,
-		events.EventHandlerWrapper(restaurantEventHandler.HandleMenuItemCreatedEvent),
-		reflect.TypeOf(events.MenuItemCreatedEvent{}))
-	eventConsumer.RegisterEventHandler(events.RESTAURANT_EVENTS_TOPIC,
-		events.MENU_ITEM_UPDATED_EVENT_ID,
-		events.EventHandlerWrapper(restaurantEventHandler.HandleMenuItemUpdatedEvent),
-		reflect.TypeOf(events.MenuItemUpdatedEvent{}))
-	eventConsumer.RegisterEventHandler(events.RESTAURANT_EVENTS_TOPIC,
-		events.MENU_ITEM_DELETED_EVENT_ID,
-		events.EventHandlerWrapper(restaurantEventHandler.HandleMenuItemDeletedEvent),
-		reflect.TypeOf(events.MenuItemDeletedEvent{}))
+func RegisterRestaurantEventHandlers(eventConsumer events.EventConsumer, restaurantEventHandler *RestaurantEventHandler) error {
+	err := svcevents.RestaurantCreated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantDeleted.Register(eventConsumer, restaurantEventHandler.HandleRestaurantDeletedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.MenuItemCreated.Register(eventConsumer, restaurantEventHandler.HandleMenuItemCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.MenuItemUpdated.Register(eventConsumer, restaurantEventHandler.HandleMenuItemUpdatedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.MenuItemDeleted.Register(eventConsumer, restaurantEventHandler.HandleMenuItemDeletedEvent)
 }
 
 func (r *RestaurantEventHandler) HandleRestaurantCreatedEvent(event events.Event[events.RestaurantCreatedEvent]) error {
-	restaurant := models.Restaurant{ID: event.Payload.ID}
-	err := r.restaurantStore.CreateRestaurant(&restaurant)
-	return err
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		restaurant := models.Restaurant{ID: event.Payload.ID}
+		err := r.restaurantStore.CreateRestaurant(&restaurant)
+		return err
+	})
 }
 
 func (r *RestaurantEventHandler) HandleRestaurantDeletedEvent(event events.Event[events.RestaurantDeletedEvent]) error {
-	err := r.restaurantStore.DeleteRestaurant(event.Payload.ID)
-	if err != nil {
-		return err
-	}
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		err := r.restaurantStore.DeleteRestaurant(event.Payload.ID)
+		if err != nil {
+			return err
+		}
 
-	err = r.menuItemStore.DeleteMenuItemWhereRestaurantID(event.Payload.ID)
-	return err
+		err = r.menuItemStore.DeleteMenuItemWhereRestaurantID(event.Payload.ID)
+		return err
+	})
 }
 
 func (r *RestaurantEventHandler) HandleMenuItemCreatedEvent(event events.Event[events.MenuItemCreatedEvent]) error {
-	menuItem := models.MenuItem{
-		ID:           event.Payload.ID,
-		RestaurantID: event.Payload.RestaurantID,
-		Name:         event.Payload.Name,
-		Price:        event.Payload.Price,
-	}
-	err := r.menuItemStore.CreateMenuItem(&menuItem)
-	return err
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		menuItem := models.MenuItem{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Name:         event.Payload.Name,
+			Price:        event.Payload.Price,
+		}
+		err := r.menuItemStore.CreateMenuItem(&menuItem)
+		return err
+	})
 }
 
 func (r *RestaurantEventHandler) HandleMenuItemDeletedEvent(event events.Event[events.MenuItemDeletedEvent]) error {
-	err := r.menuItemStore.DeleteMenuItem(event.Payload.ID)
-	return err
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		err := r.menuItemStore.DeleteMenuItem(event.Payload.ID)
+		return err
+	})
 }
 
 func (r *RestaurantEventHandler) HandleMenuItemUpdatedEvent(event events.Event[events.MenuItemUpdatedEvent]) error {
-	menuItem := models.MenuItem{
-		ID:           event.Payload.ID,
-		RestaurantID: event.Payload.RestaurantID,
-		Name:         event.Payload.Name,
-		Price:        event.Payload.Price,
-	}
-	err := r.menuItemStore.UpdateMenuItem(&menuItem)
-	return err
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		menuItem := models.MenuItem{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Name:         event.Payload.Name,
+			Price:        event.Payload.Price,
+		}
+		err := r.menuItemStore.UpdateMenuItem(&menuItem)
+		return err
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (r *RestaurantEventHandler) inTx(ctx context.Context, fn func(r *RestaurantEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, r.restaurantStore, func(tx pgx.Tx) error {
+		txHandler := *r
+		txHandler.restaurantStore = pgconfig.WithTx(r.restaurantStore, tx)
+		txHandler.menuItemStore = pgconfig.WithTx(r.menuItemStore, tx)
+
+		return fn(&txHandler)
+	})
 }
@@ -3,13 +3,24 @@ package handlers
 import (
 	"time"
 
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
 )
 
 type StateTransitionTicketRequest struct {
-	ID      int                `validate:"required,min=1"    json:"id"`
-	Event   models.TicketEvent `validate:"required,min=0"    json:"event"`
-	ReadyBy string             `                             json:"ready_by"`
+	ID            int                           `validate:"required,min=1"    json:"id"`
+	Event         models.TicketEvent            `validate:"required,min=0"    json:"event"`
+	ReadyBy       string                        `                             json:"ready_by"`
+	DeclineReason svcevents.TicketDeclineReason `                             json:"decline_reason"`
+}
+
+// Tickets are approved, rejected and canceled by the order saga and completed
+// by the courier picking up the order, so a restaurant may only trigger the
+// remaining events.
+var restaurantTicketEvents = map[models.TicketEvent]bool{
+	models.BEGIN_PREPARING:  true,
+	models.FINISH_PREPARING: true,
+	models.DECLINE_TICKET:   true,
 }
 
 type StateTransitionResponse struct {
@@ -3,16 +3,19 @@ package handlers
 import (
 	"errors"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type RestaurantVerifier struct {
-	store models.RestaurantStore
+	store     models.RestaurantStore
+	verifyJWT auth.VerifyJWTFunc
 }
 
-func NewRestaurantVerifier(store models.RestaurantStore) *RestaurantVerifier {
-	return &RestaurantVerifier{store}
+func NewRestaurantVerifier(store models.RestaurantStore, verifyJWT auth.VerifyJWTFunc) *RestaurantVerifier {
+	return &RestaurantVerifier{store, verifyJWT}
 }
 
 func (c *RestaurantVerifier) DoesSubjectExist(id int) (bool, error) {
@@ -24,3 +27,9 @@ func (c *RestaurantVerifier) DoesSubjectExist(id int) (bool, error) {
 	}
 	return true, nil
 }
+
+// Tokens are issued and revoked by restaurant-svc, so it's asked whether
+// the token was revoked.
+func (c *RestaurantVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsRemoteJWTRevoked(c.verifyJWT, token)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -13,11 +14,13 @@ import (
 	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/validation"
+	"github.com/jackc/pgx/v5"
 )
 
 type TicketServer struct {
-	secretKey []byte
+	keys auth.KeySet
 
 	ticketStore     models.TicketStore
 	ticketItemStore models.TicketItemStore
@@ -29,15 +32,16 @@ type TicketServer struct {
 	verifier auth.Verifier
 }
 
-func NewTicketServer(secretKey []byte,
+func NewTicketServer(keys auth.KeySet,
 	ticketStore models.TicketStore,
 	ticketItemStore models.TicketItemStore,
 	menuItemStore models.MenuItemStore,
 	restaurantStore models.RestaurantStore,
-	publisher events.EventPublisher) *TicketServer {
+	publisher events.EventPublisher,
+	verifyJWT auth.VerifyJWTFunc) *TicketServer {
 
 	s := TicketServer{
-		secretKey: secretKey,
+		keys: keys,
 
 		ticketStore:     ticketStore,
 		ticketItemStore: ticketItemStore,
@@ -46,7 +50,7 @@ func NewTicketServer(secretKey []byte,
 
 		publisher: publisher,
 
-		verifier: NewRestaurantVerifier(restaurantStore),
+		verifier: NewRestaurantVerifier(restaurantStore, verifyJWT),
 	}
 
 	return &s
@@ -55,9 +59,9 @@ func NewTicketServer(secretKey []byte,
 func (t *TicketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		auth.AuthenticationMW(t.getFilteredTickets, t.verifier, t.secretKey)(w, r)
+		auth.AuthenticationMW(t.getFilteredTickets, t.verifier, t.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPost:
-		auth.AuthenticationMW(t.stateTransitionHandler, t.verifier, t.secretKey)(w, r)
+		auth.AuthenticationMW(t.stateTransitionHandler, t.verifier, t.keys, auth.RESTAURANT)(w, r)
 	}
 }
 
@@ -68,6 +72,11 @@ func (t *TicketServer) stateTransitionHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if !restaurantTicketEvents[ticketRequest.Event] {
+		httperrors.HandleBadRequest(w, ErrForbiddenTicketEvent)
+		return
+	}
+
 	restaurantID, _ := strconv.Atoi(r.Header.Get("Subject"))
 
 	ticket, err := t.ticketStore.GetTicketByID(ticketRequest.ID)
@@ -100,7 +109,19 @@ func (t *TicketServer) stateTransitionHandler(w http.ResponseWriter, r *http.Req
 		ticket.ReadyBy = readyBy
 	}
 
-	err = t.ticketStore.UpdateTicket(&ticket)
+	if ticketRequest.Event == models.DECLINE_TICKET && !ticketRequest.DeclineReason.IsValid() {
+		httperrors.HandleBadRequest(w, ErrInvalidDeclineReason)
+		return
+	}
+
+	err = t.inTx(r.Context(), func(t *TicketServer) error {
+		err := t.ticketStore.UpdateTicket(&ticket)
+		if err != nil {
+			return err
+		}
+
+		return t.sendTicketStateTransitionEvent(r.Context(), ticketRequest, ticket)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 		return
@@ -110,21 +131,30 @@ func (t *TicketServer) stateTransitionHandler(w http.ResponseWriter, r *http.Req
 	stateTransitionResponse := NewStateTransitionResponse(ticket)
 
 	json.NewEncoder(w).Encode(stateTransitionResponse)
+}
 
-	err = t.sendTicketStateTransitionEvent(ticketRequest.Event, ticket)
-	if err != nil {
-		httperrors.HandleInternalServerError(w, err)
-	}
+// inTx runs fn with a copy of the server whose stores and publisher write
+// through a single transaction, so a ticket and its events are committed
+// together.
+func (t *TicketServer) inTx(ctx context.Context, fn func(t *TicketServer) error) error {
+	return pgconfig.RunInTx(ctx, t.ticketStore, func(tx pgx.Tx) error {
+		txServer := *t
+		txServer.ticketStore = pgconfig.WithTx(t.ticketStore, tx)
+		txServer.ticketItemStore = pgconfig.WithTx(t.ticketItemStore, tx)
+		txServer.publisher = pgconfig.WithTx(t.publisher, tx)
+
+		return fn(&txServer)
+	})
 }
 
-func (t *TicketServer) sendTicketStateTransitionEvent(event models.TicketEvent, ticket models.Ticket) error {
-	switch event {
+func (t *TicketServer) sendTicketStateTransitionEvent(ctx context.Context, ticketRequest StateTransitionTicketRequest, ticket models.Ticket) error {
+	switch ticketRequest.Event {
 	case models.BEGIN_PREPARING:
 		payload := svcevents.TicketBeginPreparingEvent{
 			ID:      ticket.ID,
 			ReadyBy: ticket.ReadyBy,
 		}
-		event := events.NewEvent(svcevents.TICKET_BEGIN_PREPARING_EVENT_ID, ticket.ID, payload)
+		event := svcevents.TicketBeginPreparing.New(ticket.ID, payload).WithTraceContext(ctx).WithCorrelationID(ticket.CorrelationID)
 
 		err := t.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, event)
 		return err
@@ -132,13 +162,23 @@ func (t *TicketServer) sendTicketStateTransitionEvent(event models.TicketEvent,
 		payload := svcevents.TicketFinishPreparingEvent{
 			ID: ticket.ID,
 		}
-		event := events.NewEvent(svcevents.TICKET_FINISH_PREPARING_EVENT_ID, ticket.ID, payload)
+		event := svcevents.TicketFinishPreparing.New(ticket.ID, payload).WithTraceContext(ctx).WithCorrelationID(ticket.CorrelationID)
+
+		err := t.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, event)
+		return err
+	case models.DECLINE_TICKET:
+		payload := svcevents.TicketDeclinedEvent{
+			ID:     ticket.ID,
+			Reason: ticketRequest.DeclineReason,
+		}
+		event := svcevents.TicketDeclined.New(ticket.ID, payload).WithTraceContext(ctx).WithCorrelationID(ticket.CorrelationID)
 
 		err := t.publisher.Publish(svcevents.KITCHEN_EVENTS_TOPIC, event)
 		return err
 	default:
-		// no event needs to be published
-		return nil
+		// Every event a restaurant can trigger has to reach the order and the
+		// delivery, so a ticket can't change state without publishing one.
+		return ErrForbiddenTicketEvent
 	}
 }
 
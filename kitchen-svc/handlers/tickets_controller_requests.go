@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
 	"github.com/VitoNaychev/food-app/reqbuilder"
 )
@@ -24,6 +25,19 @@ func NewBeginPreparingTicketRequest(jwt string, id int, readyBy string) *http.Re
 	return request
 }
 
+func NewDeclineTicketRequest(jwt string, id int, reason svcevents.TicketDeclineReason) *http.Request {
+	ticketRequest := StateTransitionTicketRequest{
+		ID:            id,
+		Event:         models.DECLINE_TICKET,
+		DeclineReason: reason,
+	}
+
+	request := reqbuilder.NewRequestWithBody(http.MethodPost, "/tickets", ticketRequest)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
+
 func NewChangeTicketStateRequest(jwt string, id int, event models.TicketEvent) *http.Request {
 	ticketRequest := StateTransitionTicketRequest{
 		ID:    id,
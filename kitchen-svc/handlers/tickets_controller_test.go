@@ -15,8 +15,9 @@ import (
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
 	"github.com/VitoNaychev/food-app/kitchen-svc/stubs"
 	"github.com/VitoNaychev/food-app/kitchen-svc/testdata"
-
+	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/testutil/dummies"
 	"github.com/VitoNaychev/food-app/testutil/tabletests"
 	"github.com/VitoNaychev/food-app/validation"
 )
@@ -38,12 +39,13 @@ func TestMain(m *testing.M) {
 }
 
 func TestTicketEndpointAuthentication(t *testing.T) {
-	server := handlers.NewTicketServer(env.SecretKey,
+	server := handlers.NewTicketServer(auth.HMACKey(env.SecretKey),
 		&stubs.StubTicketStore{},
 		&stubs.StubTicketItemStore{},
 		&stubs.StubMenuItemStore{},
 		&stubs.StubRestaurantStore{},
-		&stubs.StubEventPublisher{})
+		&stubs.StubEventPublisher{},
+		dummies.DummyVerifyJWT)
 
 	invalidJWT := "invalidJWT"
 	cases := map[string]*http.Request{
@@ -54,6 +56,32 @@ func TestTicketEndpointAuthentication(t *testing.T) {
 	tabletests.RunAuthenticationTests(t, server, cases)
 }
 
+func TestTicketEndpointRevokedJWT(t *testing.T) {
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{
+			testdata.ShackRestaurant,
+		},
+	}
+
+	revokedVerifyJWT := func(jwt string) (msgtypes.AuthResponse, error) {
+		return msgtypes.AuthResponse{Status: msgtypes.REVOKED}, nil
+	}
+	server := handlers.NewTicketServer(auth.HMACKey(env.SecretKey), &stubs.StubTicketStore{}, nil, nil, restaurantStore,
+		&stubs.StubEventPublisher{}, revokedVerifyJWT)
+
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
+
+	t.Run("returns Unauthorized on JWT revoked by restaurant-svc", func(t *testing.T) {
+		request := handlers.NewGetTicketsRequest(shackJWT, "")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+}
+
 func TestTicketStateTransisions(t *testing.T) {
 	ticketStore := &stubs.StubTicketStore{}
 	restaurantStore := &stubs.StubRestaurantStore{
@@ -64,9 +92,9 @@ func TestTicketStateTransisions(t *testing.T) {
 
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewTicketServer(env.SecretKey, ticketStore, nil, nil, restaurantStore, publisher)
+	server := handlers.NewTicketServer(auth.HMACKey(env.SecretKey), ticketStore, nil, nil, restaurantStore, publisher, dummies.DummyVerifyJWT)
 
-	shackJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.ShackRestaurant.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 
 	t.Run("changes ticket state to IN_PROGRESS on event BEGIN_PREPARING", func(t *testing.T) {
 		ticketStore.SpyTicket = testdata.OpenShackTicket
@@ -133,10 +161,35 @@ func TestTicketStateTransisions(t *testing.T) {
 		testutil.AssertErrorResponse(t, response.Body, handlers.ErrUnsuportedStateTransition)
 	})
 
+	t.Run("returns Bad Request on events restaurants can't trigger", func(t *testing.T) {
+		cases := []struct {
+			ticket models.Ticket
+			event  models.TicketEvent
+		}{
+			{testdata.OpenShackTicket, models.APPROVE_TICKET},
+			{testdata.OpenShackTicket, models.CANCEL_TICKET},
+			{testdata.ReadyForPickupShackTicket, models.COMPLETE_TICKET},
+			{testdata.ReadyForPickupShackTicket, models.COMPLETE_CONFIRMED},
+		}
+
+		for _, c := range cases {
+			ticketStore.SpyTicket = c.ticket
+
+			request := handlers.NewChangeTicketStateRequest(shackJWT, c.ticket.ID, c.event)
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, request)
+
+			testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+			testutil.AssertErrorResponse(t, response.Body, handlers.ErrForbiddenTicketEvent)
+			testutil.AssertEqual(t, ticketStore.SpyTicket.State, c.ticket.State)
+		}
+	})
+
 	t.Run("changes ticket state to DECLINED on event DECLINE", func(t *testing.T) {
 		ticketStore.SpyTicket = testdata.OpenShackTicket
 
-		request := handlers.NewChangeTicketStateRequest(shackJWT, testdata.OpenShackTicket.ID, models.DECLINE_TICKET)
+		request := handlers.NewDeclineTicketRequest(shackJWT, testdata.OpenShackTicket.ID, svcevents.DECLINE_REASON_TOO_BUSY)
 		response := httptest.NewRecorder()
 
 		request.Header.Add("Subject", "1")
@@ -155,6 +208,32 @@ func TestTicketStateTransisions(t *testing.T) {
 		json.NewDecoder(response.Body).Decode(&got)
 
 		testutil.AssertEqual(t, got, want)
+
+		wantTopic := svcevents.KITCHEN_EVENTS_TOPIC
+		wantEvent := events.InterfaceEvent{
+			EventID:     svcevents.TICKET_DECLINED_EVENT_ID,
+			AggregateID: testdata.OpenShackTicket.ID,
+			Payload: svcevents.TicketDeclinedEvent{
+				ID:     testdata.OpenShackTicket.ID,
+				Reason: svcevents.DECLINE_REASON_TOO_BUSY,
+			},
+		}
+
+		testutil.AssertEqual(t, publisher.SpyTopic, wantTopic)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("returns Bad Request on DECLINE with unsupported reason", func(t *testing.T) {
+		ticketStore.SpyTicket = testdata.OpenShackTicket
+
+		request := handlers.NewDeclineTicketRequest(shackJWT, testdata.OpenShackTicket.ID, svcevents.TicketDeclineReason(42))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrInvalidDeclineReason)
+		testutil.AssertEqual(t, ticketStore.SpyTicket.State, models.OPEN)
 	})
 
 	t.Run("changes ticket state to READY_FOR_PICKUP on event FINISH_PREPARING", func(t *testing.T) {
@@ -192,6 +271,19 @@ func TestTicketStateTransisions(t *testing.T) {
 		testutil.AssertEqual(t, publisher.SpyTopic, wantTopic)
 		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
 	})
+
+	t.Run("correlates published event with the order event the ticket was created by", func(t *testing.T) {
+		ticketStore.SpyTicket = testdata.InProgressShackTicket
+		ticketStore.SpyTicket.CorrelationID = "correlation-id"
+
+		request := handlers.NewChangeTicketStateRequest(shackJWT, testdata.InProgressShackTicket.ID, models.FINISH_PREPARING)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, publisher.SpyEvent.Headers.CorrelationID, "correlation-id")
+	})
 }
 
 func TestTicketGetters(t *testing.T) {
@@ -218,9 +310,21 @@ func TestTicketGetters(t *testing.T) {
 
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewTicketServer(env.SecretKey, ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher)
+	server := handlers.NewTicketServer(auth.HMACKey(env.SecretKey), ticketStore, ticketItemStore, menuItemStore, restaurantStore, publisher, dummies.DummyVerifyJWT)
+
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 
-	shackJWT, _ := auth.GenerateJWT(env.SecretKey, env.ExpiresAt, testdata.ShackRestaurant.ID)
+	t.Run("returns Unauthorized on courier JWT with restaurant's ID", func(t *testing.T) {
+		courierJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), env.ExpiresAt, testdata.ShackRestaurant.ID, auth.COURIER)
+
+		request := handlers.NewGetTicketsRequest(courierJWT, "?state=open")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRole)
+	})
 
 	t.Run("returns open tickets on GET to /tickets?state=open", func(t *testing.T) {
 		want := testdata.OpenShackTicketResponse
@@ -38,9 +38,9 @@ func TestTicketControllerIntegration(t *testing.T) {
 
 	initTables(t, restaurantStore, menuItemStore, ticketStore, ticketItemStore)
 
-	shackJWT, _ := auth.GenerateJWT(env.SecretKey, time.Second*10, testdata.ShackRestaurant.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(env.SecretKey), time.Second*10, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 
-	server := handlers.NewTicketServer(env.SecretKey, ticketStore, ticketItemStore, menuItemStore, restaurantStore, &dummies.DummyPublisher{})
+	server := handlers.NewTicketServer(auth.HMACKey(env.SecretKey), ticketStore, ticketItemStore, menuItemStore, restaurantStore, &dummies.DummyPublisher{}, dummies.DummyVerifyJWT)
 
 	t.Run("gets all tickets for a restaurant", func(t *testing.T) {
 		request := handlers.NewGetTicketsRequest(shackJWT, "")
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgMenuItemStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgMenuItemStore(ctx context.Context, connString string) (*PgMenuItemStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
@@ -24,6 +25,14 @@ func NewPgMenuItemStore(ctx context.Context, connString string) (*PgMenuItemStor
 	return &pgMenuItemStore, nil
 }
 
+func (p *PgMenuItemStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgMenuItemStore) WithTx(tx pgx.Tx) MenuItemStore {
+	return &PgMenuItemStore{tx}
+}
+
 func (p *PgMenuItemStore) GetMenuItemByID(id int) (MenuItem, error) {
 	query := `SELECT * FROM menu_items WHERE id = @id`
 	args := pgx.NamedArgs{"id": id}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgRestaurantStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgRestaurantStore(ctx context.Context, connString string) (*PgRestaurantStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
@@ -24,6 +25,14 @@ func NewPgRestaurantStore(ctx context.Context, connString string) (*PgRestaurant
 	return &pgRestaurantStore, nil
 }
 
+func (p *PgRestaurantStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgRestaurantStore) WithTx(tx pgx.Tx) RestaurantStore {
+	return &PgRestaurantStore{tx}
+}
+
 func (p *PgRestaurantStore) DeleteRestaurant(id int) error {
 	query := `DELETE FROM restaurants WHERE id = @id`
 	args := pgx.NamedArgs{"id": id}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgTicketItemStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgTicketItemStore(ctx context.Context, connString string) (*PgTicketItemStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
@@ -24,6 +25,14 @@ func NewPgTicketItemStore(ctx context.Context, connString string) (*PgTicketItem
 	return &pgTicketItemStore, nil
 }
 
+func (p *PgTicketItemStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgTicketItemStore) WithTx(tx pgx.Tx) TicketItemStore {
+	return &PgTicketItemStore{tx}
+}
+
 func (p *PgTicketItemStore) CreateTicketItem(ticketItem *TicketItem) error {
 	query := `insert into ticket_items(id, ticket_id, menu_item_id, quantity) 
 	values (@id, @ticket_id, @menu_item_id, @quantity)`
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgTicketStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgTicketStore(ctx context.Context, connString string) (*PgTicketStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
@@ -24,16 +25,25 @@ func NewPgTicketStore(ctx context.Context, connString string) (*PgTicketStore, e
 	return &pgTicketStore, nil
 }
 
+func (p *PgTicketStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgTicketStore) WithTx(tx pgx.Tx) TicketStore {
+	return &PgTicketStore{tx}
+}
+
 func (p *PgTicketStore) CreateTicket(ticket *Ticket) error {
-	query := `insert into tickets(id, restaurant_id, state, total, ready_by) 
-	values (@id, @restaurant_id, @state, @total, @ready_by)`
+	query := `insert into tickets(id, restaurant_id, state, total, ready_by, correlation_id) 
+	values (@id, @restaurant_id, @state, @total, @ready_by, @correlation_id)`
 
 	args := pgx.NamedArgs{
-		"id":            ticket.ID,
-		"restaurant_id": ticket.RestaurantID,
-		"state":         ticket.State,
-		"total":         ticket.Total,
-		"ready_by":      ticket.ReadyBy,
+		"id":             ticket.ID,
+		"restaurant_id":  ticket.RestaurantID,
+		"state":          ticket.State,
+		"total":          ticket.Total,
+		"ready_by":       ticket.ReadyBy,
+		"correlation_id": ticket.CorrelationID,
 	}
 
 	_, err := p.conn.Exec(context.Background(), query, args)
@@ -8,6 +8,9 @@ type Ticket struct {
 	RestaurantID int `db:"restaurant_id"`
 	Total        float32
 	ReadyBy      time.Time `db:"ready_by"`
+	// CorrelationID is the correlation of the event the ticket was created
+	// by, which the events published on behalf of the restaurant continue.
+	CorrelationID string `db:"correlation_id"`
 	// PreparingTime      time.Time
 	// PickedUpTime       time.Time
 	// ReadyForPickupTime time.Time
@@ -5,6 +5,7 @@ import "github.com/VitoNaychev/food-app/sm"
 var ticketDeltas = []sm.Delta{
 	{Current: sm.State(CREATE_PENDING), Event: sm.Event(APPROVE_TICKET), Next: sm.State(OPEN), Predicate: nil, Callback: nil},
 	{Current: sm.State(CREATE_PENDING), Event: sm.Event(REJECT_TICKET), Next: sm.State(REJECTED), Predicate: nil, Callback: nil},
+	{Current: sm.State(CREATE_PENDING), Event: sm.Event(CANCEL_TICKET), Next: sm.State(CANCELED), Predicate: nil, Callback: nil},
 	{Current: sm.State(OPEN), Event: sm.Event(CANCEL_TICKET), Next: sm.State(CANCELED), Predicate: nil, Callback: nil},
 	{Current: sm.State(OPEN), Event: sm.Event(DECLINE_TICKET), Next: sm.State(DECLINED), Predicate: nil, Callback: nil},
 	{Current: sm.State(OPEN), Event: sm.Event(BEGIN_PREPARING), Next: sm.State(IN_PROGRESS), Predicate: nil, Callback: nil},
@@ -22,6 +22,10 @@ func StateNameToStateValue(stateName string) (TicketState, error) {
 	var stateValue TicketState
 
 	switch stateName {
+	case "create_pending":
+		stateValue = CREATE_PENDING
+	case "rejected":
+		stateValue = REJECTED
 	case "open":
 		stateValue = OPEN
 	case "in_progress":
@@ -44,6 +48,10 @@ func StateValueToStateName(stateValue TicketState) (string, error) {
 	var stateName string
 
 	switch stateValue {
+	case CREATE_PENDING:
+		stateName = "create_pending"
+	case REJECTED:
+		stateName = "rejected"
 	case OPEN:
 		stateName = "open"
 	case IN_PROGRESS:
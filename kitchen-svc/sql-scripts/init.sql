@@ -1,3 +1,5 @@
+DROP TABLE IF EXISTS inbox;
+DROP TABLE IF EXISTS outbox;
 DROP TABLE IF EXISTS ticket_items;
 DROP TABLE IF EXISTS tickets;
 DROP TABLE IF EXISTS menu_items;
@@ -20,7 +22,8 @@ CREATE TABLE tickets (
     restaurant_id             int                          REFERENCES restaurants(id),
     total                     numeric(8, 2)                NOT NULL,
     state                     int                          NOT NULL,
-    ready_by                  timestamp with time zone     NOT NULL
+    ready_by                  timestamp with time zone     NOT NULL,
+    correlation_id            varchar(100)                 NOT NULL      DEFAULT ''
 );
 
 CREATE TABLE ticket_items (
@@ -30,4 +33,17 @@ CREATE TABLE ticket_items (
     quantity     int       NOT NULL
 );
 
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
 
+CREATE TABLE inbox (
+  key                 varchar(100)         PRIMARY KEY,
+  processed_at        timestamp with time zone NOT NULL DEFAULT now()
+);
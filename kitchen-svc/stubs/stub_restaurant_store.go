@@ -1,6 +1,9 @@
 package stubs
 
-import "github.com/VitoNaychev/food-app/kitchen-svc/models"
+import (
+	"github.com/VitoNaychev/food-app/kitchen-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
 
 type StubRestaurantStore struct {
 	Restaurants         []models.Restaurant
@@ -20,7 +23,7 @@ func (s *StubRestaurantStore) GetRestaurantByID(id int) (models.Restaurant, erro
 		}
 	}
 
-	return models.Restaurant{}, nil
+	return models.Restaurant{}, storeerrors.ErrNotFound
 }
 
 func (s *StubRestaurantStore) CreateRestaurant(restaurant *models.Restaurant) error {
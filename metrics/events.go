@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	SUCCESS_RESULT   = "success"
+	ERROR_RESULT     = "error"
+	MALFORMED_RESULT = "malformed"
+)
+
+var (
+	eventsPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "events_published_total",
+		Help: "Number of events published to Kafka.",
+	}, []string{"topic", "event_id", "result"})
+
+	eventsConsumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "events_consumed_total",
+		Help: "Number of events consumed from Kafka.",
+	}, []string{"topic", "event_id", "result"})
+
+	eventHandlerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "event_handler_duration_seconds",
+		Help:    "Duration of event handler invocations, including retries.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic", "event_id"})
+
+	consumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_group_lag",
+		Help: "Number of messages the consumer group is behind on a partition.",
+	}, []string{"topic", "partition"})
+)
+
+func init() {
+	Registry.MustRegister(eventsPublished, eventsConsumed, eventHandlerDuration, consumerLag)
+}
+
+func result(err error) string {
+	if err != nil {
+		return ERROR_RESULT
+	}
+	return SUCCESS_RESULT
+}
+
+func ObserveEventPublished(topic string, eventID int, err error) {
+	eventsPublished.WithLabelValues(topic, strconv.Itoa(eventID), result(err)).Inc()
+}
+
+func ObserveEventHandled(topic string, eventID int, duration time.Duration, err error) {
+	eventsConsumed.WithLabelValues(topic, strconv.Itoa(eventID), result(err)).Inc()
+	eventHandlerDuration.WithLabelValues(topic, strconv.Itoa(eventID)).Observe(duration.Seconds())
+}
+
+// ObserveMalformedEvent records an event that couldn't be decoded, and so
+// has no known EventID.
+func ObserveMalformedEvent(topic string) {
+	eventsConsumed.WithLabelValues(topic, "", MALFORMED_RESULT).Inc()
+}
+
+func SetConsumerLag(topic string, partition int32, lag int64) {
+	consumerLag.WithLabelValues(topic, strconv.Itoa(int(partition))).Set(float64(lag))
+}
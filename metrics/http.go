@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Requests are recorded under the pattern of the route they matched rather
+// than their path, so that requests to arbitrary paths can't create new
+// series. Requests that matched no route are all recorded under this route.
+const UnmatchedRoute = "unmatched"
+
+// router is implemented by *http.ServeMux and the servers that embed one.
+type router interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of handled HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of handled HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+func init() {
+	Registry.MustRegister(httpRequests, httpRequestDuration)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Middleware serves the metrics on MetricsPath and records the requests to
+// every other path handled by handler.
+func Middleware(handler http.Handler) http.Handler {
+	metricsHandler := Handler()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == MetricsPath {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+
+		handler.ServeHTTP(recorder, r)
+
+		route := matchedRoute(handler, r)
+		status := strconv.Itoa(recorder.status)
+
+		httpRequests.WithLabelValues(r.Method, route, status).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// matchedRoute follows the request through nested routers and returns the
+// pattern of the innermost route it matched.
+func matchedRoute(handler http.Handler, r *http.Request) string {
+	route := UnmatchedRoute
+	for {
+		router, ok := handler.(router)
+		if !ok {
+			return route
+		}
+
+		var pattern string
+		handler, pattern = router.Handler(r)
+		if pattern == "" {
+			return route
+		}
+		route = pattern
+	}
+}
@@ -0,0 +1,114 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/metrics"
+	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func scrapeMetrics(t testing.TB, handler http.Handler) string {
+	t.Helper()
+
+	request, _ := http.NewRequest(http.MethodGet, metrics.MetricsPath, nil)
+	response := httptest.NewRecorder()
+
+	handler.ServeHTTP(response, request)
+
+	testutil.AssertStatus(t, response.Code, http.StatusOK)
+	return response.Body.String()
+}
+
+func assertMetric(t testing.TB, scraped string, metric string) {
+	t.Helper()
+
+	if !strings.Contains(scraped, metric) {
+		t.Errorf("didn't find metric %q in scraped metrics", metric)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	orderRouter := http.NewServeMux()
+	orderRouter.HandleFunc("/order/all/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	router := http.NewServeMux()
+	router.Handle("/order/", orderRouter)
+	handler := metrics.Middleware(router)
+
+	t.Run("records request per route and status", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/order/all/", nil)
+		response := httptest.NewRecorder()
+
+		handler.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusAccepted)
+
+		scraped := scrapeMetrics(t, handler)
+		assertMetric(t, scraped, `http_requests_total{method="GET",route="/order/all/",status="202"} 1`)
+		assertMetric(t, scraped, `http_request_duration_seconds_count{method="GET",route="/order/all/",status="202"} 1`)
+	})
+
+	t.Run("records requests under the pattern of the route they matched", func(t *testing.T) {
+		for _, path := range []string{"/order/all/1", "/order/all/2"} {
+			request, _ := http.NewRequest(http.MethodPost, path, nil)
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		scraped := scrapeMetrics(t, handler)
+		assertMetric(t, scraped, `http_requests_total{method="POST",route="/order/all/",status="202"} 2`)
+	})
+
+	t.Run("records requests that matched no route under a single route", func(t *testing.T) {
+		for _, path := range []string{"/customer/1/", "/customer/2/"} {
+			request, _ := http.NewRequest(http.MethodGet, path, nil)
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+		}
+
+		scraped := scrapeMetrics(t, handler)
+		assertMetric(t, scraped, `http_requests_total{method="GET",route="unmatched",status="404"} 2`)
+	})
+
+	t.Run("records requests that matched only an outer route under its pattern", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/order/1/", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		scraped := scrapeMetrics(t, handler)
+		assertMetric(t, scraped, `http_requests_total{method="GET",route="/order/",status="404"} 1`)
+	})
+}
+
+func TestEventMetrics(t *testing.T) {
+	metrics.ObserveEventPublished("order-events-topic", 1, nil)
+	metrics.ObserveEventHandled("order-events-topic", 2, time.Millisecond, errors.New("dummy error"))
+	metrics.SetConsumerLag("order-events-topic", 0, 5)
+
+	scraped := scrapeMetrics(t, metrics.Handler())
+	assertMetric(t, scraped, `events_published_total{event_id="1",result="success",topic="order-events-topic"} 1`)
+	assertMetric(t, scraped, `events_consumed_total{event_id="2",result="error",topic="order-events-topic"} 1`)
+	assertMetric(t, scraped, `event_handler_duration_seconds_count{event_id="2",topic="order-events-topic"} 1`)
+	assertMetric(t, scraped, `kafka_consumer_group_lag{partition="0",topic="order-events-topic"} 5`)
+}
+
+func TestPgPoolMetrics(t *testing.T) {
+	// The pool connects lazily, so it doesn't need a database to report
+	// its stats.
+	pool, err := pgxpool.New(context.Background(), "postgres://user@localhost:1/db?pool_max_conns=3")
+	testutil.AssertNoErr(t, err)
+	defer pool.Close()
+
+	metrics.RegisterPgPool(pool)
+
+	scraped := scrapeMetrics(t, metrics.Handler())
+	assertMetric(t, scraped, "pgxpool_max_conns 3")
+	assertMetric(t, scraped, "pgxpool_total_conns 0")
+	assertMetric(t, scraped, "pgxpool_acquires_total 0")
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pgConnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pgx_connects_total",
+		Help: "Number of attempts to connect to the database.",
+	}, []string{"result"})
+
+	pgQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pgx_queries_total",
+		Help: "Number of executed database queries.",
+	}, []string{"result"})
+
+	pgQueryDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pgx_query_duration_seconds",
+		Help:    "Duration of executed database queries.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	Registry.MustRegister(pgConnects, pgQueries, pgQueryDuration, pgPools)
+}
+
+type queryStartKey struct{}
+
+// PgQueryTracer records the connection attempts and the number and duration
+// of the queries executed on a pgx connection.
+type PgQueryTracer struct{}
+
+func NewPgQueryTracer() *PgQueryTracer {
+	return &PgQueryTracer{}
+}
+
+func (p *PgQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (p *PgQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	pgQueries.WithLabelValues(result(data.Err)).Inc()
+
+	if start, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		pgQueryDuration.Observe(time.Since(start).Seconds())
+	}
+}
+
+func (p *PgQueryTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	return ctx
+}
+
+func (p *PgQueryTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	pgConnects.WithLabelValues(result(data.Err)).Inc()
+}
+
+var (
+	pgPoolAcquiredConns = prometheus.NewDesc("pgxpool_acquired_conns",
+		"Number of connections currently acquired from the pools.", nil, nil)
+	pgPoolIdleConns = prometheus.NewDesc("pgxpool_idle_conns",
+		"Number of idle connections in the pools.", nil, nil)
+	pgPoolTotalConns = prometheus.NewDesc("pgxpool_total_conns",
+		"Number of connections in the pools.", nil, nil)
+	pgPoolMaxConns = prometheus.NewDesc("pgxpool_max_conns",
+		"Maximum number of connections of the pools.", nil, nil)
+	pgPoolAcquires = prometheus.NewDesc("pgxpool_acquires_total",
+		"Number of connections acquired from the pools.", nil, nil)
+	pgPoolEmptyAcquires = prometheus.NewDesc("pgxpool_empty_acquires_total",
+		"Number of acquires that had to wait for a connection.", nil, nil)
+	pgPoolAcquireDuration = prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+		"Time spent acquiring connections from the pools.", nil, nil)
+
+	pgPools = &pgPoolCollector{}
+)
+
+// RegisterPgPool exports the stats of pool. Every store opens a pool of its
+// own, so the stats of all registered pools are summed up.
+func RegisterPgPool(pool *pgxpool.Pool) {
+	pgPools.add(pool)
+}
+
+type pgPoolCollector struct {
+	mu    sync.Mutex
+	pools []*pgxpool.Pool
+}
+
+func (p *pgPoolCollector) add(pool *pgxpool.Pool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pools = append(p.pools, pool)
+}
+
+func (p *pgPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgPoolAcquiredConns
+	ch <- pgPoolIdleConns
+	ch <- pgPoolTotalConns
+	ch <- pgPoolMaxConns
+	ch <- pgPoolAcquires
+	ch <- pgPoolEmptyAcquires
+	ch <- pgPoolAcquireDuration
+}
+
+func (p *pgPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var acquiredConns, idleConns, totalConns, maxConns int32
+	var acquires, emptyAcquires int64
+	var acquireDuration time.Duration
+	for _, pool := range p.pools {
+		stat := pool.Stat()
+
+		acquiredConns += stat.AcquiredConns()
+		idleConns += stat.IdleConns()
+		totalConns += stat.TotalConns()
+		maxConns += stat.MaxConns()
+		acquires += stat.AcquireCount()
+		emptyAcquires += stat.EmptyAcquireCount()
+		acquireDuration += stat.AcquireDuration()
+	}
+
+	ch <- prometheus.MustNewConstMetric(pgPoolAcquiredConns, prometheus.GaugeValue, float64(acquiredConns))
+	ch <- prometheus.MustNewConstMetric(pgPoolIdleConns, prometheus.GaugeValue, float64(idleConns))
+	ch <- prometheus.MustNewConstMetric(pgPoolTotalConns, prometheus.GaugeValue, float64(totalConns))
+	ch <- prometheus.MustNewConstMetric(pgPoolMaxConns, prometheus.GaugeValue, float64(maxConns))
+	ch <- prometheus.MustNewConstMetric(pgPoolAcquires, prometheus.CounterValue, float64(acquires))
+	ch <- prometheus.MustNewConstMetric(pgPoolEmptyAcquires, prometheus.CounterValue, float64(emptyAcquires))
+	ch <- prometheus.MustNewConstMetric(pgPoolAcquireDuration, prometheus.CounterValue, acquireDuration.Seconds())
+}
@@ -7,9 +7,11 @@ const (
 	INVALID
 	NOT_FOUND
 	OK
+	REVOKED
 )
 
 type AuthResponse struct {
 	Status AuthStatus
 	ID     int
+	Role   string
 }
@@ -9,10 +9,13 @@ import (
 	"strings"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/order-svc/handlers"
 	"github.com/VitoNaychev/food-app/order-svc/models"
 	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func main() {
@@ -28,6 +31,12 @@ func main() {
 		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), "order-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -46,13 +55,97 @@ func main() {
 		log.Fatalf("Address Store error: %v", err)
 	}
 
-	eventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	restaurantStore, err := models.NewPgRestaurantStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Restaurant Store error: %v", err)
+	}
+
+	menuItemStore, err := models.NewPgMenuItemStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Menu Item Store error: %v", err)
+	}
+
+	restaurantAddressStore, err := models.NewPgRestaurantAddressStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Restaurant Address Store error: %v", err)
+	}
+
+	restaurantHoursStore, err := models.NewPgRestaurantHoursStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Restaurant Hours Store error: %v", err)
+	}
+
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
 	if err != nil {
 		log.Fatalf("Kafka Event Publisher error: %v\n", err)
 	}
+	kafkaEventPublisher.SetProducer("order-svc")
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(context.Background())
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
+
+	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "order-svc")
+	if err != nil {
+		log.Fatalf("Kafka Event Consumer error: %v\n", err)
+	}
+
+	inboxStore, err := events.NewPgInboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Inbox Store error: %v\n", err)
+	}
+	eventConsumer.SetInboxStore(inboxStore)
+	eventConsumer.SetDeadLetterPublisher(kafkaEventPublisher)
+
+	kitchenEventHandler := handlers.NewKitchenEventHandler(orderStore, eventPublisher)
+	err = handlers.RegisterKitchenEventHandlers(eventConsumer, kitchenEventHandler)
+	if err != nil {
+		log.Fatalf("Kitchen Event Handlers error: %v\n", err)
+	}
+
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(orderStore)
+	err = handlers.RegisterDeliveryEventHandlers(eventConsumer, deliveryEventHandler)
+	if err != nil {
+		log.Fatalf("Delivery Event Handlers error: %v\n", err)
+	}
+
+	paymentEventHandler := handlers.NewPaymentEventHandler(orderStore, eventPublisher)
+	err = handlers.RegisterPaymentEventHandlers(eventConsumer, paymentEventHandler)
+	if err != nil {
+		log.Fatalf("Payment Event Handlers error: %v\n", err)
+	}
+
+	restaurantEventHandler := handlers.NewRestaurantEventHandler(restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore)
+	err = handlers.RegisterRestaurantEventHandlers(eventConsumer, restaurantEventHandler)
+	if err != nil {
+		log.Fatalf("Restaurant Event Handlers error: %v\n", err)
+	}
+
+	go eventConsumer.Run(context.Background())
+	go events.LogEventConsumerErrors(context.Background(), eventConsumer)
+
+	authConfig := auth.DefaultRemoteAuthConfig
+	authConfig.URL = os.Getenv("CUSTOMER_AUTH_URL")
+	if authConfig.URL == "" {
+		authConfig.URL = "http://customer-svc:8080/customer/auth/"
+	}
+	authClient := auth.NewRemoteAuthClient(authConfig)
 
-	orderServer := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, eventPublisher, handlers.VerifyJWT)
+	orderServer := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, eventPublisher, authClient.VerifyJWT)
 
 	fmt.Println("Order service listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", orderServer))
+	log.Fatal(http.ListenAndServe(":8080", tracing.Middleware(metrics.Middleware(orderServer), "order-svc")))
 }
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type DeliveryEventHandler struct {
+	orderStore models.OrderStore
+}
+
+func NewDeliveryEventHandler(orderStore models.OrderStore) *DeliveryEventHandler {
+	deliveryEventHandler := DeliveryEventHandler{
+		orderStore: orderStore,
+	}
+
+	return &deliveryEventHandler
+}
+
+func RegisterDeliveryEventHandlers(eventConsumer events.EventConsumer, deliveryEventHandler *DeliveryEventHandler) error {
+	err := svcevents.DeliveryPickedUp.Register(eventConsumer, deliveryEventHandler.HandleDeliveryPickedUpEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.DeliveryCompleted.Register(eventConsumer, deliveryEventHandler.HandleDeliveryCompletedEvent)
+}
+
+func (d *DeliveryEventHandler) HandleDeliveryPickedUpEvent(event events.Event[svcevents.DeliveryPickedUpEvent]) error {
+	return d.inTx(event.Context(), func(d *DeliveryEventHandler) error {
+		return applyEventAndUpdateOrder(d.orderStore, event.Payload.ID, models.PICKUP_ORDER)
+	})
+}
+
+func (d *DeliveryEventHandler) HandleDeliveryCompletedEvent(event events.Event[svcevents.DeliveryCompletedEvent]) error {
+	return d.inTx(event.Context(), func(d *DeliveryEventHandler) error {
+		return applyEventAndUpdateOrder(d.orderStore, event.Payload.ID, models.COMPLETE_ORDER)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (d *DeliveryEventHandler) inTx(ctx context.Context, fn func(d *DeliveryEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, d.orderStore, func(tx pgx.Tx) error {
+		txHandler := *d
+		txHandler.orderStore = pgconfig.WithTx(d.orderStore, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -0,0 +1,53 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/handlers"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/order-svc/stubs"
+	"github.com/VitoNaychev/food-app/order-svc/testdata"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestDeliveryEventHandler(t *testing.T) {
+	t.Run("updates order status on DELIVERY_PICKED_UP event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.PREPARED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewDeliveryEventHandler(orderStore)
+
+		want := order
+		want.Status = models.PICKED_UP
+
+		payload := svcevents.DeliveryPickedUpEvent{ID: want.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_PICKED_UP_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleDeliveryPickedUpEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("updates order status on DELIVERY_COMPLETED event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.PICKED_UP
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewDeliveryEventHandler(orderStore)
+
+		want := order
+		want.Status = models.COMPLETED
+
+		payload := svcevents.DeliveryCompletedEvent{ID: want.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+}
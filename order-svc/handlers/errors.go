@@ -8,4 +8,10 @@ var (
 	ErrCustomerNotFound  = errors.New("customer doesn't exist")
 	ErrOrderNotFound     = errors.New("order doesn't exist")
 	ErrUnathorizedAction = errors.New("customer does not have permission to perform this action")
+
+	ErrRestaurantNotFound      = errors.New("restaurant doesn't exist")
+	ErrRestaurantNotValid      = errors.New("restaurant isn't accepting orders")
+	ErrRestaurantClosed        = errors.New("restaurant is closed")
+	ErrMenuItemNotFound        = errors.New("menu item doesn't exist")
+	ErrMenuItemNotInRestaurant = errors.New("menu item doesn't belong to restaurant")
 )
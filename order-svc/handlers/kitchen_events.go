@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type KitchenEventHandler struct {
+	orderStore models.OrderStore
+	publisher  events.EventPublisher
+}
+
+func NewKitchenEventHandler(orderStore models.OrderStore, publisher events.EventPublisher) *KitchenEventHandler {
+	kitchenEventHandler := KitchenEventHandler{
+		orderStore: orderStore,
+		publisher:  publisher,
+	}
+
+	return &kitchenEventHandler
+}
+
+func RegisterKitchenEventHandlers(eventConsumer events.EventConsumer, kitchenEventHandler *KitchenEventHandler) error {
+	err := svcevents.TicketBeginPreparing.Register(eventConsumer, kitchenEventHandler.HandleTicketBeginPreparingEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.TicketFinishPreparing.Register(eventConsumer, kitchenEventHandler.HandleTicketFinishPreparingEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.TicketCancel.Register(eventConsumer, kitchenEventHandler.HandleTicketCancelEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.TicketApproved.Register(eventConsumer, kitchenEventHandler.HandleTicketApprovedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.TicketRejected.Register(eventConsumer, kitchenEventHandler.HandleTicketRejectedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.TicketDeclined.Register(eventConsumer, kitchenEventHandler.HandleTicketDeclinedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.TicketCancelRejected.Register(eventConsumer, kitchenEventHandler.HandleTicketCancelRejectedEvent)
+}
+
+func (k *KitchenEventHandler) HandleTicketApprovedEvent(event events.Event[svcevents.TicketApprovedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return applyTicketOutcome(k.orderStore, event.Payload.ID, models.APPROVE_ORDER)
+	})
+}
+
+func (k *KitchenEventHandler) HandleTicketRejectedEvent(event events.Event[svcevents.TicketRejectedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return applyTicketOutcome(k.orderStore, event.Payload.ID, models.REJECT_ORDER)
+	})
+}
+
+// The kitchen publishes a ticket's outcome again when the ORDER_CREATED event
+// is redelivered, so an order that is no longer pending approval has already
+// been decided.
+func applyTicketOutcome(orderStore models.OrderStore, orderID int, event models.OrderEvent) error {
+	order, err := orderStore.GetOrderByID(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != models.APPROVAL_PENDING {
+		return nil
+	}
+
+	return applyEventAndUpdateOrder(orderStore, orderID, event)
+}
+
+func (k *KitchenEventHandler) HandleTicketBeginPreparingEvent(event events.Event[svcevents.TicketBeginPreparingEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return applyTicketProgress(k.orderStore, event.Payload.ID, models.BEGIN_PREPARING_ORDER)
+	})
+}
+
+func (k *KitchenEventHandler) HandleTicketFinishPreparingEvent(event events.Event[svcevents.TicketFinishPreparingEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return applyTicketProgress(k.orderStore, event.Payload.ID, models.FINISH_PREPARING_ORDER)
+	})
+}
+
+// An order canceled while the kitchen was already preparing its ticket is
+// resumed by the TICKET_CANCEL_REJECTED event that follows, so the progress
+// made in the meantime is skipped rather than failed.
+func applyTicketProgress(orderStore models.OrderStore, orderID int, event models.OrderEvent) error {
+	order, err := orderStore.GetOrderByID(orderID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status == models.CANCELED {
+		return nil
+	}
+
+	return applyEventAndUpdateOrder(orderStore, orderID, event)
+}
+
+func (k *KitchenEventHandler) HandleTicketCancelRejectedEvent(event events.Event[svcevents.TicketCancelRejectedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		order, err := k.orderStore.GetOrderByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != models.CANCELED {
+			return nil
+		}
+
+		orderSM := models.NewOrderSM(order.Status)
+		err = orderSM.Exec(models.RESUME_ORDER)
+		if err != nil {
+			return err
+		}
+
+		if event.Payload.Prepared {
+			err = orderSM.Exec(models.FINISH_PREPARING_ORDER)
+			if err != nil {
+				return err
+			}
+		}
+
+		return k.orderStore.UpdateOrderStatus(order.ID, orderSM.Current())
+	})
+}
+
+func (k *KitchenEventHandler) HandleTicketCancelEvent(event events.Event[svcevents.TicketCancelEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		order, err := k.orderStore.GetOrderByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		// Tickets canceled in response to an ORDER_CANCELED event find the order
+		// already canceled, so there is nothing left to do.
+		if order.Status == models.CANCELED {
+			return nil
+		}
+
+		return applyEventAndUpdateOrder(k.orderStore, event.Payload.ID, models.CANCEL_ORDER)
+	})
+}
+
+func (k *KitchenEventHandler) HandleTicketDeclinedEvent(event events.Event[svcevents.TicketDeclinedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		order, err := k.orderStore.GetOrderByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		// A redelivered event finds the order already declined, but the refund
+		// request is published again in case it failed the first time around.
+		if order.Status != models.DECLINED {
+			err = applyEventAndUpdateOrder(k.orderStore, order.ID, models.DECLINE_ORDER)
+			if err != nil {
+				return err
+			}
+		}
+
+		payload := svcevents.RefundRequestedEvent{
+			ID:         order.ID,
+			CustomerID: order.CustomerID,
+			Amount:     order.Total,
+			Reason:     event.Payload.Reason.String(),
+		}
+		refundEvent := svcevents.RefundRequested.New(order.ID, payload).CausedBy(event.Headers)
+
+		return k.publisher.Publish(svcevents.ORDER_EVENTS_TOPIC, refundEvent)
+	})
+}
+
+func applyEventAndUpdateOrder(orderStore models.OrderStore, orderID int, event models.OrderEvent) error {
+	order, err := orderStore.GetOrderByID(orderID)
+	if err != nil {
+		return err
+	}
+
+	orderSM := models.NewOrderSM(order.Status)
+	err = orderSM.Exec(event)
+	if err != nil {
+		return err
+	}
+
+	return orderStore.UpdateOrderStatus(order.ID, orderSM.Current())
+}
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (k *KitchenEventHandler) inTx(ctx context.Context, fn func(k *KitchenEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, k.orderStore, func(tx pgx.Tx) error {
+		txHandler := *k
+		txHandler.orderStore = pgconfig.WithTx(k.orderStore, tx)
+		txHandler.publisher = pgconfig.WithTx(k.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
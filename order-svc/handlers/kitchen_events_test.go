@@ -0,0 +1,263 @@
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/handlers"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/order-svc/stubs"
+	"github.com/VitoNaychev/food-app/order-svc/testdata"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestKitchenEventHandler(t *testing.T) {
+	t.Run("updates order status on TICKET_APPROVED event", func(t *testing.T) {
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{testdata.PeterCreatedOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := testdata.PeterCreatedOrder
+		want.Status = models.APPROVED
+
+		payload := svcevents.TicketApprovedEvent{ID: want.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_APPROVED_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketApprovedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("updates order status on TICKET_REJECTED event", func(t *testing.T) {
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{testdata.PeterCreatedOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := testdata.PeterCreatedOrder
+		want.Status = models.REJECTED
+
+		payload := svcevents.TicketRejectedEvent{ID: want.ID, Reason: "menu item doesn't exist"}
+		event := events.NewTypedEvent(svcevents.TICKET_REJECTED_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("ignores republished TICKET_APPROVED event for approved order", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.PREPARING
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.TicketApprovedEvent{ID: order.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_APPROVED_EVENT_ID, order.ID, payload)
+
+		err := eventHandler.HandleTicketApprovedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, models.Order{})
+	})
+
+	t.Run("updates order status on TICKET_BEGIN_PREPARING event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.APPROVED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := order
+		want.Status = models.PREPARING
+
+		payload := svcevents.TicketBeginPreparingEvent{
+			ID:      want.ID,
+			ReadyBy: time.Now(),
+		}
+		event := events.NewTypedEvent(svcevents.TICKET_BEGIN_PREPARING_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketBeginPreparingEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("updates order status on TICKET_FINISH_PREPARING event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.PREPARING
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := order
+		want.Status = models.PREPARED
+
+		payload := svcevents.TicketFinishPreparingEvent{ID: want.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_FINISH_PREPARING_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketFinishPreparingEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("updates order status on TICKET_CANCEL event", func(t *testing.T) {
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{testdata.PeterCreatedOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := testdata.PeterCreatedOrder
+		want.Status = models.CANCELED
+
+		payload := svcevents.TicketCancelEvent{ID: want.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketCancelEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("ignores TICKET_CANCEL event for canceled order", func(t *testing.T) {
+		canceledOrder := testdata.PeterCreatedOrder
+		canceledOrder.Status = models.CANCELED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{canceledOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.TicketCancelEvent{ID: canceledOrder.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketCancelEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, models.Order{})
+	})
+
+	t.Run("ignores TICKET_BEGIN_PREPARING event for canceled order", func(t *testing.T) {
+		canceledOrder := testdata.PeterCreatedOrder
+		canceledOrder.Status = models.CANCELED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{canceledOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.TicketBeginPreparingEvent{ID: canceledOrder.ID, ReadyBy: time.Now()}
+		event := events.NewTypedEvent(svcevents.TICKET_BEGIN_PREPARING_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketBeginPreparingEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, models.Order{})
+	})
+
+	t.Run("resumes canceled order on TICKET_CANCEL_REJECTED event", func(t *testing.T) {
+		canceledOrder := testdata.PeterCreatedOrder
+		canceledOrder.Status = models.CANCELED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{canceledOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := canceledOrder
+		want.Status = models.PREPARING
+
+		payload := svcevents.TicketCancelRejectedEvent{ID: canceledOrder.ID, Prepared: false}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_REJECTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketCancelRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("resumes canceled order as prepared on TICKET_CANCEL_REJECTED event for prepared ticket", func(t *testing.T) {
+		canceledOrder := testdata.PeterCreatedOrder
+		canceledOrder.Status = models.CANCELED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{canceledOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		want := canceledOrder
+		want.Status = models.PREPARED
+
+		payload := svcevents.TicketCancelRejectedEvent{ID: canceledOrder.ID, Prepared: true}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_REJECTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketCancelRejectedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+	})
+
+	t.Run("declines order and requests refund on TICKET_DECLINED event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.APPROVED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, publisher)
+
+		want := order
+		want.Status = models.DECLINED
+
+		payload := svcevents.TicketDeclinedEvent{ID: want.ID, Reason: svcevents.DECLINE_REASON_ITEMS_UNAVAILABLE}
+		event := events.NewTypedEvent(svcevents.TICKET_DECLINED_EVENT_ID, want.ID, payload)
+
+		err := eventHandler.HandleTicketDeclinedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+
+		wantEvent := events.NewEvent(svcevents.REFUND_REQUESTED_EVENT_ID, order.ID, svcevents.RefundRequestedEvent{
+			ID:         order.ID,
+			CustomerID: order.CustomerID,
+			Amount:     order.Total,
+			Reason:     "items_unavailable",
+		})
+		testutil.AssertEqual(t, publisher.Topic, svcevents.ORDER_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.Event, wantEvent)
+	})
+
+	t.Run("requests refund again on redelivered TICKET_DECLINED event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.DECLINED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, publisher)
+
+		payload := svcevents.TicketDeclinedEvent{ID: order.ID, Reason: svcevents.DECLINE_REASON_TOO_BUSY}
+		event := events.NewTypedEvent(svcevents.TICKET_DECLINED_EVENT_ID, order.ID, payload)
+
+		err := eventHandler.HandleTicketDeclinedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, models.Order{})
+		testutil.AssertEqual(t, publisher.Event.EventID, svcevents.REFUND_REQUESTED_EVENT_ID)
+	})
+
+	t.Run("returns error on TICKET_BEGIN_PREPARING event for unapproved order", func(t *testing.T) {
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{testdata.PeterCreatedOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.TicketBeginPreparingEvent{ID: testdata.PeterCreatedOrder.ID, ReadyBy: time.Now()}
+		event := events.NewTypedEvent(svcevents.TICKET_BEGIN_PREPARING_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketBeginPreparingEvent(event)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+	})
+
+	t.Run("returns error on invalid state transition", func(t *testing.T) {
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{testdata.PeterCompletedOrder}}
+		eventHandler := handlers.NewKitchenEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.TicketCancelEvent{ID: testdata.PeterCompletedOrder.ID}
+		event := events.NewTypedEvent(svcevents.TICKET_CANCEL_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketCancelEvent(event)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+	})
+}
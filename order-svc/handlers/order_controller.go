@@ -3,34 +3,19 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
-	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/order-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/VitoNaychev/food-app/validation"
+	"github.com/VitoNaychev/food-app/workhours"
 )
 
-func VerifyJWT(token string) (authResponse msgtypes.AuthResponse, err error) {
-	request, err := http.NewRequest(http.MethodPost, "http://customer-svc:8080/customer/auth/", nil)
-	if err != nil {
-		return
-	}
-	request.Header.Add("Token", token)
-
-	response, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return
-	}
-
-	err = json.NewDecoder(response.Body).Decode(&authResponse)
-	return
-}
-
 func (o *OrderServer) cancelOrder(w http.ResponseWriter, r *http.Request) {
 	cancelOrderRequest, err := validation.ValidateBody[CancelOrderRequest](r.Body)
 	if err != nil {
@@ -55,15 +40,23 @@ func (o *OrderServer) cancelOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if order.Status != models.APPROVAL_PENDING && order.Status != models.APPROVED {
+	err = o.inTx(r.Context(), func(o *OrderServer) error {
+		err := o.orderStore.CancelOrder(cancelOrderRequest.ID)
+		if err != nil {
+			return err
+		}
+
+		payload := svcevents.OrderCanceledEvent{ID: order.ID}
+		event := svcevents.OrderCanceled.New(order.ID, payload).WithTraceContext(r.Context())
+
+		return o.publisher.Publish(svcevents.ORDER_EVENTS_TOPIC, event)
+	})
+	if errors.Is(err, models.ErrOrderNotCancelable) {
 		cancelOrderResponse := CancelOrderResponse{Status: false}
 		json.NewEncoder(w).Encode(cancelOrderResponse)
 		return
-	}
-
-	err = o.orderStore.CancelOrder(cancelOrderRequest.ID)
-	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+	} else if err != nil {
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
@@ -80,53 +73,133 @@ func (o *OrderServer) createOrder(w http.ResponseWriter, r *http.Request) {
 
 	customerID, _ := strconv.Atoi(r.Header["Subject"][0])
 
-	order := CreateOrderRequestToOrder(createOrderRequest, customerID)
-	pickupAddress := GetPickupAddressFromCreateOrderRequest(createOrderRequest)
-	deliveryAddress := GetDeliveryAddressFromCreateOrderRequest(createOrderRequest)
-
-	err = o.addressStore.CreateAddress(&pickupAddress)
+	restaurant, err := o.restaurantStore.GetRestaurantByID(createOrderRequest.RestaurantID)
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		if errors.Is(err, storeerrors.ErrNotFound) {
+			httperrors.WriteJSONError(w, http.StatusBadRequest, ErrRestaurantNotFound)
+		} else {
+			httperrors.HandleInternalServerError(w, err)
+		}
+		return
+	}
+
+	if restaurant.Status != models.RESTAURANT_VALID {
+		httperrors.WriteJSONError(w, http.StatusBadRequest, ErrRestaurantNotValid)
 		return
 	}
-	err = o.addressStore.CreateAddress(&deliveryAddress)
+
+	isOpen, err := o.isRestaurantOpen(restaurant, time.Now())
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
-	order.PickupAddress = pickupAddress.ID
-	order.DeliveryAddress = deliveryAddress.ID
+	if !isOpen {
+		httperrors.WriteJSONError(w, http.StatusBadRequest, ErrRestaurantClosed)
+		return
+	}
 
-	order.Status = models.APPROVAL_PENDING
-	err = o.orderStore.CreateOrder(&order)
+	restaurantAddress, err := o.restaurantAddressStore.GetRestaurantAddressByRestaurantID(createOrderRequest.RestaurantID)
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
 	orderItems := GetOrderItemsFromCreateOrderRequest(createOrderRequest)
-	for i := range orderItems {
-		orderItems[i].OrderID = order.ID
-
-		err = o.orderItemStore.CreateOrderItem(&orderItems[i])
-		if err != nil {
+	total, err := o.calculateTotal(createOrderRequest.RestaurantID, orderItems)
+	if err != nil {
+		if errors.Is(err, ErrMenuItemNotFound) || errors.Is(err, ErrMenuItemNotInRestaurant) {
+			httperrors.WriteJSONError(w, http.StatusBadRequest, err)
+		} else {
 			httperrors.HandleInternalServerError(w, err)
-			return
 		}
+		return
 	}
 
-	orderResponse := NewOrderResponseBody(order, orderItems, pickupAddress, deliveryAddress)
+	order := CreateOrderRequestToOrder(createOrderRequest, customerID)
+	order.Total = total
+	pickupAddress := RestaurantAddressToAddress(restaurantAddress)
+	deliveryAddress := GetDeliveryAddressFromCreateOrderRequest(createOrderRequest)
 
-	json.NewEncoder(w).Encode(orderResponse)
+	err = o.inTx(r.Context(), func(o *OrderServer) error {
+		err := o.addressStore.CreateAddress(&pickupAddress)
+		if err != nil {
+			return err
+		}
+		err = o.addressStore.CreateAddress(&deliveryAddress)
+		if err != nil {
+			return err
+		}
+
+		order.PickupAddress = pickupAddress.ID
+		order.DeliveryAddress = deliveryAddress.ID
+
+		order.Status = models.APPROVAL_PENDING
+		err = o.orderStore.CreateOrder(&order)
+		if err != nil {
+			return err
+		}
+
+		for i := range orderItems {
+			orderItems[i].OrderID = order.ID
 
-	payload := NewOrderCreatedEvent(order, orderItems, pickupAddress, deliveryAddress)
-	event := events.NewEvent(svcevents.ORDER_CREATED_EVENT_ID, order.ID, payload)
+			err = o.orderItemStore.CreateOrderItem(&orderItems[i])
+			if err != nil {
+				return err
+			}
+		}
 
-	err = o.publisher.Publish(svcevents.ORDER_EVENTS_TOPIC, event)
+		payload := NewOrderCreatedEvent(order, orderItems, pickupAddress, deliveryAddress)
+		event := svcevents.OrderCreated.New(order.ID, payload).WithTraceContext(r.Context())
+
+		return o.publisher.Publish(svcevents.ORDER_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
+		return
 	}
+
+	orderResponse := NewOrderResponseBody(order, orderItems, pickupAddress, deliveryAddress)
+	json.NewEncoder(w).Encode(orderResponse)
+}
+
+func (o *OrderServer) isRestaurantOpen(restaurant models.Restaurant, now time.Time) (bool, error) {
+	restaurantHours, err := o.restaurantHoursStore.GetRestaurantHoursByRestaurantID(restaurant.ID)
+	if err != nil {
+		return false, err
+	}
+
+	week := []workhours.Hours{}
+	for _, hours := range restaurantHours {
+		week = append(week, workhours.Hours{
+			Day:     hours.Day,
+			Opening: hours.Opening,
+			Closing: hours.Closing,
+		})
+	}
+
+	return workhours.IsOpenIn(week, restaurant.Timezone, now)
+}
+
+func (o *OrderServer) calculateTotal(restaurantID int, orderItems []models.OrderItem) (float32, error) {
+	var total float64
+	for _, orderItem := range orderItems {
+		menuItem, err := o.menuItemStore.GetMenuItemByID(orderItem.MenuItemID)
+		if err != nil {
+			if errors.Is(err, storeerrors.ErrNotFound) {
+				return 0, ErrMenuItemNotFound
+			}
+			return 0, err
+		}
+
+		if menuItem.RestaurantID != restaurantID {
+			return 0, ErrMenuItemNotInRestaurant
+		}
+
+		total += float64(menuItem.Price) * float64(orderItem.Quantity)
+	}
+
+	return float32(math.Round(total*100) / 100), nil
 }
 
 func (o *OrderServer) getAllOrders(w http.ResponseWriter, r *http.Request) {
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
 )
 
 type OrderServer struct {
@@ -13,15 +16,24 @@ type OrderServer struct {
 	orderItemStore models.OrderItemStore
 	addressStore   models.AddressStore
 
+	restaurantStore        models.RestaurantStore
+	menuItemStore          models.MenuItemStore
+	restaurantAddressStore models.RestaurantAddressStore
+	restaurantHoursStore   models.RestaurantHoursStore
+
 	publisher events.EventPublisher
 
 	verifyJWT auth.VerifyJWTFunc
-	http.Handler
+	*http.ServeMux
 }
 
 func NewOrderServer(orderStore models.OrderStore,
 	orderItemsStore models.OrderItemStore,
 	addressStore models.AddressStore,
+	restaurantStore models.RestaurantStore,
+	menuItemStore models.MenuItemStore,
+	restaurantAddressStore models.RestaurantAddressStore,
+	restaurantHoursStore models.RestaurantHoursStore,
 	publisher events.EventPublisher,
 	verifyJWT auth.VerifyJWTFunc) OrderServer {
 
@@ -30,6 +42,11 @@ func NewOrderServer(orderStore models.OrderStore,
 		orderItemStore: orderItemsStore,
 		addressStore:   addressStore,
 
+		restaurantStore:        restaurantStore,
+		menuItemStore:          menuItemStore,
+		restaurantAddressStore: restaurantAddressStore,
+		restaurantHoursStore:   restaurantHoursStore,
+
 		publisher: publisher,
 
 		verifyJWT: verifyJWT,
@@ -37,12 +54,27 @@ func NewOrderServer(orderStore models.OrderStore,
 
 	router := http.NewServeMux()
 
-	router.Handle("/order/all/", auth.RemoteAuthenticationMW(server.getAllOrders, verifyJWT))
-	router.Handle("/order/current/", auth.RemoteAuthenticationMW(server.getCurrentOrders, verifyJWT))
-	router.Handle("/order/new/", auth.RemoteAuthenticationMW(server.createOrder, verifyJWT))
-	router.Handle("/order/cancel/", auth.RemoteAuthenticationMW(server.cancelOrder, verifyJWT))
+	router.Handle("/order/all/", auth.RemoteAuthenticationMW(server.getAllOrders, verifyJWT, auth.CUSTOMER))
+	router.Handle("/order/current/", auth.RemoteAuthenticationMW(server.getCurrentOrders, verifyJWT, auth.CUSTOMER))
+	router.Handle("/order/new/", auth.RemoteAuthenticationMW(server.createOrder, verifyJWT, auth.CUSTOMER))
+	router.Handle("/order/cancel/", auth.RemoteAuthenticationMW(server.cancelOrder, verifyJWT, auth.CUSTOMER))
 
-	server.Handler = router
+	server.ServeMux = router
 
 	return server
 }
+
+// inTx runs fn with a copy of the server whose stores and publisher write
+// through a single transaction, so an order and its events are committed
+// together.
+func (o *OrderServer) inTx(ctx context.Context, fn func(o *OrderServer) error) error {
+	return pgconfig.RunInTx(ctx, o.orderStore, func(tx pgx.Tx) error {
+		txServer := *o
+		txServer.orderStore = pgconfig.WithTx(o.orderStore, tx)
+		txServer.orderItemStore = pgconfig.WithTx(o.orderItemStore, tx)
+		txServer.addressStore = pgconfig.WithTx(o.addressStore, tx)
+		txServer.publisher = pgconfig.WithTx(o.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
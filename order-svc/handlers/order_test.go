@@ -24,9 +24,22 @@ func TestOrderEndpointAuthentication(t *testing.T) {
 	orderItemStore := &stubs.StubOrderItemStore{}
 	addressStore := &stubs.StubAddressStore{}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	invalidJWT := "invalidJWT"
 	cases := map[string]*http.Request{
@@ -44,9 +57,22 @@ func TestOrderRequestValidation(t *testing.T) {
 	orderItemStore := &stubs.StubOrderItemStore{}
 	addressStore := &stubs.StubAddressStore{}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	peterJWT := strconv.Itoa(testdata.PeterCustomerID)
 
@@ -69,12 +95,25 @@ func TestOrderResponseValidity(t *testing.T) {
 		Addresses: []models.Address{testdata.ChickenShackAddress, testdata.PeterAddress1, testdata.PeterAddress2},
 	}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	peterJWT := strconv.Itoa(testdata.PeterCustomerID)
-	createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.ChickenShackAddress, testdata.PeterAddress1)
+	createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
 	cancelOrderRequestBody := handlers.CancelOrderRequest{ID: testdata.PeterCreatedOrder.ID}
 
 	cases := []tabletests.ResponseValidationTestcase{
@@ -126,9 +165,22 @@ func TestCancelOrder(t *testing.T) {
 		Addresses: []models.Address{testdata.ChickenShackAddress, testdata.PeterAddress1, testdata.PeterAddress2},
 	}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	t.Run("return Unauthorized on attemp to cancel another user's order", func(t *testing.T) {
 		cancelOrderRequestBody := handlers.CancelOrderRequest{ID: 1}
@@ -158,6 +210,11 @@ func TestCancelOrder(t *testing.T) {
 		json.NewDecoder(response.Body).Decode(&got)
 
 		testutil.AssertEqual(t, got, want)
+
+		wantEvent := events.NewEvent(svcevents.ORDER_CANCELED_EVENT_ID, testdata.PeterCreatedOrder.ID,
+			svcevents.OrderCanceledEvent{ID: testdata.PeterCreatedOrder.ID})
+		testutil.AssertEqual(t, publisher.Topic, svcevents.ORDER_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.Event, wantEvent)
 	})
 
 	t.Run("returns Status false when order is noncancelable", func(t *testing.T) {
@@ -196,12 +253,25 @@ func TestCreateOrder(t *testing.T) {
 	orderItemStore := &stubs.StubOrderItemStore{CreatedOrderItems: []models.OrderItem{}, OrderItems: nil}
 	addressStore := &stubs.StubAddressStore{CreatedAddresses: []models.Address{}, Addresses: nil}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	t.Run("creates new order and returns it", func(t *testing.T) {
-		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.ChickenShackAddress, testdata.PeterAddress1)
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
 		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
 		response := httptest.NewRecorder()
 
@@ -243,6 +313,128 @@ func TestCreateOrder(t *testing.T) {
 		testutil.AssertEvent(t, gotEvent, wantEvent)
 		testutil.AssertEqual(t, publisher.Topic, svcevents.ORDER_EVENTS_TOPIC)
 	})
+
+	t.Run("computes total from menu item prices", func(t *testing.T) {
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCompletedOrder, testdata.PeterCompletedOrderItems, testdata.PeterAddress2)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		var got handlers.OrderResponse
+		json.NewDecoder(response.Body).Decode(&got)
+
+		testutil.AssertEqual(t, got.Total, testdata.PeterCompletedOrder.Total)
+	})
+
+	t.Run("derives pickup address from restaurant", func(t *testing.T) {
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		var got handlers.OrderResponse
+		json.NewDecoder(response.Body).Decode(&got)
+
+		want := handlers.AddressToAddressResponse(testdata.ChickenShackAddress)
+		want.Id = got.PickupAddress.Id
+		testutil.AssertEqual(t, got.PickupAddress, want)
+	})
+
+	t.Run("returns Bad Request on restaurant that doesn't exist", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.RestaurantID = 10
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(order, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrRestaurantNotFound)
+	})
+
+	t.Run("returns Bad Request on restaurant that isn't valid", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.RestaurantID = testdata.DominosRestaurant.ID
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(order, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrRestaurantNotValid)
+	})
+
+	t.Run("returns Bad Request on restaurant that is closed", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.RestaurantID = testdata.BurgerBarRestaurant.ID
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(order, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrRestaurantClosed)
+	})
+
+	t.Run("returns Bad Request on menu item that doesn't exist", func(t *testing.T) {
+		orderItems := []models.OrderItem{{MenuItemID: 10, Quantity: 1}}
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, orderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrMenuItemNotFound)
+	})
+
+	t.Run("returns Bad Request on menu item from another restaurant", func(t *testing.T) {
+		orderItems := []models.OrderItem{{MenuItemID: testdata.OtherRestaurantMenuItem.ID, Quantity: 1}}
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, orderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrMenuItemNotInRestaurant)
+	})
+
+	t.Run("returns Bad Request on negative quantity", func(t *testing.T) {
+		orderItems := []models.OrderItem{{MenuItemID: testdata.ChickenShackMenuItems[0].ID, Quantity: -1000}}
+
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, orderItems, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns Bad Request on order without items", func(t *testing.T) {
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, []models.OrderItem{}, testdata.PeterAddress1)
+		request := handlers.NewCreateOrderRequest(strconv.Itoa(testdata.PeterCustomerID), createOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
 }
 
 func TestGetCurrentOrders(t *testing.T) {
@@ -259,9 +451,22 @@ func TestGetCurrentOrders(t *testing.T) {
 		Addresses:        []models.Address{testdata.ChickenShackAddress, testdata.PeterAddress1, testdata.PeterAddress2, testdata.AliceAddress},
 	}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	t.Run("returns current orders for customer Peter", func(t *testing.T) {
 		request := handlers.NewGetCurrentOrdersRequest(strconv.Itoa(testdata.PeterCustomerID))
@@ -303,9 +508,22 @@ func TestGetOrders(t *testing.T) {
 		Addresses:        []models.Address{testdata.ChickenShackAddress, testdata.PeterAddress1, testdata.PeterAddress2, testdata.AliceAddress},
 	}
 
+	restaurantStore := &stubs.StubRestaurantStore{
+		Restaurants: []models.Restaurant{testdata.ChickenShackRestaurant, testdata.DominosRestaurant, testdata.BurgerBarRestaurant},
+	}
+	menuItemStore := &stubs.StubMenuItemStore{
+		MenuItems: append(testdata.ChickenShackMenuItems, testdata.OtherRestaurantMenuItem),
+	}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{
+		RestaurantAddresses: []models.RestaurantAddress{testdata.ChickenShackRestaurantAddress, testdata.DominosRestaurantAddress, testdata.BurgerBarRestaurantAddress},
+	}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{
+		Hours: testdata.ChickenShackRestaurantHours,
+	}
+
 	publisher := &stubs.StubEventPublisher{}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, publisher, stubs.StubVerifyJWT)
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, publisher, stubs.StubVerifyJWT)
 
 	t.Run("returns orders of customer Peter", func(t *testing.T) {
 		request := handlers.NewGetAllOrdersRequest(strconv.Itoa(testdata.PeterCustomerID))
@@ -16,6 +16,7 @@ func NewOrderCreatedEvent(order models.Order, orderItems []models.OrderItem, pic
 
 	orderCreatedEvent := svcevents.OrderCreatedEvent{
 		ID:              order.ID,
+		CustomerID:      order.CustomerID,
 		RestaurantID:    order.RestaurantID,
 		Items:           orderCreatedEventItem,
 		Total:           order.Total,
@@ -129,23 +130,12 @@ func AddressToAddressResponse(address models.Address) AddressResponse {
 }
 
 type CreateOrderRequest struct {
-	RestaurantID    int                `validate:"min=1"    json:"restaurant_id"`
-	Items           []CreateOrderItem  `validate:"required" json:"items"`
-	Total           float32            `validate:"min=0.01" json:"total"`
-	PickupAddress   CreateOrderAddress `validate:"required" json:"pickup_address"`
-	DeliveryAddress CreateOrderAddress `validate:"required" json:"delivery_address"`
+	RestaurantID    int                `validate:"min=1"                json:"restaurant_id"`
+	Items           []CreateOrderItem  `validate:"required,min=1,dive" json:"items"`
+	DeliveryAddress CreateOrderAddress `validate:"required"            json:"delivery_address"`
 }
 
-func NewCeateOrderRequestBody(order models.Order, orderItems []models.OrderItem, pickupAddress models.Address, deliveryAddress models.Address) CreateOrderRequest {
-	createPickupAddress := CreateOrderAddress{
-		Lat:          pickupAddress.Lat,
-		Lon:          pickupAddress.Lon,
-		AddressLine1: pickupAddress.AddressLine1,
-		AddressLine2: pickupAddress.AddressLine2,
-		City:         pickupAddress.City,
-		Country:      pickupAddress.Country,
-	}
-
+func NewCeateOrderRequestBody(order models.Order, orderItems []models.OrderItem, deliveryAddress models.Address) CreateOrderRequest {
 	createDeliveryAddress := CreateOrderAddress{
 		Lat:          deliveryAddress.Lat,
 		Lon:          deliveryAddress.Lon,
@@ -167,8 +157,6 @@ func NewCeateOrderRequestBody(order models.Order, orderItems []models.OrderItem,
 	createOrderRequest := CreateOrderRequest{
 		RestaurantID:    order.RestaurantID,
 		Items:           createOrderItems,
-		Total:           order.Total,
-		PickupAddress:   createPickupAddress,
 		DeliveryAddress: createDeliveryAddress,
 	}
 
@@ -180,7 +168,7 @@ func CreateOrderRequestToOrder(createOrderRequest CreateOrderRequest, customerID
 		ID:              0,
 		CustomerID:      customerID,
 		RestaurantID:    createOrderRequest.RestaurantID,
-		Total:           createOrderRequest.Total,
+		Total:           0,
 		PickupAddress:   -1,
 		DeliveryAddress: -1,
 	}
@@ -212,8 +200,18 @@ func CreateOrderItemToOrderItem(createOrderItem CreateOrderItem) models.OrderIte
 	return orderItem
 }
 
-func GetPickupAddressFromCreateOrderRequest(createOrderRequest CreateOrderRequest) models.Address {
-	return CreateOrderAddressToAddress(createOrderRequest.PickupAddress)
+func RestaurantAddressToAddress(restaurantAddress models.RestaurantAddress) models.Address {
+	address := models.Address{
+		ID:           0,
+		Lat:          restaurantAddress.Lat,
+		Lon:          restaurantAddress.Lon,
+		AddressLine1: restaurantAddress.AddressLine1,
+		AddressLine2: restaurantAddress.AddressLine2,
+		City:         restaurantAddress.City,
+		Country:      restaurantAddress.Country,
+	}
+
+	return address
 }
 
 func GetDeliveryAddressFromCreateOrderRequest(createOrderRequest CreateOrderRequest) models.Address {
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type PaymentEventHandler struct {
+	orderStore models.OrderStore
+	publisher  events.EventPublisher
+}
+
+func NewPaymentEventHandler(orderStore models.OrderStore, publisher events.EventPublisher) *PaymentEventHandler {
+	paymentEventHandler := PaymentEventHandler{
+		orderStore: orderStore,
+		publisher:  publisher,
+	}
+
+	return &paymentEventHandler
+}
+
+func RegisterPaymentEventHandlers(eventConsumer events.EventConsumer, paymentEventHandler *PaymentEventHandler) error {
+	return svcevents.PaymentAuthorizationFailed.Register(eventConsumer, paymentEventHandler.HandlePaymentAuthorizationFailedEvent)
+}
+
+// An order that can't be paid for is canceled, which also cancels its ticket
+// and delivery before the kitchen starts preparing it.
+func (p *PaymentEventHandler) HandlePaymentAuthorizationFailedEvent(event events.Event[svcevents.PaymentAuthorizationFailedEvent]) error {
+	return p.inTx(event.Context(), func(p *PaymentEventHandler) error {
+		order, err := p.orderStore.GetOrderByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == models.CANCELED || order.Status == models.REJECTED {
+			return nil
+		}
+
+		err = applyEventAndUpdateOrder(p.orderStore, order.ID, models.CANCEL_ORDER)
+		if err != nil {
+			return err
+		}
+
+		payload := svcevents.OrderCanceledEvent{ID: order.ID}
+		outEvent := svcevents.OrderCanceled.New(order.ID, payload).CausedBy(event.Headers)
+
+		return p.publisher.Publish(svcevents.ORDER_EVENTS_TOPIC, outEvent)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (p *PaymentEventHandler) inTx(ctx context.Context, fn func(p *PaymentEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, p.orderStore, func(tx pgx.Tx) error {
+		txHandler := *p
+		txHandler.orderStore = pgconfig.WithTx(p.orderStore, tx)
+		txHandler.publisher = pgconfig.WithTx(p.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/handlers"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/order-svc/stubs"
+	"github.com/VitoNaychev/food-app/order-svc/testdata"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestPaymentEventHandler(t *testing.T) {
+	t.Run("cancels order on PAYMENT_AUTHORIZATION_FAILED event", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.APPROVED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewPaymentEventHandler(orderStore, publisher)
+
+		want := order
+		want.Status = models.CANCELED
+
+		payload := svcevents.PaymentAuthorizationFailedEvent{ID: order.ID, Reason: "payment was declined by the gateway"}
+		event := events.NewTypedEvent(svcevents.PAYMENT_AUTHORIZATION_FAILED_EVENT_ID, order.ID, payload)
+
+		err := eventHandler.HandlePaymentAuthorizationFailedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, orderStore.UpdatedOrder, want)
+
+		wantEvent := events.NewEvent(svcevents.ORDER_CANCELED_EVENT_ID, order.ID, svcevents.OrderCanceledEvent{ID: order.ID})
+		testutil.AssertEqual(t, publisher.Topic, svcevents.ORDER_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.Event, wantEvent)
+	})
+
+	t.Run("ignores PAYMENT_AUTHORIZATION_FAILED event for rejected order", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.REJECTED
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewPaymentEventHandler(orderStore, publisher)
+
+		payload := svcevents.PaymentAuthorizationFailedEvent{ID: order.ID}
+		event := events.NewTypedEvent(svcevents.PAYMENT_AUTHORIZATION_FAILED_EVENT_ID, order.ID, payload)
+
+		err := eventHandler.HandlePaymentAuthorizationFailedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, publisher.Topic, "")
+	})
+
+	t.Run("returns error on order that is already being prepared", func(t *testing.T) {
+		order := testdata.PeterCreatedOrder
+		order.Status = models.PREPARING
+
+		orderStore := &stubs.StubOrderStore{Orders: []models.Order{order}}
+		eventHandler := handlers.NewPaymentEventHandler(orderStore, &stubs.StubEventPublisher{})
+
+		payload := svcevents.PaymentAuthorizationFailedEvent{ID: order.ID}
+		event := events.NewTypedEvent(svcevents.PAYMENT_AUTHORIZATION_FAILED_EVENT_ID, order.ID, payload)
+
+		err := eventHandler.HandlePaymentAuthorizationFailedEvent(event)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+	})
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type RestaurantEventHandler struct {
+	restaurantStore        models.RestaurantStore
+	menuItemStore          models.MenuItemStore
+	restaurantAddressStore models.RestaurantAddressStore
+	restaurantHoursStore   models.RestaurantHoursStore
+}
+
+func NewRestaurantEventHandler(restaurantStore models.RestaurantStore, menuItemStore models.MenuItemStore,
+	restaurantAddressStore models.RestaurantAddressStore, restaurantHoursStore models.RestaurantHoursStore) *RestaurantEventHandler {
+	restaurantEventHandler := RestaurantEventHandler{
+		restaurantStore:        restaurantStore,
+		menuItemStore:          menuItemStore,
+		restaurantAddressStore: restaurantAddressStore,
+		restaurantHoursStore:   restaurantHoursStore,
+	}
+
+	return &restaurantEventHandler
+}
+
+func RegisterRestaurantEventHandlers(eventConsumer events.EventConsumer, restaurantEventHandler *RestaurantEventHandler) error {
+	err := svcevents.RestaurantCreated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantUpdated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantUpdatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantDeleted.Register(eventConsumer, restaurantEventHandler.HandleRestaurantDeletedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.MenuItemCreated.Register(eventConsumer, restaurantEventHandler.HandleMenuItemCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.MenuItemUpdated.Register(eventConsumer, restaurantEventHandler.HandleMenuItemUpdatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.MenuItemDeleted.Register(eventConsumer, restaurantEventHandler.HandleMenuItemDeletedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantAddressCreated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantAddressCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantAddressUpdated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantAddressUpdatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.RestaurantHoursSet.Register(eventConsumer, restaurantEventHandler.HandleRestaurantHoursSetEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.RestaurantStatusUpdated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantStatusUpdatedEvent)
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantCreatedEvent(event events.Event[events.RestaurantCreatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		restaurant := models.Restaurant{ID: event.Payload.ID}
+		err := r.restaurantStore.CreateRestaurant(&restaurant)
+		return err
+	})
+}
+
+// The timezone is the only updated field order-svc keeps.
+func (r *RestaurantEventHandler) HandleRestaurantUpdatedEvent(event events.Event[events.RestaurantUpdatedEvent]) error {
+	if event.Payload.Timezone == "" {
+		return nil
+	}
+
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		restaurant, err := r.restaurantStore.GetRestaurantByID(event.Payload.ID)
+		if errors.Is(err, storeerrors.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		restaurant.Timezone = event.Payload.Timezone
+		err = r.restaurantStore.UpdateRestaurant(&restaurant)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantDeletedEvent(event events.Event[events.RestaurantDeletedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		err := r.menuItemStore.DeleteMenuItemWhereRestaurantID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		err = r.restaurantAddressStore.DeleteRestaurantAddressWhereRestaurantID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		err = r.restaurantHoursStore.DeleteRestaurantHoursWhereRestaurantID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		err = r.restaurantStore.DeleteRestaurant(event.Payload.ID)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleMenuItemCreatedEvent(event events.Event[events.MenuItemCreatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		menuItem := models.MenuItem{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Name:         event.Payload.Name,
+			Price:        event.Payload.Price,
+		}
+		err := r.menuItemStore.CreateMenuItem(&menuItem)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleMenuItemDeletedEvent(event events.Event[events.MenuItemDeletedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		err := r.menuItemStore.DeleteMenuItem(event.Payload.ID)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleMenuItemUpdatedEvent(event events.Event[events.MenuItemUpdatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		menuItem := models.MenuItem{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Name:         event.Payload.Name,
+			Price:        event.Payload.Price,
+		}
+		err := r.menuItemStore.UpdateMenuItem(&menuItem)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantAddressCreatedEvent(event events.Event[events.RestaurantAddressCreatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		address := models.RestaurantAddress{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Lat:          event.Payload.Lat,
+			Lon:          event.Payload.Lon,
+			AddressLine1: event.Payload.AddressLine1,
+			AddressLine2: event.Payload.AddressLine2,
+			City:         event.Payload.City,
+			Country:      event.Payload.Country,
+		}
+		err := r.restaurantAddressStore.CreateRestaurantAddress(&address)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantAddressUpdatedEvent(event events.Event[events.RestaurantAddressUpdatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		address := models.RestaurantAddress{
+			ID:           event.Payload.ID,
+			RestaurantID: event.Payload.RestaurantID,
+			Lat:          event.Payload.Lat,
+			Lon:          event.Payload.Lon,
+			AddressLine1: event.Payload.AddressLine1,
+			AddressLine2: event.Payload.AddressLine2,
+			City:         event.Payload.City,
+			Country:      event.Payload.Country,
+		}
+		err := r.restaurantAddressStore.UpdateRestaurantAddress(&address)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantHoursSetEvent(event events.Event[events.RestaurantHoursSetEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		restaurant, err := r.restaurantStore.GetRestaurantByID(event.Payload.RestaurantID)
+		if err != nil {
+			return err
+		}
+
+		hours := []models.RestaurantHours{}
+		for _, day := range event.Payload.Hours {
+			opening, err := time.Parse("15:04", day.Opening)
+			if err != nil {
+				return err
+			}
+
+			closing, err := time.Parse("15:04", day.Closing)
+			if err != nil {
+				return err
+			}
+
+			hours = append(hours, models.RestaurantHours{
+				RestaurantID: event.Payload.RestaurantID,
+				Day:          day.Day,
+				Opening:      opening,
+				Closing:      closing,
+			})
+		}
+
+		err = r.restaurantHoursStore.SetRestaurantHours(event.Payload.RestaurantID, hours)
+		if err != nil {
+			return err
+		}
+
+		restaurant.Timezone = event.Payload.Timezone
+		err = r.restaurantStore.UpdateRestaurant(&restaurant)
+		return err
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantStatusUpdatedEvent(event events.Event[events.RestaurantStatusUpdatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		restaurant, err := r.restaurantStore.GetRestaurantByID(event.Payload.ID)
+		if err != nil {
+			return err
+		}
+
+		restaurant.Status = models.RestaurantStatus(event.Payload.Status)
+		err = r.restaurantStore.UpdateRestaurant(&restaurant)
+		return err
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (r *RestaurantEventHandler) inTx(ctx context.Context, fn func(r *RestaurantEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, r.restaurantStore, func(tx pgx.Tx) error {
+		txHandler := *r
+		txHandler.restaurantStore = pgconfig.WithTx(r.restaurantStore, tx)
+		txHandler.menuItemStore = pgconfig.WithTx(r.menuItemStore, tx)
+		txHandler.restaurantAddressStore = pgconfig.WithTx(r.restaurantAddressStore, tx)
+		txHandler.restaurantHoursStore = pgconfig.WithTx(r.restaurantHoursStore, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -0,0 +1,210 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/order-svc/handlers"
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/order-svc/stubs"
+	"github.com/VitoNaychev/food-app/order-svc/testdata"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestRestaurantEventHandler(t *testing.T) {
+	restaurantStore := &stubs.StubRestaurantStore{}
+	menuItemStore := &stubs.StubMenuItemStore{}
+	restaurantAddressStore := &stubs.StubRestaurantAddressStore{}
+	restaurantHoursStore := &stubs.StubRestaurantHoursStore{}
+	restaurantEventHandler := handlers.NewRestaurantEventHandler(restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore)
+
+	t.Run("creates restaurant on RESTAURANT_CREATED_EVENT", func(t *testing.T) {
+		payload := events.RestaurantCreatedEvent{ID: testdata.ChickenShackRestaurant.ID}
+		event := events.NewTypedEvent(events.RESTAURANT_CREATED_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantStore.CreatedRestaurant, models.Restaurant{ID: testdata.ChickenShackRestaurant.ID})
+	})
+
+	t.Run("updates restaurant timezone on RESTAURANT_UPDATED_EVENT", func(t *testing.T) {
+		restaurantStore.Restaurants = []models.Restaurant{testdata.ChickenShackRestaurant}
+
+		payload := events.RestaurantUpdatedEvent{
+			ID:       testdata.ChickenShackRestaurant.ID,
+			Name:     "Chicken Shack",
+			IBAN:     "DE89370400440532013000",
+			Timezone: "Europe/Berlin",
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_UPDATED_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		want := testdata.ChickenShackRestaurant
+		want.Timezone = "Europe/Berlin"
+		testutil.AssertEqual(t, restaurantStore.UpdatedRestaurant, want)
+	})
+
+	t.Run("ignores RESTAURANT_UPDATED_EVENT of unknown restaurant", func(t *testing.T) {
+		restaurantStore.Restaurants = nil
+		restaurantStore.UpdatedRestaurant = models.Restaurant{}
+
+		payload := events.RestaurantUpdatedEvent{
+			ID:       testdata.ChickenShackRestaurant.ID,
+			Timezone: "Europe/Berlin",
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_UPDATED_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantStore.UpdatedRestaurant, models.Restaurant{})
+	})
+
+	t.Run("deletes restaurant, its menu items and address on RESTAURANT_DELETED_EVENT", func(t *testing.T) {
+		payload := events.RestaurantDeletedEvent{ID: testdata.ChickenShackRestaurant.ID}
+		event := events.NewTypedEvent(events.RESTAURANT_DELETED_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantDeletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantStore.DeletedRestaurantID, testdata.ChickenShackRestaurant.ID)
+		testutil.AssertEqual(t, menuItemStore.DeletedItemsRestaurantID, testdata.ChickenShackRestaurant.ID)
+		testutil.AssertEqual(t, restaurantAddressStore.DeletedAddressRestaurant, testdata.ChickenShackRestaurant.ID)
+		testutil.AssertEqual(t, restaurantHoursStore.DeletedHoursRestaurant, testdata.ChickenShackRestaurant.ID)
+	})
+
+	t.Run("creates menu item on MENU_ITEM_CREATED_EVENT", func(t *testing.T) {
+		menuItem := testdata.ChickenShackMenuItems[0]
+		payload := events.MenuItemCreatedEvent{
+			ID:           menuItem.ID,
+			RestaurantID: menuItem.RestaurantID,
+			Name:         menuItem.Name,
+			Price:        menuItem.Price,
+		}
+		event := events.NewTypedEvent(events.MENU_ITEM_CREATED_EVENT_ID, menuItem.RestaurantID, payload)
+
+		err := restaurantEventHandler.HandleMenuItemCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, menuItemStore.CreatedMenuItem, menuItem)
+	})
+
+	t.Run("updates menu item on MENU_ITEM_UPDATED_EVENT", func(t *testing.T) {
+		menuItem := testdata.ChickenShackMenuItems[0]
+		menuItem.Price = 6.20
+		payload := events.MenuItemUpdatedEvent{
+			ID:           menuItem.ID,
+			RestaurantID: menuItem.RestaurantID,
+			Name:         menuItem.Name,
+			Price:        menuItem.Price,
+		}
+		event := events.NewTypedEvent(events.MENU_ITEM_UPDATED_EVENT_ID, menuItem.RestaurantID, payload)
+
+		err := restaurantEventHandler.HandleMenuItemUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, menuItemStore.UpdatedMenuItem, menuItem)
+	})
+
+	t.Run("deletes menu item on MENU_ITEM_DELETED_EVENT", func(t *testing.T) {
+		menuItem := testdata.ChickenShackMenuItems[0]
+		payload := events.MenuItemDeletedEvent{ID: menuItem.ID}
+		event := events.NewTypedEvent(events.MENU_ITEM_DELETED_EVENT_ID, menuItem.RestaurantID, payload)
+
+		err := restaurantEventHandler.HandleMenuItemDeletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, menuItemStore.DeletedMenuItemID, menuItem.ID)
+	})
+
+	t.Run("creates restaurant address on RESTAURANT_ADDRESS_CREATED_EVENT", func(t *testing.T) {
+		address := testdata.ChickenShackRestaurantAddress
+		payload := events.RestaurantAddressCreatedEvent{
+			ID:           address.ID,
+			RestaurantID: address.RestaurantID,
+			Lat:          address.Lat,
+			Lon:          address.Lon,
+			AddressLine1: address.AddressLine1,
+			AddressLine2: address.AddressLine2,
+			City:         address.City,
+			Country:      address.Country,
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_ADDRESS_CREATED_EVENT_ID, address.RestaurantID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantAddressCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantAddressStore.CreatedAddress, address)
+	})
+
+	t.Run("updates restaurant address on RESTAURANT_ADDRESS_UPDATED_EVENT", func(t *testing.T) {
+		address := testdata.ChickenShackRestaurantAddress
+		address.AddressLine2 = "floor 2"
+		payload := events.RestaurantAddressUpdatedEvent{
+			ID:           address.ID,
+			RestaurantID: address.RestaurantID,
+			Lat:          address.Lat,
+			Lon:          address.Lon,
+			AddressLine1: address.AddressLine1,
+			AddressLine2: address.AddressLine2,
+			City:         address.City,
+			Country:      address.Country,
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_ADDRESS_UPDATED_EVENT_ID, address.RestaurantID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantAddressUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantAddressStore.UpdatedAddress, address)
+	})
+
+	t.Run("sets working hours and timezone on RESTAURANT_HOURS_SET_EVENT", func(t *testing.T) {
+		restaurantStore.Restaurants = []models.Restaurant{{ID: testdata.ChickenShackRestaurant.ID}}
+
+		days := []events.RestaurantHoursSetEventDay{}
+		for _, hours := range testdata.ChickenShackRestaurantHours {
+			days = append(days, events.RestaurantHoursSetEventDay{
+				Day:     hours.Day,
+				Opening: hours.Opening.Format("15:04"),
+				Closing: hours.Closing.Format("15:04"),
+			})
+		}
+		payload := events.RestaurantHoursSetEvent{
+			RestaurantID: testdata.ChickenShackRestaurant.ID,
+			Timezone:     testdata.ChickenShackRestaurant.Timezone,
+			Hours:        days,
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_HOURS_SET_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantHoursSetEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		wantHours := []models.RestaurantHours{}
+		for _, hours := range testdata.ChickenShackRestaurantHours {
+			hours.ID = 0
+			wantHours = append(wantHours, hours)
+		}
+
+		testutil.AssertEqual(t, restaurantHoursStore.SetHoursRestaurantID, testdata.ChickenShackRestaurant.ID)
+		testutil.AssertEqual(t, restaurantHoursStore.SetHours, wantHours)
+		testutil.AssertEqual(t, restaurantStore.UpdatedRestaurant.Timezone, testdata.ChickenShackRestaurant.Timezone)
+	})
+
+	t.Run("updates restaurant status on RESTAURANT_STATUS_UPDATED_EVENT", func(t *testing.T) {
+		restaurantStore.Restaurants = []models.Restaurant{{ID: testdata.ChickenShackRestaurant.ID}}
+
+		payload := events.RestaurantStatusUpdatedEvent{
+			ID:     testdata.ChickenShackRestaurant.ID,
+			Status: int(models.RESTAURANT_VALID),
+		}
+		event := events.NewTypedEvent(events.RESTAURANT_STATUS_UPDATED_EVENT_ID, testdata.ChickenShackRestaurant.ID, payload)
+
+		err := restaurantEventHandler.HandleRestaurantStatusUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, restaurantStore.UpdatedRestaurant.Status, models.RESTAURANT_VALID)
+	})
+}
@@ -39,10 +39,43 @@ func TestOrderServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, &dummies.DummyPublisher{}, stubs.StubVerifyJWT)
+	restaurantStore, err := models.NewPgRestaurantStore(context.Background(), connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	menuItemStore, err := models.NewPgMenuItemStore(context.Background(), connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restaurantAddressStore, err := models.NewPgRestaurantAddressStore(context.Background(), connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restaurantHoursStore, err := models.NewPgRestaurantHoursStore(context.Background(), connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restaurant := testdata.ChickenShackRestaurant
+	testutil.AssertNoErr(t, restaurantStore.CreateRestaurant(&restaurant))
+
+	restaurantAddress := testdata.ChickenShackRestaurantAddress
+	testutil.AssertNoErr(t, restaurantAddressStore.CreateRestaurantAddress(&restaurantAddress))
+
+	err = restaurantHoursStore.SetRestaurantHours(restaurant.ID, testdata.ChickenShackRestaurantHours)
+	testutil.AssertNoErr(t, err)
+
+	for _, menuItem := range testdata.ChickenShackMenuItems {
+		testutil.AssertNoErr(t, menuItemStore.CreateMenuItem(&menuItem))
+	}
+
+	server := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, &dummies.DummyPublisher{}, stubs.StubVerifyJWT)
 
 	peterJWT := strconv.Itoa(testdata.PeterCustomerID)
-	createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.ChickenShackAddress, testdata.PeterAddress1)
+	createOrderRequestBody := handlers.NewCeateOrderRequestBody(testdata.PeterCreatedOrder, testdata.PeterCreatedOrderItems, testdata.PeterAddress1)
 
 	request := handlers.NewCreateOrderRequest(peterJWT, createOrderRequestBody)
 	response := httptest.NewRecorder()
@@ -99,6 +132,20 @@ func TestOrderServerOperations(t *testing.T) {
 		testutil.AssertEqual(t, got, want)
 	})
 
+	t.Run("doesn't cancel order that is no longer cancelable", func(t *testing.T) {
+		cancelOrderRequestBody := handlers.CancelOrderRequest{ID: 1}
+		request := handlers.NewCancelOrderRequest(peterJWT, cancelOrderRequestBody)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		want := handlers.CancelOrderResponse{Status: false}
+		var got handlers.CancelOrderResponse
+		json.NewDecoder(response.Body).Decode(&got)
+
+		testutil.AssertEqual(t, got, want)
+	})
+
 	t.Run("canceled order doesn't show up in current orders", func(t *testing.T) {
 		request := handlers.NewGetCurrentOrdersRequest(peterJWT)
 		response := httptest.NewRecorder()
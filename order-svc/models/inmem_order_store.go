@@ -55,6 +55,10 @@ func (i *InMemoryOrderStore) CreateOrder(order *Order) error {
 func (i *InMemoryOrderStore) CancelOrder(id int) error {
 	for j, order := range i.orders {
 		if order.ID == id {
+			if order.Status != APPROVAL_PENDING && order.Status != APPROVED {
+				return ErrOrderNotCancelable
+			}
+
 			i.orders[j].Status = CANCELED
 			return nil
 		}
@@ -62,3 +66,14 @@ func (i *InMemoryOrderStore) CancelOrder(id int) error {
 
 	return storeerrors.ErrNotFound
 }
+
+func (i *InMemoryOrderStore) UpdateOrderStatus(id int, status Status) error {
+	for j, order := range i.orders {
+		if order.ID == id {
+			i.orders[j].Status = status
+			return nil
+		}
+	}
+
+	return storeerrors.ErrNotFound
+}
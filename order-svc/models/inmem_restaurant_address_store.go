@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type InMemoryRestaurantAddressStore struct {
+	addresses []RestaurantAddress
+}
+
+func NewInMemoryRestaurantAddressStore() *InMemoryRestaurantAddressStore {
+	return &InMemoryRestaurantAddressStore{[]RestaurantAddress{}}
+}
+
+func (i *InMemoryRestaurantAddressStore) GetRestaurantAddressByRestaurantID(restaurantID int) (RestaurantAddress, error) {
+	for _, address := range i.addresses {
+		if address.RestaurantID == restaurantID {
+			return address, nil
+		}
+	}
+	return RestaurantAddress{}, storeerrors.ErrNotFound
+}
+
+func (i *InMemoryRestaurantAddressStore) CreateRestaurantAddress(address *RestaurantAddress) error {
+	i.addresses = append(i.addresses, *address)
+
+	return nil
+}
+
+func (i *InMemoryRestaurantAddressStore) UpdateRestaurantAddress(updatedAddress *RestaurantAddress) error {
+	for j, address := range i.addresses {
+		if address.ID == updatedAddress.ID {
+			i.addresses[j] = *updatedAddress
+			return nil
+		}
+	}
+	return storeerrors.ErrNotFound
+}
+
+func (i *InMemoryRestaurantAddressStore) DeleteRestaurantAddressWhereRestaurantID(restaurantID int) error {
+	newAddresses := []RestaurantAddress{}
+	for _, address := range i.addresses {
+		if address.RestaurantID != restaurantID {
+			newAddresses = append(newAddresses, address)
+		}
+	}
+
+	i.addresses = newAddresses
+	return nil
+}
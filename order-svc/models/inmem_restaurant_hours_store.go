@@ -0,0 +1,44 @@
+package models
+
+type InMemoryRestaurantHoursStore struct {
+	hours []RestaurantHours
+}
+
+func NewInMemoryRestaurantHoursStore() *InMemoryRestaurantHoursStore {
+	return &InMemoryRestaurantHoursStore{[]RestaurantHours{}}
+}
+
+func (i *InMemoryRestaurantHoursStore) GetRestaurantHoursByRestaurantID(restaurantID int) ([]RestaurantHours, error) {
+	restaurantHours := []RestaurantHours{}
+	for _, hours := range i.hours {
+		if hours.RestaurantID == restaurantID {
+			restaurantHours = append(restaurantHours, hours)
+		}
+	}
+
+	return restaurantHours, nil
+}
+
+func (i *InMemoryRestaurantHoursStore) SetRestaurantHours(restaurantID int, hours []RestaurantHours) error {
+	i.DeleteRestaurantHoursWhereRestaurantID(restaurantID)
+
+	for _, day := range hours {
+		day.ID = len(i.hours) + 1
+		day.RestaurantID = restaurantID
+		i.hours = append(i.hours, day)
+	}
+
+	return nil
+}
+
+func (i *InMemoryRestaurantHoursStore) DeleteRestaurantHoursWhereRestaurantID(restaurantID int) error {
+	newHours := []RestaurantHours{}
+	for _, hours := range i.hours {
+		if hours.RestaurantID != restaurantID {
+			newHours = append(newHours, hours)
+		}
+	}
+
+	i.hours = newHours
+	return nil
+}
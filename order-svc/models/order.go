@@ -1,5 +1,9 @@
 package models
 
+import "errors"
+
+var ErrOrderNotCancelable = errors.New("order can't be canceled in its current status")
+
 type Order struct {
 	ID              int
 	CustomerID      int `db:"customer_id"`
@@ -0,0 +1,34 @@
+package models
+
+import "github.com/VitoNaychev/food-app/sm"
+
+var orderDeltas = []sm.Delta{
+	{Current: sm.State(APPROVAL_PENDING), Event: sm.Event(APPROVE_ORDER), Next: sm.State(APPROVED), Predicate: nil, Callback: nil},
+	{Current: sm.State(APPROVAL_PENDING), Event: sm.Event(REJECT_ORDER), Next: sm.State(REJECTED), Predicate: nil, Callback: nil},
+	{Current: sm.State(APPROVAL_PENDING), Event: sm.Event(CANCEL_ORDER), Next: sm.State(CANCELED), Predicate: nil, Callback: nil},
+	{Current: sm.State(APPROVED), Event: sm.Event(CANCEL_ORDER), Next: sm.State(CANCELED), Predicate: nil, Callback: nil},
+	{Current: sm.State(APPROVED), Event: sm.Event(DECLINE_ORDER), Next: sm.State(DECLINED), Predicate: nil, Callback: nil},
+	{Current: sm.State(APPROVED), Event: sm.Event(BEGIN_PREPARING_ORDER), Next: sm.State(PREPARING), Predicate: nil, Callback: nil},
+	{Current: sm.State(CANCELED), Event: sm.Event(RESUME_ORDER), Next: sm.State(PREPARING), Predicate: nil, Callback: nil},
+	{Current: sm.State(PREPARING), Event: sm.Event(FINISH_PREPARING_ORDER), Next: sm.State(PREPARED), Predicate: nil, Callback: nil},
+	{Current: sm.State(PREPARED), Event: sm.Event(PICKUP_ORDER), Next: sm.State(PICKED_UP), Predicate: nil, Callback: nil},
+	{Current: sm.State(PICKED_UP), Event: sm.Event(COMPLETE_ORDER), Next: sm.State(COMPLETED), Predicate: nil, Callback: nil},
+}
+
+type OrderSM struct {
+	sm sm.SM
+}
+
+func NewOrderSM(initial Status) OrderSM {
+	sm := sm.New(sm.State(initial), orderDeltas, nil)
+	return OrderSM{sm}
+}
+
+func (o *OrderSM) Exec(event OrderEvent) error {
+	err := o.sm.Exec(sm.Event(event))
+	return err
+}
+
+func (o *OrderSM) Current() Status {
+	return Status(o.sm.Current)
+}
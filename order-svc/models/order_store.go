@@ -6,4 +6,5 @@ type OrderStore interface {
 	GetCurrentOrdersByCustomerID(customerID int) ([]Order, error)
 	CreateOrder(order *Order) error
 	CancelOrder(id int) error
+	UpdateOrderStatus(id int, status Status) error
 }
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgOrderItemStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgOrderItemStore(ctx context.Context, connString string) (*PgOrderItemStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -21,6 +22,14 @@ func NewPgOrderItemStore(ctx context.Context, connString string) (*PgOrderItemSt
 	return &PgOrderItemStore{conn}, nil
 }
 
+func (p *PgOrderItemStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgOrderItemStore) WithTx(tx pgx.Tx) OrderItemStore {
+	return &PgOrderItemStore{tx}
+}
+
 func (p *PgOrderItemStore) CreateOrderItem(orderItem *OrderItem) error {
 	query := `insert into order_items(order_id, menu_item_id, quantity) 
 	values (@order_id, @menu_item_id, @quantity) returning id`
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgOrderStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgOrderStore(ctx context.Context, connString string) (*PgOrderStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -21,6 +22,14 @@ func NewPgOrderStore(ctx context.Context, connString string) (*PgOrderStore, err
 	return &PgOrderStore{conn}, nil
 }
 
+func (p *PgOrderStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgOrderStore) WithTx(tx pgx.Tx) OrderStore {
+	return &PgOrderStore{tx}
+}
+
 func (p *PgOrderStore) GetOrdersByCustomerID(customerId int) ([]Order, error) {
 	query := `select * from orders where customer_id=@customer_id`
 	args := pgx.NamedArgs{
@@ -74,10 +83,33 @@ func (p *PgOrderStore) CreateOrder(order *Order) error {
 	return storeerrors.FromPgxError(err)
 }
 
+// CancelOrder only cancels an order that is still pending approval or
+// approved, so an order the kitchen has moved on in the meantime is left as is.
 func (p *PgOrderStore) CancelOrder(id int) error {
+	query := `update orders set status=@status where id=@id and status in (@approval_pending, @approved)`
+	args := pgx.NamedArgs{
+		"status":           CANCELED,
+		"id":               id,
+		"approval_pending": APPROVAL_PENDING,
+		"approved":         APPROVED,
+	}
+
+	tag, err := p.conn.Exec(context.Background(), query, args)
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrOrderNotCancelable
+	}
+
+	return nil
+}
+
+func (p *PgOrderStore) UpdateOrderStatus(id int, status Status) error {
 	query := `update orders set status=@status where id=@id`
 	args := pgx.NamedArgs{
-		"status": CANCELED,
+		"status": status,
 		"id":     id,
 	}
 
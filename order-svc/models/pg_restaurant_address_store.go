@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgRestaurantAddressStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgRestaurantAddressStore(ctx context.Context, connString string) (*PgRestaurantAddressStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgRestaurantAddressStore{conn}, nil
+}
+
+func (p *PgRestaurantAddressStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgRestaurantAddressStore) WithTx(tx pgx.Tx) RestaurantAddressStore {
+	return &PgRestaurantAddressStore{tx}
+}
+
+func (p *PgRestaurantAddressStore) GetRestaurantAddressByRestaurantID(restaurantID int) (RestaurantAddress, error) {
+	query := `select * from restaurant_addresses where restaurant_id=@restaurant_id`
+	args := pgx.NamedArgs{
+		"restaurant_id": restaurantID,
+	}
+
+	row, _ := p.conn.Query(context.Background(), query, args)
+	address, err := pgx.CollectOneRow(row, pgx.RowToStructByName[RestaurantAddress])
+
+	if err != nil {
+		return RestaurantAddress{}, storeerrors.FromPgxError(err)
+	}
+
+	return address, nil
+}
+
+func (p *PgRestaurantAddressStore) CreateRestaurantAddress(address *RestaurantAddress) error {
+	query := `insert into restaurant_addresses(id, restaurant_id, lat, lon, address_line1, address_line2, city, country) 
+	values (@id, @restaurant_id, @lat, @lon, @address_line1, @address_line2, @city, @country)`
+	args := pgx.NamedArgs{
+		"id":            address.ID,
+		"restaurant_id": address.RestaurantID,
+		"lat":           address.Lat,
+		"lon":           address.Lon,
+		"address_line1": address.AddressLine1,
+		"address_line2": address.AddressLine2,
+		"city":          address.City,
+		"country":       address.Country,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgRestaurantAddressStore) UpdateRestaurantAddress(address *RestaurantAddress) error {
+	query := `update restaurant_addresses set restaurant_id=@restaurant_id, lat=@lat, lon=@lon, 
+	address_line1=@address_line1, address_line2=@address_line2, city=@city, country=@country where id=@id`
+	args := pgx.NamedArgs{
+		"id":            address.ID,
+		"restaurant_id": address.RestaurantID,
+		"lat":           address.Lat,
+		"lon":           address.Lon,
+		"address_line1": address.AddressLine1,
+		"address_line2": address.AddressLine2,
+		"city":          address.City,
+		"country":       address.Country,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgRestaurantAddressStore) DeleteRestaurantAddressWhereRestaurantID(restaurantID int) error {
+	query := `delete from restaurant_addresses where restaurant_id=@restaurant_id`
+	args := pgx.NamedArgs{
+		"restaurant_id": restaurantID,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgRestaurantHoursStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgRestaurantHoursStore(ctx context.Context, connString string) (*PgRestaurantHoursStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	return &PgRestaurantHoursStore{conn}, nil
+}
+
+func (p *PgRestaurantHoursStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgRestaurantHoursStore) WithTx(tx pgx.Tx) RestaurantHoursStore {
+	return &PgRestaurantHoursStore{tx}
+}
+
+func (p *PgRestaurantHoursStore) GetRestaurantHoursByRestaurantID(restaurantID int) ([]RestaurantHours, error) {
+	query := `select * from restaurant_hours where restaurant_id=@restaurant_id`
+	args := pgx.NamedArgs{
+		"restaurant_id": restaurantID,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	hours, err := pgx.CollectRows(rows, pgx.RowToStructByName[RestaurantHours])
+
+	if err != nil {
+		return []RestaurantHours{}, storeerrors.FromPgxError(err)
+	}
+
+	return hours, nil
+}
+
+func (p *PgRestaurantHoursStore) SetRestaurantHours(restaurantID int, hours []RestaurantHours) error {
+	deleteQuery := `delete from restaurant_hours where restaurant_id=@restaurant_id`
+	deleteArgs := pgx.NamedArgs{
+		"restaurant_id": restaurantID,
+	}
+
+	insertQuery := `insert into restaurant_hours(restaurant_id, day, opening, closing) 
+	values (@restaurant_id, @day, @opening, @closing)`
+
+	tx, err := p.conn.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), deleteQuery, deleteArgs)
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	for _, day := range hours {
+		insertArgs := pgx.NamedArgs{
+			"restaurant_id": restaurantID,
+			"day":           day.Day,
+			"opening":       day.Opening,
+			"closing":       day.Closing,
+		}
+
+		_, err = tx.Exec(context.Background(), insertQuery, insertArgs)
+		if err != nil {
+			return storeerrors.FromPgxError(err)
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	return nil
+}
+
+func (p *PgRestaurantHoursStore) DeleteRestaurantHoursWhereRestaurantID(restaurantID int) error {
+	query := `delete from restaurant_hours where restaurant_id=@restaurant_id`
+	args := pgx.NamedArgs{
+		"restaurant_id": restaurantID,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
@@ -0,0 +1,16 @@
+package models
+
+type RestaurantStatus int
+
+const (
+	RESTAURANT_CREATED     RestaurantStatus = 1 << 0
+	RESTAURANT_ADDRESS_SET RestaurantStatus = 1 << 1
+	RESTAURANT_HOURS_SET   RestaurantStatus = 1 << 2
+	RESTAURANT_VALID       RestaurantStatus = RESTAURANT_CREATED | RESTAURANT_ADDRESS_SET | RESTAURANT_HOURS_SET
+)
+
+type Restaurant struct {
+	ID       int `db:"id"`
+	Status   RestaurantStatus
+	Timezone string
+}
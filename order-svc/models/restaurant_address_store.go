@@ -0,0 +1,8 @@
+package models
+
+type RestaurantAddressStore interface {
+	GetRestaurantAddressByRestaurantID(restaurantID int) (RestaurantAddress, error)
+	CreateRestaurantAddress(address *RestaurantAddress) error
+	UpdateRestaurantAddress(address *RestaurantAddress) error
+	DeleteRestaurantAddressWhereRestaurantID(restaurantID int) error
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type RestaurantHours struct {
+	ID           int
+	RestaurantID int `db:"restaurant_id"`
+	Day          int
+	Opening      time.Time
+	Closing      time.Time
+}
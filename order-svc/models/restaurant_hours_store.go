@@ -0,0 +1,7 @@
+package models
+
+type RestaurantHoursStore interface {
+	GetRestaurantHoursByRestaurantID(restaurantID int) ([]RestaurantHours, error)
+	SetRestaurantHours(restaurantID int, hours []RestaurantHours) error
+	DeleteRestaurantHoursWhereRestaurantID(restaurantID int) error
+}
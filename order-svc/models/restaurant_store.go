@@ -0,0 +1,8 @@
+package models
+
+type RestaurantStore interface {
+	DeleteRestaurant(int) error
+	CreateRestaurant(*Restaurant) error
+	UpdateRestaurant(*Restaurant) error
+	GetRestaurantByID(int) (Restaurant, error)
+}
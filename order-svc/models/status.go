@@ -13,3 +13,17 @@ const (
 	PICKED_UP
 	COMPLETED
 )
+
+type OrderEvent int
+
+const (
+	APPROVE_ORDER OrderEvent = iota
+	REJECT_ORDER
+	DECLINE_ORDER
+	CANCEL_ORDER
+	BEGIN_PREPARING_ORDER
+	FINISH_PREPARING_ORDER
+	PICKUP_ORDER
+	COMPLETE_ORDER
+	RESUME_ORDER
+)
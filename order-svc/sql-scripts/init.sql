@@ -1,6 +1,44 @@
+DROP TABLE IF EXISTS inbox;
+DROP TABLE IF EXISTS outbox;
 DROP TABLE IF EXISTS order_items;
 DROP TABLE IF EXISTS orders;
 DROP TABLE IF EXISTS addresses;
+DROP TABLE IF EXISTS restaurant_hours;
+DROP TABLE IF EXISTS restaurant_addresses;
+DROP TABLE IF EXISTS menu_items;
+DROP TABLE IF EXISTS restaurants;
+
+CREATE TABLE restaurants (
+  id                  int                  PRIMARY KEY,
+  status              int                  NOT NULL        DEFAULT 0,
+  timezone            varchar(64)          NOT NULL        DEFAULT ''
+);
+
+CREATE TABLE menu_items (
+  id                  int                  PRIMARY KEY,
+  restaurant_id       int                  REFERENCES restaurants(id),
+  name                varchar(20)          NOT NULL,
+  price               numeric(6, 2)        NOT NULL
+);
+
+CREATE TABLE restaurant_addresses (
+  id                  int                  PRIMARY KEY,
+  restaurant_id       int                  REFERENCES restaurants(id),
+  lat                 numeric(20, 17)      NOT NULL,
+  lon                 numeric(20, 17)      NOT NULL,
+  address_line1       varchar(100)         NOT NULL,
+  address_line2       varchar(100)                 ,
+  city                varchar(70)          NOT NULL,
+  country             varchar(60)          NOT NULL
+);
+
+CREATE TABLE restaurant_hours (
+  id                  serial               PRIMARY KEY,
+  restaurant_id       int                  REFERENCES restaurants(id),
+  day                 int                  NOT NULL,
+  opening             time                 NOT NULL,
+  closing             time                 NOT NULL
+);
 
 CREATE TABLE addresses (
   id                  serial               PRIMARY KEY,
@@ -27,4 +65,19 @@ CREATE TABLE order_items (
 	order_id           int           NOT NULL       REFERENCES orders(id),
   menu_item_id       int           NOT NULL,
   quantity           int           NOT NULL
-);
\ No newline at end of file
+);
+
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
+
+CREATE TABLE inbox (
+  key                 varchar(100)         PRIMARY KEY,
+  processed_at        timestamp with time zone NOT NULL DEFAULT now()
+);
@@ -0,0 +1,38 @@
+package stubs
+
+import (
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type StubRestaurantAddressStore struct {
+	RestaurantAddresses      []models.RestaurantAddress
+	CreatedAddress           models.RestaurantAddress
+	UpdatedAddress           models.RestaurantAddress
+	DeletedAddressRestaurant int
+}
+
+func (s *StubRestaurantAddressStore) GetRestaurantAddressByRestaurantID(restaurantID int) (models.RestaurantAddress, error) {
+	for _, address := range s.RestaurantAddresses {
+		if address.RestaurantID == restaurantID {
+			return address, nil
+		}
+	}
+
+	return models.RestaurantAddress{}, storeerrors.ErrNotFound
+}
+
+func (s *StubRestaurantAddressStore) CreateRestaurantAddress(address *models.RestaurantAddress) error {
+	s.CreatedAddress = *address
+	return nil
+}
+
+func (s *StubRestaurantAddressStore) UpdateRestaurantAddress(address *models.RestaurantAddress) error {
+	s.UpdatedAddress = *address
+	return nil
+}
+
+func (s *StubRestaurantAddressStore) DeleteRestaurantAddressWhereRestaurantID(restaurantID int) error {
+	s.DeletedAddressRestaurant = restaurantID
+	return nil
+}
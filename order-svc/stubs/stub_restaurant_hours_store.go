@@ -0,0 +1,32 @@
+package stubs
+
+import "github.com/VitoNaychev/food-app/order-svc/models"
+
+type StubRestaurantHoursStore struct {
+	Hours                  []models.RestaurantHours
+	SetHours               []models.RestaurantHours
+	SetHoursRestaurantID   int
+	DeletedHoursRestaurant int
+}
+
+func (s *StubRestaurantHoursStore) GetRestaurantHoursByRestaurantID(restaurantID int) ([]models.RestaurantHours, error) {
+	restaurantHours := []models.RestaurantHours{}
+	for _, hours := range s.Hours {
+		if hours.RestaurantID == restaurantID {
+			restaurantHours = append(restaurantHours, hours)
+		}
+	}
+
+	return restaurantHours, nil
+}
+
+func (s *StubRestaurantHoursStore) SetRestaurantHours(restaurantID int, hours []models.RestaurantHours) error {
+	s.SetHoursRestaurantID = restaurantID
+	s.SetHours = hours
+	return nil
+}
+
+func (s *StubRestaurantHoursStore) DeleteRestaurantHoursWhereRestaurantID(restaurantID int) error {
+	s.DeletedHoursRestaurant = restaurantID
+	return nil
+}
@@ -0,0 +1,40 @@
+package stubs
+
+import (
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type StubRestaurantStore struct {
+	Restaurants         []models.Restaurant
+	CreatedRestaurant   models.Restaurant
+	UpdatedRestaurant   models.Restaurant
+	DeletedRestaurantID int
+}
+
+func (s *StubRestaurantStore) DeleteRestaurant(id int) error {
+	s.DeletedRestaurantID = id
+	return nil
+}
+
+func (s *StubRestaurantStore) GetRestaurantByID(id int) (models.Restaurant, error) {
+	for _, restaurant := range s.Restaurants {
+		if restaurant.ID == id {
+			return restaurant, nil
+		}
+	}
+
+	return models.Restaurant{}, storeerrors.ErrNotFound
+}
+
+func (s *StubRestaurantStore) CreateRestaurant(restaurant *models.Restaurant) error {
+	s.CreatedRestaurant = *restaurant
+
+	return nil
+}
+
+func (s *StubRestaurantStore) UpdateRestaurant(restaurant *models.Restaurant) error {
+	s.UpdatedRestaurant = *restaurant
+
+	return nil
+}
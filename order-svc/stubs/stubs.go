@@ -3,6 +3,7 @@ package stubs
 import (
 	"strconv"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/order-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
@@ -15,7 +16,7 @@ func StubVerifyJWT(jwt string) (msgtypes.AuthResponse, error) {
 		return msgtypes.AuthResponse{Status: msgtypes.NOT_FOUND, ID: 0}, nil
 	} else {
 		id, _ := strconv.Atoi(jwt)
-		return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id}, nil
+		return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id, Role: string(auth.CUSTOMER)}, nil
 	}
 }
 
@@ -71,6 +72,7 @@ func (s *StubAddressStore) GetAddressByID(id int) (models.Address, error) {
 type StubOrderStore struct {
 	CreatedOrders []models.Order
 	Orders        []models.Order
+	UpdatedOrder  models.Order
 }
 
 func (s *StubOrderStore) GetOrderByID(id int) (models.Order, error) {
@@ -85,6 +87,10 @@ func (s *StubOrderStore) GetOrderByID(id int) (models.Order, error) {
 func (s *StubOrderStore) CancelOrder(id int) error {
 	for i := range s.Orders {
 		if s.Orders[i].ID == id {
+			if s.Orders[i].Status != models.APPROVAL_PENDING && s.Orders[i].Status != models.APPROVED {
+				return models.ErrOrderNotCancelable
+			}
+
 			s.Orders[i].Status = models.CANCELED
 			return nil
 		}
@@ -92,6 +98,17 @@ func (s *StubOrderStore) CancelOrder(id int) error {
 	return nil
 }
 
+func (s *StubOrderStore) UpdateOrderStatus(id int, status models.Status) error {
+	for i := range s.Orders {
+		if s.Orders[i].ID == id {
+			s.Orders[i].Status = status
+			s.UpdatedOrder = s.Orders[i]
+			return nil
+		}
+	}
+	return storeerrors.ErrNotFound
+}
+
 func (s *StubOrderStore) CreateOrder(order *models.Order) error {
 	s.CreatedOrders = append(s.CreatedOrders, *order)
 	order.ID = len(s.CreatedOrders)
@@ -0,0 +1,45 @@
+package testdata
+
+import "github.com/VitoNaychev/food-app/order-svc/models"
+
+var (
+	ChickenShackMenuItems = []models.MenuItem{
+		{
+			ID:           1,
+			RestaurantID: 1,
+			Name:         "Chicken Burger",
+			Price:        5.02,
+		},
+		{
+			ID:           2,
+			RestaurantID: 1,
+			Name:         "Fries",
+			Price:        4.60,
+		},
+		{
+			ID:           3,
+			RestaurantID: 1,
+			Name:         "Coleslaw",
+			Price:        3.50,
+		},
+		{
+			ID:           4,
+			RestaurantID: 1,
+			Name:         "Chicken Wings",
+			Price:        6.80,
+		},
+		{
+			ID:           5,
+			RestaurantID: 1,
+			Name:         "Milkshake",
+			Price:        5.22,
+		},
+	}
+
+	OtherRestaurantMenuItem = models.MenuItem{
+		ID:           6,
+		RestaurantID: 2,
+		Name:         "Margherita Pizza",
+		Price:        9.50,
+	}
+)
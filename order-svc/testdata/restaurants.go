@@ -0,0 +1,74 @@
+package testdata
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/workhours"
+)
+
+var midnight, _ = time.Parse("15:04", "00:00")
+
+var (
+	ChickenShackRestaurant = models.Restaurant{
+		ID:       1,
+		Status:   models.RESTAURANT_VALID,
+		Timezone: workhours.DefaultTimezone,
+	}
+	ChickenShackRestaurantAddress = models.RestaurantAddress{
+		ID:           1,
+		RestaurantID: 1,
+		Lat:          42.635934305,
+		Lon:          23.380761684,
+		AddressLine1: "ul. Filip Avramov 411",
+		AddressLine2: "",
+		City:         "Sofia",
+		Country:      "Bulgaria",
+	}
+	// Chicken Shack is open around the clock, so that tests
+	// creating orders don't depend on the time they are run at.
+	ChickenShackRestaurantHours = []models.RestaurantHours{
+		{ID: 1, RestaurantID: 1, Day: 1, Opening: midnight, Closing: midnight},
+		{ID: 2, RestaurantID: 1, Day: 2, Opening: midnight, Closing: midnight},
+		{ID: 3, RestaurantID: 1, Day: 3, Opening: midnight, Closing: midnight},
+		{ID: 4, RestaurantID: 1, Day: 4, Opening: midnight, Closing: midnight},
+		{ID: 5, RestaurantID: 1, Day: 5, Opening: midnight, Closing: midnight},
+		{ID: 6, RestaurantID: 1, Day: 6, Opening: midnight, Closing: midnight},
+		{ID: 7, RestaurantID: 1, Day: 7, Opening: midnight, Closing: midnight},
+	}
+
+	// Dominos has set its address but not its working hours yet.
+	DominosRestaurant = models.Restaurant{
+		ID:       2,
+		Status:   models.RESTAURANT_CREATED | models.RESTAURANT_ADDRESS_SET,
+		Timezone: "",
+	}
+	DominosRestaurantAddress = models.RestaurantAddress{
+		ID:           2,
+		RestaurantID: 2,
+		Lat:          42.6931204,
+		Lon:          23.3225465,
+		AddressLine1: "bul. Vitosha 18",
+		AddressLine2: "",
+		City:         "Sofia",
+		Country:      "Bulgaria",
+	}
+
+	// Burger Bar is valid, but has no working hours in
+	// the read model, so it is never open.
+	BurgerBarRestaurant = models.Restaurant{
+		ID:       3,
+		Status:   models.RESTAURANT_VALID,
+		Timezone: workhours.DefaultTimezone,
+	}
+	BurgerBarRestaurantAddress = models.RestaurantAddress{
+		ID:           3,
+		RestaurantID: 3,
+		Lat:          42.6938570,
+		Lon:          23.3362452,
+		AddressLine1: "ul. Graf Ignatiev 10",
+		AddressLine2: "",
+		City:         "Sofia",
+		Country:      "Bulgaria",
+	}
+)
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/metrics"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/payout"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/tracing"
+)
+
+func main() {
+	env := appenv.Enviornment{
+		Dbhost: "payment-db",
+		Dbport: "5432",
+		Dbuser: os.Getenv("POSTGRES_USER"),
+		Dbpass: os.Getenv("POSTGRES_PASSWORD"),
+		Dbname: os.Getenv("POSTGRES_DB"),
+
+		KafkaBrokers: strings.Split(os.Getenv("KAFKA_BROKERS"), ","),
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), "payment-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	dbConfig := pgconfig.GetConfigFromEnv(env)
+	connStr := dbConfig.GetConnectionString()
+
+	paymentStore, err := models.NewPgPaymentStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Payment Store error: %v\n", err)
+	}
+
+	ledgerStore, err := models.NewPgLedgerStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Ledger Store error: %v\n", err)
+	}
+
+	payoutAccountStore, err := models.NewPgPayoutAccountStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Payout Account Store error: %v\n", err)
+	}
+
+	payoutBatchStore, err := models.NewPgPayoutBatchStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Payout Batch Store error: %v\n", err)
+	}
+
+	// There is no payment provider integration yet, so payments are
+	// processed by the in-memory fake gateway.
+	paymentGateway := gateway.NewFakeGateway()
+
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	if err != nil {
+		log.Fatalf("Kafka Event Publisher error: %v\n", err)
+	}
+	kafkaEventPublisher.SetProducer("payment-svc")
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(context.Background())
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
+
+	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "payment-svc")
+	if err != nil {
+		log.Fatalf("Kafka Event Consumer error: %v\n", err)
+	}
+
+	inboxStore, err := events.NewPgInboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Inbox Store error: %v\n", err)
+	}
+	eventConsumer.SetInboxStore(inboxStore)
+	eventConsumer.SetDeadLetterPublisher(kafkaEventPublisher)
+
+	orderEventHandler := handlers.NewOrderEventHandler(paymentStore, ledgerStore, paymentGateway, eventPublisher)
+	kitchenEventHandler := handlers.NewKitchenEventHandler(paymentStore, ledgerStore, paymentGateway, eventPublisher)
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(paymentStore, ledgerStore, paymentGateway, eventPublisher,
+		ledger.DefaultSplitConfig)
+	restaurantEventHandler := handlers.NewRestaurantEventHandler(payoutAccountStore)
+	courierEventHandler := handlers.NewCourierEventHandler(payoutAccountStore)
+
+	err = handlers.RegisterOrderEventHandlers(eventConsumer, orderEventHandler)
+	if err != nil {
+		log.Fatalf("Order Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterKitchenEventHandlers(eventConsumer, kitchenEventHandler)
+	if err != nil {
+		log.Fatalf("Kitchen Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterDeliveryEventHandlers(eventConsumer, deliveryEventHandler)
+	if err != nil {
+		log.Fatalf("Delivery Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterRestaurantEventHandlers(eventConsumer, restaurantEventHandler)
+	if err != nil {
+		log.Fatalf("Restaurant Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterCourierEventHandlers(eventConsumer, courierEventHandler)
+	if err != nil {
+		log.Fatalf("Courier Event Handlers error: %v\n", err)
+	}
+
+	go events.LogEventConsumerErrors(context.Background(), eventConsumer)
+
+	payoutInterval, err := time.ParseDuration(os.Getenv("PAYOUT_INTERVAL"))
+	if err != nil {
+		log.Fatalf("Payout Interval error: %v\n", err)
+	}
+
+	payoutConfig := payout.Config{
+		Interval:  payoutInterval,
+		OutputDir: os.Getenv("PAYOUT_DIR"),
+		Debtor: payout.Debtor{
+			Name: os.Getenv("PLATFORM_NAME"),
+			IBAN: os.Getenv("PLATFORM_IBAN"),
+			BIC:  os.Getenv("PLATFORM_BIC"),
+		},
+	}
+
+	// The payout job runs alongside the event consumer, so it gets its own
+	// connections instead of sharing the consumer's.
+	payoutLedgerStore, err := models.NewPgLedgerStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Ledger Store error: %v\n", err)
+	}
+
+	payoutAccountReadStore, err := models.NewPgPayoutAccountStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Payout Account Store error: %v\n", err)
+	}
+
+	payoutJob := payout.NewJob(payoutLedgerStore, payoutAccountReadStore, payoutBatchStore, payoutConfig)
+	go payoutJob.Run(context.Background())
+
+	// The payment service has no API of its own, so it only serves metrics.
+	mux := http.NewServeMux()
+	mux.Handle(metrics.MetricsPath, metrics.Handler())
+	go func() {
+		log.Fatal(http.ListenAndServe(":8080", mux))
+	}()
+
+	log.Println("payment service consuming events")
+	eventConsumer.Run(context.Background())
+}
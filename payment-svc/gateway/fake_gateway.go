@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"fmt"
+	"sync"
+)
+
+type FakeAuthorization struct {
+	CustomerID int
+	Amount     float32
+	Captured   bool
+	Refunded   bool
+}
+
+// FakeGateway is an in-memory PaymentGateway that approves every payment,
+// except those of customers marked with DeclineCustomer.
+type FakeGateway struct {
+	mu sync.Mutex
+
+	nextID            int
+	authorizations    map[string]*FakeAuthorization
+	idempotencyKeys   map[string]string
+	declinedCustomers map[int]bool
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		nextID:            1,
+		authorizations:    map[string]*FakeAuthorization{},
+		idempotencyKeys:   map[string]string{},
+		declinedCustomers: map[int]bool{},
+	}
+}
+
+func (f *FakeGateway) DeclineCustomer(customerID int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.declinedCustomers[customerID] = true
+}
+
+func (f *FakeGateway) GetAuthorization(authorizationID string) (FakeAuthorization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorization, ok := f.authorizations[authorizationID]
+	if !ok {
+		return FakeAuthorization{}, ErrAuthorizationNotFound
+	}
+
+	return *authorization, nil
+}
+
+func (f *FakeGateway) Authorize(idempotencyKey string, customerID int, amount float32) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.declinedCustomers[customerID] {
+		return "", ErrPaymentDeclined
+	}
+
+	if authorizationID, ok := f.idempotencyKeys[idempotencyKey]; ok {
+		return authorizationID, nil
+	}
+
+	authorizationID := fmt.Sprintf("fake-auth-%d", f.nextID)
+	f.nextID++
+	f.idempotencyKeys[idempotencyKey] = authorizationID
+
+	f.authorizations[authorizationID] = &FakeAuthorization{
+		CustomerID: customerID,
+		Amount:     amount,
+	}
+
+	return authorizationID, nil
+}
+
+func (f *FakeGateway) Capture(authorizationID string, amount float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorization, ok := f.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+
+	if authorization.Refunded {
+		return ErrAlreadyRefunded
+	}
+
+	if authorization.Captured {
+		return ErrAlreadyCaptured
+	}
+
+	if amount > authorization.Amount {
+		return ErrInvalidAmount
+	}
+
+	authorization.Captured = true
+	return nil
+}
+
+func (f *FakeGateway) Refund(authorizationID string, amount float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	authorization, ok := f.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+
+	if authorization.Refunded {
+		return ErrAlreadyRefunded
+	}
+
+	if amount > authorization.Amount {
+		return ErrInvalidAmount
+	}
+
+	authorization.Refunded = true
+	return nil
+}
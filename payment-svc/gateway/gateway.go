@@ -0,0 +1,23 @@
+package gateway
+
+import "errors"
+
+var (
+	ErrPaymentDeclined       = errors.New("payment was declined by the gateway")
+	ErrAuthorizationNotFound = errors.New("authorization doesn't exist")
+	ErrInvalidAmount         = errors.New("amount exceeds the authorized amount")
+	ErrAlreadyCaptured       = errors.New("authorization is already captured")
+	ErrAlreadyRefunded       = errors.New("authorization is already refunded")
+)
+
+// PaymentGateway is the payment provider the service charges customers
+// through. Authorize places a hold on the customer's funds and returns an
+// authorization ID, which Capture and Refund then operate on. Authorizing
+// again with the same idempotency key returns the existing authorization
+// instead of placing a second hold. Refunding an authorization that was
+// never captured releases the hold.
+type PaymentGateway interface {
+	Authorize(idempotencyKey string, customerID int, amount float32) (string, error)
+	Capture(authorizationID string, amount float32) error
+	Refund(authorizationID string, amount float32) error
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type CourierEventHandler struct {
+	payoutAccountStore models.PayoutAccountStore
+}
+
+func NewCourierEventHandler(payoutAccountStore models.PayoutAccountStore) *CourierEventHandler {
+	courierEventHandler := CourierEventHandler{
+		payoutAccountStore: payoutAccountStore,
+	}
+
+	return &courierEventHandler
+}
+
+func RegisterCourierEventHandlers(eventConsumer events.EventConsumer, courierEventHandler *CourierEventHandler) error {
+	err := svcevents.CourierCreated.Register(eventConsumer, courierEventHandler.HandleCourierCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.CourierUpdated.Register(eventConsumer, courierEventHandler.HandleCourierUpdatedEvent)
+}
+
+func (c *CourierEventHandler) HandleCourierCreatedEvent(event events.Event[svcevents.CourierCreatedEvent]) error {
+	return c.inTx(event.Context(), func(c *CourierEventHandler) error {
+		payoutAccount := models.PayoutAccount{
+			AccountType: models.COURIER_ACCOUNT,
+			OwnerID:     event.Payload.ID,
+			Name:        event.Payload.Name,
+			IBAN:        event.Payload.IBAN,
+		}
+
+		return c.payoutAccountStore.SetPayoutAccount(&payoutAccount)
+	})
+}
+
+func (c *CourierEventHandler) HandleCourierUpdatedEvent(event events.Event[svcevents.CourierUpdatedEvent]) error {
+	return c.inTx(event.Context(), func(c *CourierEventHandler) error {
+		payoutAccount := models.PayoutAccount{
+			AccountType: models.COURIER_ACCOUNT,
+			OwnerID:     event.Payload.ID,
+			Name:        event.Payload.Name,
+			IBAN:        event.Payload.IBAN,
+		}
+
+		return c.payoutAccountStore.SetPayoutAccount(&payoutAccount)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (c *CourierEventHandler) inTx(ctx context.Context, fn func(c *CourierEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, c.payoutAccountStore, func(tx pgx.Tx) error {
+		txHandler := *c
+		txHandler.payoutAccountStore = pgconfig.WithTx(c.payoutAccountStore, tx)
+
+		return fn(&txHandler)
+	})
+}
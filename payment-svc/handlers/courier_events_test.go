@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestCourierEventHandler(t *testing.T) {
+	t.Run("sets courier payout account on COURIER_CREATED event", func(t *testing.T) {
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		eventHandler := handlers.NewCourierEventHandler(payoutAccountStore)
+
+		payload := svcevents.CourierCreatedEvent{ID: 1, Name: "Alice", IBAN: "BG80BNBG96611020345678"}
+		event := events.NewTypedEvent(svcevents.COURIER_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleCourierCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := payoutAccountStore.GetPayoutAccount(models.Account{Type: models.COURIER_ACCOUNT, OwnerID: 1})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, models.PayoutAccount{
+			AccountType: models.COURIER_ACCOUNT,
+			OwnerID:     1,
+			Name:        payload.Name,
+			IBAN:        payload.IBAN,
+		})
+	})
+
+	t.Run("updates courier payout account on COURIER_UPDATED event", func(t *testing.T) {
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		payoutAccountStore.SetPayoutAccount(&models.PayoutAccount{
+			AccountType: models.COURIER_ACCOUNT,
+			OwnerID:     1,
+			Name:        "Alice",
+			IBAN:        "BG80BNBG96611020345678",
+		})
+		eventHandler := handlers.NewCourierEventHandler(payoutAccountStore)
+
+		payload := svcevents.CourierUpdatedEvent{ID: 1, Name: "Alice", IBAN: "DE89370400440532013000"}
+		event := events.NewTypedEvent(svcevents.COURIER_UPDATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleCourierUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := payoutAccountStore.GetPayoutAccount(models.Account{Type: models.COURIER_ACCOUNT, OwnerID: 1})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.IBAN, payload.IBAN)
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type DeliveryEventHandler struct {
+	paymentStore   models.PaymentStore
+	ledgerStore    models.LedgerStore
+	paymentGateway gateway.PaymentGateway
+	publisher      events.EventPublisher
+	splitConfig    ledger.SplitConfig
+}
+
+func NewDeliveryEventHandler(paymentStore models.PaymentStore, ledgerStore models.LedgerStore, paymentGateway gateway.PaymentGateway,
+	publisher events.EventPublisher, splitConfig ledger.SplitConfig) *DeliveryEventHandler {
+	deliveryEventHandler := DeliveryEventHandler{
+		paymentStore:   paymentStore,
+		ledgerStore:    ledgerStore,
+		paymentGateway: paymentGateway,
+		publisher:      publisher,
+		splitConfig:    splitConfig,
+	}
+
+	return &deliveryEventHandler
+}
+
+func RegisterDeliveryEventHandlers(eventConsumer events.EventConsumer, deliveryEventHandler *DeliveryEventHandler) error {
+	return svcevents.DeliveryCompleted.Register(eventConsumer, deliveryEventHandler.HandleDeliveryCompletedEvent)
+}
+
+// Delivery IDs match the IDs of the orders they were created for, which in
+// turn are the IDs of the payments.
+func (d *DeliveryEventHandler) HandleDeliveryCompletedEvent(event events.Event[svcevents.DeliveryCompletedEvent]) error {
+	return d.inTx(event.Context(), func(d *DeliveryEventHandler) error {
+		return capturePayment(d.paymentStore, d.ledgerStore, d.paymentGateway, d.publisher, d.splitConfig,
+			event.Payload.ID, event.Payload.CourierID, event.Headers)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (d *DeliveryEventHandler) inTx(ctx context.Context, fn func(d *DeliveryEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, d.paymentStore, func(tx pgx.Tx) error {
+		txHandler := *d
+		txHandler.paymentStore = pgconfig.WithTx(d.paymentStore, tx)
+		txHandler.ledgerStore = pgconfig.WithTx(d.ledgerStore, tx)
+		txHandler.publisher = pgconfig.WithTx(d.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
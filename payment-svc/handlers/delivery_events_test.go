@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/stubs"
+	"github.com/VitoNaychev/food-app/payment-svc/testdata"
+	"github.com/VitoNaychev/food-app/sm"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestDeliveryEventHandler(t *testing.T) {
+	t.Run("captures payment on DELIVERY_COMPLETED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewDeliveryEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway,
+			publisher, ledger.DefaultSplitConfig)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: payment.ID, CourierID: 1}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		want := payment
+		want.State = models.CAPTURED
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment, want)
+
+		authorization, _ := paymentGateway.GetAuthorization(payment.AuthorizationID)
+		testutil.AssertEqual(t, authorization.Captured, true)
+
+		wantEvent := events.NewEvent(svcevents.PAYMENT_CAPTURED_EVENT_ID, payment.ID, svcevents.PaymentCapturedEvent{
+			ID:           payment.ID,
+			RestaurantID: payment.RestaurantID,
+			Amount:       payment.Amount,
+		})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.PAYMENT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("finishes capture that went through before the payment was updated", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+		paymentGateway.Capture(payment.AuthorizationID, payment.Amount)
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewDeliveryEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway,
+			publisher, ledger.DefaultSplitConfig)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: payment.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment.State, models.CAPTURED)
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.PAYMENT_CAPTURED_EVENT_ID)
+	})
+
+	t.Run("returns error on DELIVERY_COMPLETED event for refunded payment", func(t *testing.T) {
+		payment := testdata.PeterPayment
+		payment.State = models.REFUNDED
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		eventHandler := handlers.NewDeliveryEventHandler(paymentStore, models.NewInMemoryLedgerStore(), gateway.NewFakeGateway(),
+			&stubs.StubEventPublisher{}, ledger.DefaultSplitConfig)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: payment.ID}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+
+		testutil.AssertError(t, err, sm.ErrInvalidEvent)
+	})
+
+	t.Run("books commission split on DELIVERY_COMPLETED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		ledgerStore := models.NewInMemoryLedgerStore()
+		eventHandler := handlers.NewDeliveryEventHandler(paymentStore, ledgerStore, paymentGateway,
+			&stubs.StubEventPublisher{}, ledger.DefaultSplitConfig)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: payment.ID, CourierID: 2}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		assertBalance(t, ledgerStore, models.Account{Type: models.CLEARING_ACCOUNT}, -1250)
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: payment.RestaurantID}, 1000)
+		assertBalance(t, ledgerStore, models.Account{Type: models.COURIER_ACCOUNT, OwnerID: 2}, 125)
+		assertBalance(t, ledgerStore, models.Account{Type: models.PLATFORM_ACCOUNT}, 125)
+	})
+
+	t.Run("doesn't book capture twice on redelivered DELIVERY_COMPLETED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+		paymentGateway.Capture(payment.AuthorizationID, payment.Amount)
+
+		ledgerStore := models.NewInMemoryLedgerStore()
+		ledgerStore.CreateTransaction(ledger.CaptureTransactionID(payment.ID),
+			ledger.CaptureEntries(payment, 2, ledger.DefaultSplitConfig))
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		eventHandler := handlers.NewDeliveryEventHandler(paymentStore, ledgerStore, paymentGateway,
+			&stubs.StubEventPublisher{}, ledger.DefaultSplitConfig)
+
+		payload := svcevents.DeliveryCompletedEvent{ID: payment.ID, CourierID: 2}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleDeliveryCompletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment.State, models.CAPTURED)
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: payment.RestaurantID}, 1000)
+	})
+}
+
+func assertBalance(t testing.TB, ledgerStore models.LedgerStore, account models.Account, want int64) {
+	t.Helper()
+
+	got, err := ledgerStore.GetBalance(account)
+	testutil.AssertNoErr(t, err)
+	testutil.AssertEqual(t, got, want)
+}
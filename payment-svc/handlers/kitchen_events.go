@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type KitchenEventHandler struct {
+	paymentStore   models.PaymentStore
+	ledgerStore    models.LedgerStore
+	paymentGateway gateway.PaymentGateway
+	publisher      events.EventPublisher
+}
+
+func NewKitchenEventHandler(paymentStore models.PaymentStore, ledgerStore models.LedgerStore, paymentGateway gateway.PaymentGateway,
+	publisher events.EventPublisher) *KitchenEventHandler {
+	kitchenEventHandler := KitchenEventHandler{
+		paymentStore:   paymentStore,
+		ledgerStore:    ledgerStore,
+		paymentGateway: paymentGateway,
+		publisher:      publisher,
+	}
+
+	return &kitchenEventHandler
+}
+
+func RegisterKitchenEventHandlers(eventConsumer events.EventConsumer, kitchenEventHandler *KitchenEventHandler) error {
+	return svcevents.TicketRejected.Register(eventConsumer, kitchenEventHandler.HandleTicketRejectedEvent)
+}
+
+// A rejected ticket never turns into an order the customer can cancel, so
+// the authorization is released here instead.
+func (k *KitchenEventHandler) HandleTicketRejectedEvent(event events.Event[svcevents.TicketRejectedEvent]) error {
+	return k.inTx(event.Context(), func(k *KitchenEventHandler) error {
+		return refundPayment(k.paymentStore, k.ledgerStore, k.paymentGateway, k.publisher, event.Payload.ID, event.Headers)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (k *KitchenEventHandler) inTx(ctx context.Context, fn func(k *KitchenEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, k.paymentStore, func(tx pgx.Tx) error {
+		txHandler := *k
+		txHandler.paymentStore = pgconfig.WithTx(k.paymentStore, tx)
+		txHandler.ledgerStore = pgconfig.WithTx(k.ledgerStore, tx)
+		txHandler.publisher = pgconfig.WithTx(k.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -0,0 +1,37 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/stubs"
+	"github.com/VitoNaychev/food-app/payment-svc/testdata"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestKitchenEventHandler(t *testing.T) {
+	t.Run("releases authorization on TICKET_REJECTED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewKitchenEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway, publisher)
+
+		payload := svcevents.TicketRejectedEvent{ID: payment.ID, Reason: "menu item doesn't exist"}
+		event := events.NewTypedEvent(svcevents.TICKET_REJECTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleTicketRejectedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment.State, models.REFUNDED)
+
+		authorization, _ := paymentGateway.GetAuthorization(payment.AuthorizationID)
+		testutil.AssertEqual(t, authorization.Refunded, true)
+		testutil.AssertEqual(t, authorization.Captured, false)
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type OrderEventHandler struct {
+	paymentStore   models.PaymentStore
+	ledgerStore    models.LedgerStore
+	paymentGateway gateway.PaymentGateway
+	publisher      events.EventPublisher
+}
+
+func NewOrderEventHandler(paymentStore models.PaymentStore, ledgerStore models.LedgerStore, paymentGateway gateway.PaymentGateway,
+	publisher events.EventPublisher) *OrderEventHandler {
+	orderEventHandler := OrderEventHandler{
+		paymentStore:   paymentStore,
+		ledgerStore:    ledgerStore,
+		paymentGateway: paymentGateway,
+		publisher:      publisher,
+	}
+
+	return &orderEventHandler
+}
+
+func RegisterOrderEventHandlers(eventConsumer events.EventConsumer, orderEventHandler *OrderEventHandler) error {
+	err := svcevents.OrderCreated.Register(eventConsumer, orderEventHandler.HandleOrderCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	err = svcevents.OrderCanceled.Register(eventConsumer, orderEventHandler.HandleOrderCanceledEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.RefundRequested.Register(eventConsumer, orderEventHandler.HandleRefundRequestedEvent)
+}
+
+func (o *OrderEventHandler) HandleOrderCreatedEvent(event events.Event[svcevents.OrderCreatedEvent]) error {
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		return authorizePayment(o.paymentStore, o.paymentGateway, o.publisher, event.Payload, event.Headers)
+	})
+}
+
+func (o *OrderEventHandler) HandleOrderCanceledEvent(event events.Event[svcevents.OrderCanceledEvent]) error {
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		return refundPayment(o.paymentStore, o.ledgerStore, o.paymentGateway, o.publisher, event.Payload.ID, event.Headers)
+	})
+}
+
+func (o *OrderEventHandler) HandleRefundRequestedEvent(event events.Event[svcevents.RefundRequestedEvent]) error {
+	return o.inTx(event.Context(), func(o *OrderEventHandler) error {
+		return refundPayment(o.paymentStore, o.ledgerStore, o.paymentGateway, o.publisher, event.Payload.ID, event.Headers)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores and publisher write
+// through the transaction the event is handled in, or a new one if there
+// is none.
+func (o *OrderEventHandler) inTx(ctx context.Context, fn func(o *OrderEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, o.paymentStore, func(tx pgx.Tx) error {
+		txHandler := *o
+		txHandler.paymentStore = pgconfig.WithTx(o.paymentStore, tx)
+		txHandler.ledgerStore = pgconfig.WithTx(o.ledgerStore, tx)
+		txHandler.publisher = pgconfig.WithTx(o.publisher, tx)
+
+		return fn(&txHandler)
+	})
+}
@@ -0,0 +1,190 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/stubs"
+	"github.com/VitoNaychev/food-app/payment-svc/testdata"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestOrderEventHandler(t *testing.T) {
+	t.Run("authorizes payment on ORDER_CREATED event", func(t *testing.T) {
+		paymentStore := &stubs.StubPaymentStore{}
+		paymentGateway := gateway.NewFakeGateway()
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		want := testdata.PeterPayment
+		want.AuthorizationID = paymentStore.CreatedPayment.AuthorizationID
+		testutil.AssertEqual(t, paymentStore.CreatedPayment, want)
+
+		authorization, err := paymentGateway.GetAuthorization(want.AuthorizationID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, authorization.Amount, want.Amount)
+
+		wantEvent := events.NewEvent(svcevents.PAYMENT_AUTHORIZED_EVENT_ID, want.ID,
+			svcevents.PaymentAuthorizedEvent{ID: want.ID, Amount: want.Amount})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.PAYMENT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("reuses authorization of ORDER_CREATED event redelivered before the payment was stored", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, payload.ID, payload)
+
+		var authorizationIDs []string
+		for i := 0; i < 2; i++ {
+			paymentStore := &stubs.StubPaymentStore{}
+			eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway, &stubs.StubEventPublisher{})
+
+			err := eventHandler.HandleOrderCreatedEvent(event)
+			testutil.AssertNoErr(t, err)
+
+			authorizationIDs = append(authorizationIDs, paymentStore.CreatedPayment.AuthorizationID)
+		}
+
+		testutil.AssertEqual(t, authorizationIDs[1], authorizationIDs[0])
+	})
+
+	t.Run("records failed authorization on declined payment", func(t *testing.T) {
+		paymentStore := &stubs.StubPaymentStore{}
+		paymentGateway := gateway.NewFakeGateway()
+		paymentGateway.DeclineCustomer(testdata.PeterOrderCreatedEvent.CustomerID)
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway, publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		want := testdata.PeterPayment
+		want.State = models.AUTHORIZATION_FAILED
+		testutil.AssertEqual(t, paymentStore.CreatedPayment, want)
+
+		wantEvent := events.NewEvent(svcevents.PAYMENT_AUTHORIZATION_FAILED_EVENT_ID, want.ID,
+			svcevents.PaymentAuthorizationFailedEvent{ID: want.ID, Reason: gateway.ErrPaymentDeclined.Error()})
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("ignores redelivered ORDER_CREATED event", func(t *testing.T) {
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{testdata.PeterPayment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), gateway.NewFakeGateway(), publisher)
+
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCreatedEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, paymentStore.CreatedPayment, models.Payment{})
+		testutil.AssertEqual(t, publisher.SpyTopic, "")
+	})
+
+	t.Run("refunds authorized payment on ORDER_CANCELED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), paymentGateway, publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: payment.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment.State, models.REFUNDED)
+
+		authorization, _ := paymentGateway.GetAuthorization(payment.AuthorizationID)
+		testutil.AssertEqual(t, authorization.Refunded, true)
+
+		wantEvent := events.NewEvent(svcevents.PAYMENT_REFUNDED_EVENT_ID, payment.ID,
+			svcevents.PaymentRefundedEvent{ID: payment.ID, Amount: payment.Amount})
+		testutil.AssertEqual(t, publisher.SpyTopic, svcevents.PAYMENT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.SpyEvent, wantEvent)
+	})
+
+	t.Run("refunds captured payment on REFUND_REQUESTED event", func(t *testing.T) {
+		paymentGateway := gateway.NewFakeGateway()
+		payment := authorizePayment(t, paymentGateway, testdata.PeterPayment)
+		paymentGateway.Capture(payment.AuthorizationID, payment.Amount)
+		payment.State = models.CAPTURED
+
+		ledgerStore := models.NewInMemoryLedgerStore()
+		ledgerStore.CreateTransaction(ledger.CaptureTransactionID(payment.ID),
+			ledger.CaptureEntries(payment, 1, ledger.DefaultSplitConfig))
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, ledgerStore, paymentGateway, publisher)
+
+		payload := svcevents.RefundRequestedEvent{
+			ID:         payment.ID,
+			CustomerID: payment.CustomerID,
+			Amount:     payment.Amount,
+			Reason:     svcevents.DECLINE_REASON_TOO_BUSY.String(),
+		}
+		event := events.NewTypedEvent(svcevents.REFUND_REQUESTED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleRefundRequestedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment.State, models.REFUNDED)
+
+		authorization, _ := paymentGateway.GetAuthorization(payment.AuthorizationID)
+		testutil.AssertEqual(t, authorization.Refunded, true)
+		testutil.AssertEqual(t, publisher.SpyEvent.EventID, svcevents.PAYMENT_REFUNDED_EVENT_ID)
+
+		restaurantAccount := models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: payment.RestaurantID}
+		balance, _ := ledgerStore.GetBalance(restaurantAccount)
+		testutil.AssertEqual(t, balance, 0)
+	})
+
+	t.Run("ignores refund of failed authorization", func(t *testing.T) {
+		payment := testdata.PeterPayment
+		payment.State = models.AUTHORIZATION_FAILED
+
+		paymentStore := &stubs.StubPaymentStore{Payments: []models.Payment{payment}}
+		publisher := &stubs.StubEventPublisher{}
+		eventHandler := handlers.NewOrderEventHandler(paymentStore, models.NewInMemoryLedgerStore(), gateway.NewFakeGateway(), publisher)
+
+		payload := svcevents.OrderCanceledEvent{ID: payment.ID}
+		event := events.NewTypedEvent(svcevents.ORDER_CANCELED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleOrderCanceledEvent(event)
+
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, paymentStore.UpdatedPayment, models.Payment{})
+		testutil.AssertEqual(t, publisher.SpyTopic, "")
+	})
+}
+
+func authorizePayment(t testing.TB, paymentGateway *gateway.FakeGateway, payment models.Payment) models.Payment {
+	t.Helper()
+
+	idempotencyKey := handlers.AuthorizationIdempotencyKey(payment.ID)
+	authorizationID, err := paymentGateway.Authorize(idempotencyKey, payment.CustomerID, payment.Amount)
+	testutil.AssertNoErr(t, err)
+
+	payment.AuthorizationID = authorizationID
+	return payment
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+func authorizePayment(paymentStore models.PaymentStore, paymentGateway gateway.PaymentGateway, publisher events.EventPublisher,
+	orderCreatedEvent svcevents.OrderCreatedEvent, cause events.EventHeaders) error {
+	_, err := paymentStore.GetPaymentByID(orderCreatedEvent.ID)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, storeerrors.ErrNotFound) {
+		return err
+	}
+
+	payment := models.Payment{
+		ID:           orderCreatedEvent.ID,
+		CustomerID:   orderCreatedEvent.CustomerID,
+		RestaurantID: orderCreatedEvent.RestaurantID,
+		Amount:       orderCreatedEvent.Total,
+		State:        models.AUTHORIZED,
+	}
+
+	// The payment ID keys the authorization, so an event redelivered after the
+	// payment failed to be stored gets back the hold placed the first time.
+	idempotencyKey := AuthorizationIdempotencyKey(payment.ID)
+	authorizationID, authorizationErr := paymentGateway.Authorize(idempotencyKey, payment.CustomerID, payment.Amount)
+	if authorizationErr != nil && !errors.Is(authorizationErr, gateway.ErrPaymentDeclined) {
+		return authorizationErr
+	}
+
+	if authorizationErr == nil {
+		payment.AuthorizationID = authorizationID
+	} else {
+		payment.State = models.AUTHORIZATION_FAILED
+	}
+
+	err = paymentStore.CreatePayment(&payment)
+	if err != nil {
+		return err
+	}
+
+	var event events.InterfaceEvent
+	if authorizationErr == nil {
+		payload := svcevents.PaymentAuthorizedEvent{ID: payment.ID, Amount: payment.Amount}
+		event = svcevents.PaymentAuthorized.New(payment.ID, payload).CausedBy(cause)
+	} else {
+		payload := svcevents.PaymentAuthorizationFailedEvent{ID: payment.ID, Reason: authorizationErr.Error()}
+		event = svcevents.PaymentAuthorizationFailed.New(payment.ID, payload).CausedBy(cause)
+	}
+
+	return publisher.Publish(svcevents.PAYMENT_EVENTS_TOPIC, event)
+}
+
+func AuthorizationIdempotencyKey(paymentID int) string {
+	return fmt.Sprintf("payment-%d", paymentID)
+}
+
+// Transactions are booked before the payment is updated, so a redelivered
+// event finds the transaction already in the ledger and doesn't book it twice.
+func bookTransaction(ledgerStore models.LedgerStore, transactionID string, entries []models.LedgerEntry) error {
+	_, err := ledgerStore.GetTransaction(transactionID)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, storeerrors.ErrNotFound) {
+		return err
+	}
+
+	return ledgerStore.CreateTransaction(transactionID, entries)
+}
+
+func capturePayment(paymentStore models.PaymentStore, ledgerStore models.LedgerStore, paymentGateway gateway.PaymentGateway,
+	publisher events.EventPublisher, splitConfig ledger.SplitConfig, paymentID int, courierID int, cause events.EventHeaders) error {
+	payment, err := paymentStore.GetPaymentByID(paymentID)
+	if err != nil {
+		return err
+	}
+
+	if payment.State == models.CAPTURED {
+		return nil
+	}
+
+	paymentSM := models.NewPaymentSM(payment.State)
+	err = paymentSM.Exec(models.CAPTURE_PAYMENT)
+	if err != nil {
+		return err
+	}
+
+	// A capture that went through before the payment could be updated is
+	// reported back as already captured when the event is redelivered.
+	err = paymentGateway.Capture(payment.AuthorizationID, payment.Amount)
+	if err != nil && !errors.Is(err, gateway.ErrAlreadyCaptured) {
+		return err
+	}
+
+	entries := ledger.CaptureEntries(payment, courierID, splitConfig)
+	err = bookTransaction(ledgerStore, ledger.CaptureTransactionID(payment.ID), entries)
+	if err != nil {
+		return err
+	}
+
+	payment.State = paymentSM.Current()
+	err = paymentStore.UpdatePayment(&payment)
+	if err != nil {
+		return err
+	}
+
+	payload := svcevents.PaymentCapturedEvent{
+		ID:           payment.ID,
+		RestaurantID: payment.RestaurantID,
+		Amount:       payment.Amount,
+	}
+	event := svcevents.PaymentCaptured.New(payment.ID, payload).CausedBy(cause)
+
+	return publisher.Publish(svcevents.PAYMENT_EVENTS_TOPIC, event)
+}
+
+func refundPayment(paymentStore models.PaymentStore, ledgerStore models.LedgerStore, paymentGateway gateway.PaymentGateway,
+	publisher events.EventPublisher, paymentID int, cause events.EventHeaders) error {
+	payment, err := paymentStore.GetPaymentByID(paymentID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if payment.State == models.REFUNDED || payment.State == models.AUTHORIZATION_FAILED {
+		return nil
+	}
+
+	paymentSM := models.NewPaymentSM(payment.State)
+	err = paymentSM.Exec(models.REFUND_PAYMENT)
+	if err != nil {
+		return err
+	}
+
+	err = paymentGateway.Refund(payment.AuthorizationID, payment.Amount)
+	if err != nil && !errors.Is(err, gateway.ErrAlreadyRefunded) {
+		return err
+	}
+
+	if payment.State == models.CAPTURED {
+		err = reverseCapture(ledgerStore, payment.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	payment.State = paymentSM.Current()
+	err = paymentStore.UpdatePayment(&payment)
+	if err != nil {
+		return err
+	}
+
+	payload := svcevents.PaymentRefundedEvent{ID: payment.ID, Amount: payment.Amount}
+	event := svcevents.PaymentRefunded.New(payment.ID, payload).CausedBy(cause)
+
+	return publisher.Publish(svcevents.PAYMENT_EVENTS_TOPIC, event)
+}
+
+func reverseCapture(ledgerStore models.LedgerStore, paymentID int) error {
+	captureEntries, err := ledgerStore.GetTransaction(ledger.CaptureTransactionID(paymentID))
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	entries := ledger.ReversalEntries(captureEntries)
+	return bookTransaction(ledgerStore, ledger.RefundTransactionID(paymentID), entries)
+}
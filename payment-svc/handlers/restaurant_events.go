@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/jackc/pgx/v5"
+)
+
+type RestaurantEventHandler struct {
+	payoutAccountStore models.PayoutAccountStore
+}
+
+func NewRestaurantEventHandler(payoutAccountStore models.PayoutAccountStore) *RestaurantEventHandler {
+	restaurantEventHandler := RestaurantEventHandler{
+		payoutAccountStore: payoutAccountStore,
+	}
+
+	return &restaurantEventHandler
+}
+
+func RegisterRestaurantEventHandlers(eventConsumer events.EventConsumer, restaurantEventHandler *RestaurantEventHandler) error {
+	err := svcevents.RestaurantCreated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantCreatedEvent)
+	if err != nil {
+		return err
+	}
+
+	return svcevents.RestaurantUpdated.Register(eventConsumer, restaurantEventHandler.HandleRestaurantUpdatedEvent)
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantCreatedEvent(event events.Event[events.RestaurantCreatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		payoutAccount := models.PayoutAccount{
+			AccountType: models.RESTAURANT_ACCOUNT,
+			OwnerID:     event.Payload.ID,
+			Name:        event.Payload.Name,
+			IBAN:        event.Payload.IBAN,
+		}
+
+		return r.payoutAccountStore.SetPayoutAccount(&payoutAccount)
+	})
+}
+
+func (r *RestaurantEventHandler) HandleRestaurantUpdatedEvent(event events.Event[events.RestaurantUpdatedEvent]) error {
+	return r.inTx(event.Context(), func(r *RestaurantEventHandler) error {
+		payoutAccount := models.PayoutAccount{
+			AccountType: models.RESTAURANT_ACCOUNT,
+			OwnerID:     event.Payload.ID,
+			Name:        event.Payload.Name,
+			IBAN:        event.Payload.IBAN,
+		}
+
+		return r.payoutAccountStore.SetPayoutAccount(&payoutAccount)
+	})
+}
+
+// inTx runs fn with a copy of the handler whose stores write through the
+// transaction the event is handled in, or a new one if there is none.
+func (r *RestaurantEventHandler) inTx(ctx context.Context, fn func(r *RestaurantEventHandler) error) error {
+	return pgconfig.RunInTx(ctx, r.payoutAccountStore, func(tx pgx.Tx) error {
+		txHandler := *r
+		txHandler.payoutAccountStore = pgconfig.WithTx(r.payoutAccountStore, tx)
+
+		return fn(&txHandler)
+	})
+}
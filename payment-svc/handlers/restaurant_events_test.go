@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestRestaurantEventHandler(t *testing.T) {
+	t.Run("sets restaurant payout account on RESTAURANT_CREATED event", func(t *testing.T) {
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		eventHandler := handlers.NewRestaurantEventHandler(payoutAccountStore)
+
+		payload := events.RestaurantCreatedEvent{ID: 1, Name: "Chicken Shack", IBAN: "DE89370400440532013000"}
+		event := events.NewTypedEvent(events.RESTAURANT_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleRestaurantCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := payoutAccountStore.GetPayoutAccount(models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, models.PayoutAccount{
+			AccountType: models.RESTAURANT_ACCOUNT,
+			OwnerID:     1,
+			Name:        payload.Name,
+			IBAN:        payload.IBAN,
+		})
+	})
+
+	t.Run("updates restaurant payout account on RESTAURANT_UPDATED event", func(t *testing.T) {
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		payoutAccountStore.SetPayoutAccount(&models.PayoutAccount{
+			AccountType: models.RESTAURANT_ACCOUNT,
+			OwnerID:     1,
+			Name:        "Chicken Shack",
+			IBAN:        "DE89370400440532013000",
+		})
+		eventHandler := handlers.NewRestaurantEventHandler(payoutAccountStore)
+
+		payload := events.RestaurantUpdatedEvent{ID: 1, Name: "Chicken Shack Mladost", IBAN: "BG80BNBG96611020345678"}
+		event := events.NewTypedEvent(events.RESTAURANT_UPDATED_EVENT_ID, payload.ID, payload)
+
+		err := eventHandler.HandleRestaurantUpdatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := payoutAccountStore.GetPayoutAccount(models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.Name, payload.Name)
+		testutil.AssertEqual(t, got.IBAN, payload.IBAN)
+	})
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/integrationutil"
+	"github.com/VitoNaychev/food-app/payment-svc/gateway"
+	"github.com/VitoNaychev/food-app/payment-svc/handlers"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/stubs"
+	"github.com/VitoNaychev/food-app/payment-svc/testdata"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestPaymentEventHandlersIntegration(t *testing.T) {
+	config := pgconfig.GetConfigFromEnv(env)
+	integrationutil.SetupDatabaseContainer(t, &config, "../sql-scripts/init.sql")
+
+	connStr := config.GetConnectionString()
+
+	paymentStore, err := models.NewPgPaymentStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	ledgerStore, err := models.NewPgLedgerStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	paymentGateway := gateway.NewFakeGateway()
+	publisher := &stubs.StubEventPublisher{}
+
+	orderEventHandler := handlers.NewOrderEventHandler(paymentStore, ledgerStore, paymentGateway, publisher)
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(paymentStore, ledgerStore, paymentGateway, publisher,
+		ledger.DefaultSplitConfig)
+
+	t.Run("authorizes payment", func(t *testing.T) {
+		payload := testdata.PeterOrderCreatedEvent
+		event := events.NewTypedEvent(svcevents.ORDER_CREATED_EVENT_ID, payload.ID, payload)
+
+		err := orderEventHandler.HandleOrderCreatedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := paymentStore.GetPaymentByID(testdata.PeterPayment.ID)
+		testutil.AssertNoErr(t, err)
+
+		want := testdata.PeterPayment
+		want.AuthorizationID = got.AuthorizationID
+		testutil.AssertEqual(t, got, want)
+	})
+
+	t.Run("captures payment", func(t *testing.T) {
+		payload := svcevents.DeliveryCompletedEvent{ID: testdata.PeterPayment.ID, CourierID: 1}
+		event := events.NewTypedEvent(svcevents.DELIVERY_COMPLETED_EVENT_ID, payload.ID, payload)
+
+		err := deliveryEventHandler.HandleDeliveryCompletedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := paymentStore.GetPaymentByID(testdata.PeterPayment.ID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.State, models.CAPTURED)
+
+		balance, err := ledgerStore.GetBalance(models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: got.RestaurantID})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, balance, 1000)
+	})
+
+	t.Run("refunds payment", func(t *testing.T) {
+		payload := svcevents.RefundRequestedEvent{ID: testdata.PeterPayment.ID}
+		event := events.NewTypedEvent(svcevents.REFUND_REQUESTED_EVENT_ID, payload.ID, payload)
+
+		err := orderEventHandler.HandleRefundRequestedEvent(event)
+		testutil.AssertNoErr(t, err)
+
+		got, err := paymentStore.GetPaymentByID(testdata.PeterPayment.ID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.State, models.REFUNDED)
+
+		balance, err := ledgerStore.GetBalance(models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: got.RestaurantID})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, balance, 0)
+	})
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/integrationutil"
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/payout"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestPayoutJobIntegration(t *testing.T) {
+	config := pgconfig.GetConfigFromEnv(env)
+	integrationutil.SetupDatabaseContainer(t, &config, "../sql-scripts/init.sql")
+
+	connStr := config.GetConnectionString()
+
+	ledgerStore, err := models.NewPgLedgerStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	payoutAccountStore, err := models.NewPgPayoutAccountStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	payoutBatchStore, err := models.NewPgPayoutBatchStore(context.Background(), connStr)
+	testutil.AssertNoErr(t, err)
+
+	payment := models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}
+	err = ledgerStore.CreateTransaction(ledger.CaptureTransactionID(payment.ID),
+		ledger.CaptureEntries(payment, 1, ledger.DefaultSplitConfig))
+	testutil.AssertNoErr(t, err)
+
+	err = payoutAccountStore.SetPayoutAccount(&models.PayoutAccount{
+		AccountType: models.RESTAURANT_ACCOUNT,
+		OwnerID:     1,
+		Name:        "Chicken Shack",
+		IBAN:        "DE89370400440532013000",
+	})
+	testutil.AssertNoErr(t, err)
+
+	payoutConfig := payout.Config{
+		OutputDir: t.TempDir(),
+		Debtor:    payout.Debtor{Name: "Food App", IBAN: "BG18RZBB91550123456789", BIC: "RZBBBGSF"},
+	}
+	job := payout.NewJob(ledgerStore, payoutAccountStore, payoutBatchStore, payoutConfig)
+
+	t.Run("creates payout batch", func(t *testing.T) {
+		batch, err := job.CreatePayoutBatch(time.Now())
+		testutil.AssertNoErr(t, err)
+
+		got, err := payoutBatchStore.GetPayoutBatchByID(batch.ID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, len(got.Items), 1)
+		testutil.AssertEqual(t, got.Items[0].Amount, 1000)
+
+		if got.ExportedAt == nil {
+			t.Errorf("didn't mark payout batch as exported")
+		}
+
+		balance, err := ledgerStore.GetBalance(models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1})
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, balance, 0)
+	})
+
+	t.Run("keeps balance of courier without payout account", func(t *testing.T) {
+		balances, err := ledgerStore.GetBalancesByAccountType(models.COURIER_ACCOUNT)
+		testutil.AssertNoErr(t, err)
+
+		want := []models.AccountBalance{{Account: models.Account{Type: models.COURIER_ACCOUNT, OwnerID: 1}, Balance: 125}}
+		testutil.AssertEqual(t, balances, want)
+	})
+}
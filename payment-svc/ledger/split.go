@@ -0,0 +1,73 @@
+package ledger
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+)
+
+type SplitConfig struct {
+	CommissionRate float64
+	CourierRate    float64
+}
+
+var DefaultSplitConfig = SplitConfig{
+	CommissionRate: 0.20,
+	CourierRate:    0.10,
+}
+
+func CaptureTransactionID(paymentID int) string {
+	return fmt.Sprintf("capture-%d", paymentID)
+}
+
+func RefundTransactionID(paymentID int) string {
+	return fmt.Sprintf("refund-%d", paymentID)
+}
+
+func PayoutTransactionID(batchID int) string {
+	return fmt.Sprintf("payout-%d", batchID)
+}
+
+func ToCents(amount float32) int64 {
+	return int64(math.Round(float64(amount) * 100))
+}
+
+// The restaurant is credited the order total minus the commission, the
+// courier gets their fee out of the commission and the platform keeps what
+// is left. Deliveries without a known courier leave the whole commission to
+// the platform.
+func CaptureEntries(payment models.Payment, courierID int, config SplitConfig) []models.LedgerEntry {
+	total := ToCents(payment.Amount)
+	commission := int64(math.Round(float64(total) * config.CommissionRate))
+
+	var courierShare int64
+	if courierID != 0 {
+		courierShare = min(int64(math.Round(float64(total)*config.CourierRate)), commission)
+	}
+
+	entries := []models.LedgerEntry{
+		{AccountType: models.CLEARING_ACCOUNT, Amount: -total},
+		{AccountType: models.RESTAURANT_ACCOUNT, OwnerID: payment.RestaurantID, Amount: total - commission},
+		{AccountType: models.PLATFORM_ACCOUNT, Amount: commission - courierShare},
+	}
+
+	if courierShare != 0 {
+		entries = append(entries, models.LedgerEntry{AccountType: models.COURIER_ACCOUNT, OwnerID: courierID, Amount: courierShare})
+	}
+
+	return entries
+}
+
+func ReversalEntries(entries []models.LedgerEntry) []models.LedgerEntry {
+	reversal := []models.LedgerEntry{}
+	for _, entry := range entries {
+		reversal = append(reversal, models.LedgerEntry{
+			AccountType: entry.AccountType,
+			OwnerID:     entry.OwnerID,
+			Amount:      -entry.Amount,
+		})
+	}
+
+	return reversal
+}
@@ -0,0 +1,56 @@
+package ledger_test
+
+import (
+	"testing"
+
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func TestCaptureEntries(t *testing.T) {
+	payment := models.Payment{ID: 1, RestaurantID: 3, Amount: 12.50}
+
+	t.Run("splits payment between restaurant, courier and platform", func(t *testing.T) {
+		got := ledger.CaptureEntries(payment, 2, ledger.DefaultSplitConfig)
+
+		want := []models.LedgerEntry{
+			{AccountType: models.CLEARING_ACCOUNT, Amount: -1250},
+			{AccountType: models.RESTAURANT_ACCOUNT, OwnerID: 3, Amount: 1000},
+			{AccountType: models.PLATFORM_ACCOUNT, Amount: 125},
+			{AccountType: models.COURIER_ACCOUNT, OwnerID: 2, Amount: 125},
+		}
+		testutil.AssertEqual(t, got, want)
+		testutil.AssertNoErr(t, models.ValidateTransaction(got))
+	})
+
+	t.Run("leaves courier share to platform without courier", func(t *testing.T) {
+		got := ledger.CaptureEntries(payment, 0, ledger.DefaultSplitConfig)
+
+		want := []models.LedgerEntry{
+			{AccountType: models.CLEARING_ACCOUNT, Amount: -1250},
+			{AccountType: models.RESTAURANT_ACCOUNT, OwnerID: 3, Amount: 1000},
+			{AccountType: models.PLATFORM_ACCOUNT, Amount: 250},
+		}
+		testutil.AssertEqual(t, got, want)
+	})
+
+	t.Run("balances amounts that don't split evenly", func(t *testing.T) {
+		payment := models.Payment{ID: 1, RestaurantID: 3, Amount: 9.99}
+
+		got := ledger.CaptureEntries(payment, 2, ledger.DefaultSplitConfig)
+
+		testutil.AssertNoErr(t, models.ValidateTransaction(got))
+	})
+
+	t.Run("reverses entries", func(t *testing.T) {
+		entries := ledger.CaptureEntries(payment, 2, ledger.DefaultSplitConfig)
+
+		got := ledger.ReversalEntries(entries)
+
+		for i := range entries {
+			testutil.AssertEqual(t, got[i].Amount, -entries[i].Amount)
+		}
+		testutil.AssertNoErr(t, models.ValidateTransaction(got))
+	})
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type InMemoryLedgerStore struct {
+	entries []LedgerEntry
+}
+
+func NewInMemoryLedgerStore() *InMemoryLedgerStore {
+	return &InMemoryLedgerStore{[]LedgerEntry{}}
+}
+
+func (i *InMemoryLedgerStore) CreateTransaction(transactionID string, entries []LedgerEntry) error {
+	err := ValidateTransaction(entries)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		entry.ID = len(i.entries) + 1
+		entry.TransactionID = transactionID
+		entry.CreatedAt = now
+		i.entries = append(i.entries, entry)
+	}
+
+	return nil
+}
+
+func (i *InMemoryLedgerStore) GetTransaction(transactionID string) ([]LedgerEntry, error) {
+	entries := []LedgerEntry{}
+	for _, entry := range i.entries {
+		if entry.TransactionID == transactionID {
+			entries = append(entries, entry)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, storeerrors.ErrNotFound
+	}
+
+	return entries, nil
+}
+
+func (i *InMemoryLedgerStore) GetBalance(account Account) (int64, error) {
+	var balance int64
+	for _, entry := range i.entries {
+		if entry.Account() == account {
+			balance += entry.Amount
+		}
+	}
+
+	return balance, nil
+}
+
+func (i *InMemoryLedgerStore) GetBalancesByAccountType(accountType AccountType) ([]AccountBalance, error) {
+	balances := map[int]int64{}
+	for _, entry := range i.entries {
+		if entry.AccountType == accountType {
+			balances[entry.OwnerID] += entry.Amount
+		}
+	}
+
+	accountBalances := []AccountBalance{}
+	for ownerID, balance := range balances {
+		account := Account{Type: accountType, OwnerID: ownerID}
+		accountBalances = append(accountBalances, AccountBalance{Account: account, Balance: balance})
+	}
+
+	sort.Slice(accountBalances, func(a, b int) bool {
+		return accountBalances[a].Account.OwnerID < accountBalances[b].Account.OwnerID
+	})
+
+	return accountBalances, nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import "github.com/VitoNaychev/food-app/storeerrors"
+
+type InMemoryPaymentStore struct {
+	payments []Payment
+}
+
+func NewInMemoryPaymentStore() *InMemoryPaymentStore {
+	return &InMemoryPaymentStore{[]Payment{}}
+}
+
+func (i *InMemoryPaymentStore) CreatePayment(payment *Payment) error {
+	i.payments = append(i.payments, *payment)
+
+	return nil
+}
+
+func (i *InMemoryPaymentStore) GetPaymentByID(id int) (Payment, error) {
+	for _, payment := range i.payments {
+		if payment.ID == id {
+			return payment, nil
+		}
+	}
+	return Payment{}, storeerrors.ErrNotFound
+}
+
+func (i *InMemoryPaymentStore) UpdatePayment(payment *Payment) error {
+	for j, oldPayment := range i.payments {
+		if oldPayment.ID == payment.ID {
+			i.payments[j] = *payment
+			return nil
+		}
+	}
+	return storeerrors.ErrNotFound
+}
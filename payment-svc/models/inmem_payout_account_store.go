@@ -0,0 +1,33 @@
+package models
+
+import "github.com/VitoNaychev/food-app/storeerrors"
+
+type InMemoryPayoutAccountStore struct {
+	payoutAccounts []PayoutAccount
+}
+
+func NewInMemoryPayoutAccountStore() *InMemoryPayoutAccountStore {
+	return &InMemoryPayoutAccountStore{[]PayoutAccount{}}
+}
+
+func (i *InMemoryPayoutAccountStore) SetPayoutAccount(payoutAccount *PayoutAccount) error {
+	for j, oldPayoutAccount := range i.payoutAccounts {
+		if oldPayoutAccount.AccountType == payoutAccount.AccountType && oldPayoutAccount.OwnerID == payoutAccount.OwnerID {
+			i.payoutAccounts[j] = *payoutAccount
+			return nil
+		}
+	}
+
+	i.payoutAccounts = append(i.payoutAccounts, *payoutAccount)
+	return nil
+}
+
+func (i *InMemoryPayoutAccountStore) GetPayoutAccount(account Account) (PayoutAccount, error) {
+	for _, payoutAccount := range i.payoutAccounts {
+		if payoutAccount.AccountType == account.Type && payoutAccount.OwnerID == account.OwnerID {
+			return payoutAccount, nil
+		}
+	}
+
+	return PayoutAccount{}, storeerrors.ErrNotFound
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type InMemoryPayoutBatchStore struct {
+	payoutBatches []PayoutBatch
+}
+
+func NewInMemoryPayoutBatchStore() *InMemoryPayoutBatchStore {
+	return &InMemoryPayoutBatchStore{[]PayoutBatch{}}
+}
+
+func (i *InMemoryPayoutBatchStore) CreatePayoutBatch(payoutBatch *PayoutBatch) error {
+	payoutBatch.ID = len(i.payoutBatches) + 1
+	for j := range payoutBatch.Items {
+		payoutBatch.Items[j].ID = j + 1
+		payoutBatch.Items[j].BatchID = payoutBatch.ID
+	}
+
+	i.payoutBatches = append(i.payoutBatches, *payoutBatch)
+
+	return nil
+}
+
+func (i *InMemoryPayoutBatchStore) GetPayoutBatchByID(id int) (PayoutBatch, error) {
+	for _, payoutBatch := range i.payoutBatches {
+		if payoutBatch.ID == id {
+			return payoutBatch, nil
+		}
+	}
+
+	return PayoutBatch{}, storeerrors.ErrNotFound
+}
+
+func (i *InMemoryPayoutBatchStore) GetUnexportedPayoutBatchIDs() ([]int, error) {
+	ids := []int{}
+	for _, payoutBatch := range i.payoutBatches {
+		if payoutBatch.ExportedAt == nil {
+			ids = append(ids, payoutBatch.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (i *InMemoryPayoutBatchStore) MarkPayoutBatchExported(id int, exportedAt time.Time) error {
+	for j := range i.payoutBatches {
+		if i.payoutBatches[j].ID == id {
+			i.payoutBatches[j].ExportedAt = &exportedAt
+			return nil
+		}
+	}
+
+	return storeerrors.ErrNotFound
+}
+
+func (i *InMemoryPayoutBatchStore) LockPayoutBatches() error {
+	return nil
+}
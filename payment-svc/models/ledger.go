@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type AccountType int
+
+const (
+	CLEARING_ACCOUNT AccountType = iota
+	PLATFORM_ACCOUNT
+	RESTAURANT_ACCOUNT
+	COURIER_ACCOUNT
+)
+
+var ErrUnbalancedTransaction = errors.New("ledger transaction entries don't sum up to zero")
+
+// The platform and clearing accounts have a single owner, which is
+// identified by owner ID 0.
+type Account struct {
+	Type    AccountType
+	OwnerID int
+}
+
+// Amounts are kept in cents. Money owed to an account is booked as a
+// positive amount, while money collected from customers is booked against
+// the clearing account as a negative one.
+type LedgerEntry struct {
+	ID            int
+	TransactionID string      `db:"transaction_id"`
+	AccountType   AccountType `db:"account_type"`
+	OwnerID       int         `db:"owner_id"`
+	Amount        int64
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+func (l LedgerEntry) Account() Account {
+	return Account{Type: l.AccountType, OwnerID: l.OwnerID}
+}
+
+type AccountBalance struct {
+	Account Account
+	Balance int64
+}
+
+func ValidateTransaction(entries []LedgerEntry) error {
+	var sum int64
+	for _, entry := range entries {
+		sum += entry.Amount
+	}
+
+	if len(entries) == 0 || sum != 0 {
+		return ErrUnbalancedTransaction
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package models
+
+type LedgerStore interface {
+	CreateTransaction(string, []LedgerEntry) error
+	GetTransaction(string) ([]LedgerEntry, error)
+	GetBalance(Account) (int64, error)
+	GetBalancesByAccountType(AccountType) ([]AccountBalance, error)
+}
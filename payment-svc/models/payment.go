@@ -0,0 +1,10 @@
+package models
+
+type Payment struct {
+	ID              int
+	CustomerID      int `db:"customer_id"`
+	RestaurantID    int `db:"restaurant_id"`
+	Amount          float32
+	State           PaymentState
+	AuthorizationID string `db:"authorization_id"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "github.com/VitoNaychev/food-app/sm"
+
+var paymentDeltas = []sm.Delta{
+	{Current: sm.State(AUTHORIZED), Event: sm.Event(CAPTURE_PAYMENT), Next: sm.State(CAPTURED), Predicate: nil, Callback: nil},
+	{Current: sm.State(AUTHORIZED), Event: sm.Event(REFUND_PAYMENT), Next: sm.State(REFUNDED), Predicate: nil, Callback: nil},
+	{Current: sm.State(CAPTURED), Event: sm.Event(REFUND_PAYMENT), Next: sm.State(REFUNDED), Predicate: nil, Callback: nil},
+}
+
+type PaymentSM struct {
+	sm sm.SM
+}
+
+func NewPaymentSM(initial PaymentState) PaymentSM {
+	sm := sm.New(sm.State(initial), paymentDeltas, nil)
+	return PaymentSM{sm}
+}
+
+func (p *PaymentSM) Exec(event PaymentEvent) error {
+	err := p.sm.Exec(sm.Event(event))
+	return err
+}
+
+func (p *PaymentSM) Current() PaymentState {
+	return PaymentState(p.sm.Current)
+}
@@ -0,0 +1,17 @@
+package models
+
+type PaymentState int
+
+const (
+	AUTHORIZED PaymentState = iota
+	AUTHORIZATION_FAILED
+	CAPTURED
+	REFUNDED
+)
+
+type PaymentEvent int
+
+const (
+	CAPTURE_PAYMENT PaymentEvent = iota
+	REFUND_PAYMENT
+)
@@ -0,0 +1,7 @@
+package models
+
+type PaymentStore interface {
+	CreatePayment(*Payment) error
+	GetPaymentByID(int) (Payment, error)
+	UpdatePayment(*Payment) error
+}
@@ -0,0 +1,8 @@
+package models
+
+type PayoutAccount struct {
+	AccountType AccountType `db:"account_type"`
+	OwnerID     int         `db:"owner_id"`
+	Name        string
+	IBAN        string
+}
@@ -0,0 +1,6 @@
+package models
+
+type PayoutAccountStore interface {
+	SetPayoutAccount(*PayoutAccount) error
+	GetPayoutAccount(Account) (PayoutAccount, error)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// A batch is booked before its pain.001 file is written, so ExportedAt is
+// nil until the file has been written.
+type PayoutBatch struct {
+	ID         int
+	CreatedAt  time.Time    `db:"created_at"`
+	ExportedAt *time.Time   `db:"exported_at"`
+	Items      []PayoutItem `db:"-"`
+}
+
+func (p PayoutBatch) Total() int64 {
+	var total int64
+	for _, item := range p.Items {
+		total += item.Amount
+	}
+
+	return total
+}
+
+// A payout item is a single transfer to an IBAN. Balances of all accounts
+// sharing the IBAN are paid out together.
+type PayoutItem struct {
+	ID       int
+	BatchID  int `db:"batch_id"`
+	Name     string
+	IBAN     string
+	Amount   int64
+	Accounts []AccountBalance `db:"-"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type PayoutBatchStore interface {
+	CreatePayoutBatch(*PayoutBatch) error
+	GetPayoutBatchByID(int) (PayoutBatch, error)
+	GetUnexportedPayoutBatchIDs() ([]int, error)
+	MarkPayoutBatchExported(id int, exportedAt time.Time) error
+	// LockPayoutBatches blocks until no other transaction is booking a payout
+	// batch and holds the lock until the calling transaction ends.
+	LockPayoutBatches() error
+}
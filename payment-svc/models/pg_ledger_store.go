@@ -0,0 +1,126 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgLedgerStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgLedgerStore(ctx context.Context, connString string) (*PgLedgerStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	pgLedgerStore := PgLedgerStore{conn}
+
+	return &pgLedgerStore, nil
+}
+
+func (p *PgLedgerStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgLedgerStore) WithTx(tx pgx.Tx) LedgerStore {
+	return &PgLedgerStore{tx}
+}
+
+func (p *PgLedgerStore) CreateTransaction(transactionID string, entries []LedgerEntry) error {
+	err := ValidateTransaction(entries)
+	if err != nil {
+		return err
+	}
+
+	query := `insert into ledger_entries(transaction_id, account_type, owner_id, amount) 
+	values (@transaction_id, @account_type, @owner_id, @amount)`
+
+	tx, err := p.conn.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	for _, entry := range entries {
+		args := pgx.NamedArgs{
+			"transaction_id": transactionID,
+			"account_type":   entry.AccountType,
+			"owner_id":       entry.OwnerID,
+			"amount":         entry.Amount,
+		}
+
+		_, err = tx.Exec(context.Background(), query, args)
+		if err != nil {
+			return storeerrors.FromPgxError(err)
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	return nil
+}
+
+func (p *PgLedgerStore) GetTransaction(transactionID string) ([]LedgerEntry, error) {
+	query := `select * from ledger_entries where transaction_id=@transaction_id order by id`
+	args := pgx.NamedArgs{
+		"transaction_id": transactionID,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[LedgerEntry])
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	if len(entries) == 0 {
+		return nil, storeerrors.ErrNotFound
+	}
+
+	return entries, nil
+}
+
+func (p *PgLedgerStore) GetBalance(account Account) (int64, error) {
+	query := `select coalesce(sum(amount), 0) from ledger_entries where account_type=@account_type and owner_id=@owner_id`
+	args := pgx.NamedArgs{
+		"account_type": account.Type,
+		"owner_id":     account.OwnerID,
+	}
+
+	var balance int64
+	err := p.conn.QueryRow(context.Background(), query, args).Scan(&balance)
+	if err != nil {
+		return 0, storeerrors.FromPgxError(err)
+	}
+
+	return balance, nil
+}
+
+func (p *PgLedgerStore) GetBalancesByAccountType(accountType AccountType) ([]AccountBalance, error) {
+	query := `select owner_id, sum(amount) from ledger_entries where account_type=@account_type 
+	group by owner_id order by owner_id`
+	args := pgx.NamedArgs{
+		"account_type": accountType,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), query, args)
+	accountBalances, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (AccountBalance, error) {
+		accountBalance := AccountBalance{Account: Account{Type: accountType}}
+		err := row.Scan(&accountBalance.Account.OwnerID, &accountBalance.Balance)
+		return accountBalance, err
+	})
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return accountBalances, nil
+}
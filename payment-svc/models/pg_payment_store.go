@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgPaymentStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgPaymentStore(ctx context.Context, connString string) (*PgPaymentStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	pgPaymentStore := PgPaymentStore{conn}
+
+	return &pgPaymentStore, nil
+}
+
+func (p *PgPaymentStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgPaymentStore) WithTx(tx pgx.Tx) PaymentStore {
+	return &PgPaymentStore{tx}
+}
+
+func (p *PgPaymentStore) CreatePayment(payment *Payment) error {
+	query := `insert into payments(id, customer_id, restaurant_id, amount, state, authorization_id) 
+	values (@id, @customer_id, @restaurant_id, @amount, @state, @authorization_id)`
+
+	args := pgx.NamedArgs{
+		"id":               payment.ID,
+		"customer_id":      payment.CustomerID,
+		"restaurant_id":    payment.RestaurantID,
+		"amount":           payment.Amount,
+		"state":            payment.State,
+		"authorization_id": payment.AuthorizationID,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgPaymentStore) GetPaymentByID(id int) (Payment, error) {
+	query := `select * from payments where id=@id`
+	args := pgx.NamedArgs{
+		"id": id,
+	}
+
+	row, _ := p.conn.Query(context.Background(), query, args)
+	payment, err := pgx.CollectOneRow(row, pgx.RowToStructByName[Payment])
+
+	if err != nil {
+		return Payment{}, storeerrors.FromPgxError(err)
+	}
+
+	return payment, nil
+}
+
+func (p *PgPaymentStore) UpdatePayment(payment *Payment) error {
+	query := `update payments set state=@state, amount=@amount, authorization_id=@authorization_id where id=@id`
+	args := pgx.NamedArgs{
+		"id":               payment.ID,
+		"amount":           payment.Amount,
+		"state":            payment.State,
+		"authorization_id": payment.AuthorizationID,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+
+	return storeerrors.FromPgxError(err)
+}
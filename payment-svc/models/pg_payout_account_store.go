@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgPayoutAccountStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgPayoutAccountStore(ctx context.Context, connString string) (*PgPayoutAccountStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	pgPayoutAccountStore := PgPayoutAccountStore{conn}
+
+	return &pgPayoutAccountStore, nil
+}
+
+func (p *PgPayoutAccountStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgPayoutAccountStore) WithTx(tx pgx.Tx) PayoutAccountStore {
+	return &PgPayoutAccountStore{tx}
+}
+
+func (p *PgPayoutAccountStore) SetPayoutAccount(payoutAccount *PayoutAccount) error {
+	query := `insert into payout_accounts(account_type, owner_id, name, iban) 
+	values (@account_type, @owner_id, @name, @iban)
+	on conflict (account_type, owner_id) do update set name=@name, iban=@iban`
+
+	args := pgx.NamedArgs{
+		"account_type": payoutAccount.AccountType,
+		"owner_id":     payoutAccount.OwnerID,
+		"name":         payoutAccount.Name,
+		"iban":         payoutAccount.IBAN,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+func (p *PgPayoutAccountStore) GetPayoutAccount(account Account) (PayoutAccount, error) {
+	query := `select * from payout_accounts where account_type=@account_type and owner_id=@owner_id`
+	args := pgx.NamedArgs{
+		"account_type": account.Type,
+		"owner_id":     account.OwnerID,
+	}
+
+	row, _ := p.conn.Query(context.Background(), query, args)
+	payoutAccount, err := pgx.CollectOneRow(row, pgx.RowToStructByName[PayoutAccount])
+
+	if err != nil {
+		return PayoutAccount{}, storeerrors.FromPgxError(err)
+	}
+
+	return payoutAccount, nil
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+type PgPayoutBatchStore struct {
+	conn pgconfig.Executor
+}
+
+func NewPgPayoutBatchStore(ctx context.Context, connString string) (*PgPayoutBatchStore, error) {
+	conn, err := pgconfig.Connect(ctx, connString)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to database: %w", err)
+	}
+
+	pgPayoutBatchStore := PgPayoutBatchStore{conn}
+
+	return &pgPayoutBatchStore, nil
+}
+
+func (p *PgPayoutBatchStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgPayoutBatchStore) WithTx(tx pgx.Tx) PayoutBatchStore {
+	return &PgPayoutBatchStore{tx}
+}
+
+func (p *PgPayoutBatchStore) CreatePayoutBatch(payoutBatch *PayoutBatch) error {
+	createBatchQuery := `insert into payout_batches(created_at) values (@created_at) returning id`
+	createBatchArgs := pgx.NamedArgs{
+		"created_at": payoutBatch.CreatedAt,
+	}
+
+	createItemQuery := `insert into payout_items(batch_id, name, iban, amount) 
+	values (@batch_id, @name, @iban, @amount) returning id`
+
+	tx, err := p.conn.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), createBatchQuery, createBatchArgs).Scan(&payoutBatch.ID)
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	for i := range payoutBatch.Items {
+		item := &payoutBatch.Items[i]
+		item.BatchID = payoutBatch.ID
+
+		createItemArgs := pgx.NamedArgs{
+			"batch_id": item.BatchID,
+			"name":     item.Name,
+			"iban":     item.IBAN,
+			"amount":   item.Amount,
+		}
+
+		err = tx.QueryRow(context.Background(), createItemQuery, createItemArgs).Scan(&item.ID)
+		if err != nil {
+			return storeerrors.FromPgxError(err)
+		}
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return storeerrors.FromPgxError(err)
+	}
+
+	return nil
+}
+
+func (p *PgPayoutBatchStore) GetPayoutBatchByID(id int) (PayoutBatch, error) {
+	batchQuery := `select * from payout_batches where id=@id`
+	batchArgs := pgx.NamedArgs{
+		"id": id,
+	}
+
+	row, _ := p.conn.Query(context.Background(), batchQuery, batchArgs)
+	payoutBatch, err := pgx.CollectOneRow(row, pgx.RowToStructByName[PayoutBatch])
+	if err != nil {
+		return PayoutBatch{}, storeerrors.FromPgxError(err)
+	}
+
+	itemsQuery := `select * from payout_items where batch_id=@batch_id order by id`
+	itemsArgs := pgx.NamedArgs{
+		"batch_id": id,
+	}
+
+	rows, _ := p.conn.Query(context.Background(), itemsQuery, itemsArgs)
+	payoutBatch.Items, err = pgx.CollectRows(rows, pgx.RowToStructByName[PayoutItem])
+	if err != nil {
+		return PayoutBatch{}, storeerrors.FromPgxError(err)
+	}
+
+	return payoutBatch, nil
+}
+
+func (p *PgPayoutBatchStore) GetUnexportedPayoutBatchIDs() ([]int, error) {
+	query := `select id from payout_batches where exported_at is null order by id`
+
+	rows, _ := p.conn.Query(context.Background(), query)
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, storeerrors.FromPgxError(err)
+	}
+
+	return ids, nil
+}
+
+func (p *PgPayoutBatchStore) MarkPayoutBatchExported(id int, exportedAt time.Time) error {
+	query := `update payout_batches set exported_at=@exported_at where id=@id`
+	args := pgx.NamedArgs{
+		"id":          id,
+		"exported_at": exportedAt,
+	}
+
+	_, err := p.conn.Exec(context.Background(), query, args)
+	return storeerrors.FromPgxError(err)
+}
+
+// The advisory lock is transaction scoped, so the store has to be bound to
+// the transaction that books the batch.
+func (p *PgPayoutBatchStore) LockPayoutBatches() error {
+	_, err := p.conn.Exec(context.Background(), `select pg_advisory_xact_lock(hashtext('payout_batches'))`)
+	return storeerrors.FromPgxError(err)
+}
@@ -0,0 +1,236 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/pgconfig"
+	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrNothingToPay = errors.New("no positive balances with a payout account")
+
+type Config struct {
+	Interval  time.Duration
+	OutputDir string
+	Debtor    Debtor
+}
+
+type Job struct {
+	ledgerStore        models.LedgerStore
+	payoutAccountStore models.PayoutAccountStore
+	payoutBatchStore   models.PayoutBatchStore
+	config             Config
+}
+
+func NewJob(ledgerStore models.LedgerStore, payoutAccountStore models.PayoutAccountStore,
+	payoutBatchStore models.PayoutBatchStore, config Config) *Job {
+	job := Job{
+		ledgerStore:        ledgerStore,
+		payoutAccountStore: payoutAccountStore,
+		payoutBatchStore:   payoutBatchStore,
+		config:             config,
+	}
+
+	return &job
+}
+
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			j.ExportUnexportedPayoutBatches()
+
+			batch, err := j.CreatePayoutBatch(now)
+			if errors.Is(err, ErrNothingToPay) {
+				continue
+			} else if err != nil {
+				log.Printf("payout batch error: %v\n", err)
+				continue
+			}
+
+			log.Printf("created payout batch %d with %d transfers\n", batch.ID, len(batch.Items))
+		}
+	}
+}
+
+// CreatePayoutBatch pays out the positive balances of all restaurant and
+// courier accounts. Balances of accounts without a payout account are kept
+// until one is set. The batch and its ledger entries are booked together,
+// and a batch whose file couldn't be written stays unexported until a later
+// run exports it.
+func (j *Job) CreatePayoutBatch(now time.Time) (models.PayoutBatch, error) {
+	batch, err := j.bookPayoutBatch(now)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	err = j.ExportPayoutBatch(batch.ID)
+	if err != nil {
+		return models.PayoutBatch{}, fmt.Errorf("payout batch %d was booked but not exported: %w", batch.ID, err)
+	}
+
+	return batch, nil
+}
+
+// ExportPayoutBatch writes the stored batch as a pain.001 file, replacing
+// the file of an earlier export, and marks the batch as exported.
+func (j *Job) ExportPayoutBatch(batchID int) error {
+	batch, err := j.payoutBatchStore.GetPayoutBatchByID(batchID)
+	if err != nil {
+		return err
+	}
+
+	err = j.writePain001(batch)
+	if err != nil {
+		return err
+	}
+
+	return j.payoutBatchStore.MarkPayoutBatchExported(batchID, time.Now())
+}
+
+// ExportUnexportedPayoutBatches exports the batches whose export failed,
+// including those booked before the service was restarted.
+func (j *Job) ExportUnexportedPayoutBatches() {
+	batchIDs, err := j.payoutBatchStore.GetUnexportedPayoutBatchIDs()
+	if err != nil {
+		log.Printf("payout batch export error: %v\n", err)
+		return
+	}
+
+	for _, batchID := range batchIDs {
+		err := j.ExportPayoutBatch(batchID)
+		if err != nil {
+			log.Printf("payout batch %d export error: %v\n", batchID, err)
+			continue
+		}
+
+		log.Printf("exported payout batch %d\n", batchID)
+	}
+}
+
+func (j *Job) bookPayoutBatch(now time.Time) (models.PayoutBatch, error) {
+	var batch models.PayoutBatch
+
+	err := pgconfig.RunInTx(context.Background(), j.payoutBatchStore, func(tx pgx.Tx) error {
+		txJob := *j
+		txJob.ledgerStore = pgconfig.WithTx(j.ledgerStore, tx)
+		txJob.payoutBatchStore = pgconfig.WithTx(j.payoutBatchStore, tx)
+
+		// Balances are read before they are debited, so concurrent runs
+		// would pay out the same balances twice.
+		err := txJob.payoutBatchStore.LockPayoutBatches()
+		if err != nil {
+			return err
+		}
+
+		items, err := txJob.collectPayoutItems()
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			return ErrNothingToPay
+		}
+
+		batch = models.PayoutBatch{CreatedAt: now, Items: items}
+		err = txJob.payoutBatchStore.CreatePayoutBatch(&batch)
+		if err != nil {
+			return err
+		}
+
+		return txJob.ledgerStore.CreateTransaction(ledger.PayoutTransactionID(batch.ID), payoutEntries(batch))
+	})
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	return batch, nil
+}
+
+func (j *Job) collectPayoutItems() ([]models.PayoutItem, error) {
+	itemsByIBAN := map[string]*models.PayoutItem{}
+
+	for _, accountType := range []models.AccountType{models.RESTAURANT_ACCOUNT, models.COURIER_ACCOUNT} {
+		balances, err := j.ledgerStore.GetBalancesByAccountType(accountType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, balance := range balances {
+			if balance.Balance <= 0 {
+				continue
+			}
+
+			payoutAccount, err := j.payoutAccountStore.GetPayoutAccount(balance.Account)
+			if errors.Is(err, storeerrors.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			item, ok := itemsByIBAN[payoutAccount.IBAN]
+			if !ok {
+				item = &models.PayoutItem{Name: payoutAccount.Name, IBAN: payoutAccount.IBAN}
+				itemsByIBAN[payoutAccount.IBAN] = item
+			}
+
+			item.Amount += balance.Balance
+			item.Accounts = append(item.Accounts, balance)
+		}
+	}
+
+	items := []models.PayoutItem{}
+	for _, item := range itemsByIBAN {
+		items = append(items, *item)
+	}
+
+	sort.Slice(items, func(a, b int) bool {
+		return items[a].IBAN < items[b].IBAN
+	})
+
+	return items, nil
+}
+
+func payoutEntries(batch models.PayoutBatch) []models.LedgerEntry {
+	entries := []models.LedgerEntry{}
+	for _, item := range batch.Items {
+		for _, balance := range item.Accounts {
+			entries = append(entries, models.LedgerEntry{
+				AccountType: balance.Account.Type,
+				OwnerID:     balance.Account.OwnerID,
+				Amount:      -balance.Balance,
+			})
+		}
+	}
+
+	entries = append(entries, models.LedgerEntry{AccountType: models.CLEARING_ACCOUNT, Amount: batch.Total()})
+
+	return entries
+}
+
+func (j *Job) writePain001(batch models.PayoutBatch) error {
+	path := filepath.Join(j.config.OutputDir, fmt.Sprintf("payout-%d.xml", batch.ID))
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return WritePain001(file, batch, j.config.Debtor)
+}
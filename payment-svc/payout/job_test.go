@@ -0,0 +1,198 @@
+package payout_test
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/payment-svc/ledger"
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/payment-svc/payout"
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+var debtor = payout.Debtor{
+	Name: "Food App",
+	IBAN: "BG18RZBB91550123456789",
+	BIC:  "RZBBBGSF",
+}
+
+func TestCreatePayoutBatch(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("pays out balances aggregated per IBAN", func(t *testing.T) {
+		ledgerStore := models.NewInMemoryLedgerStore()
+		captureOrder(t, ledgerStore, models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}, 1)
+		captureOrder(t, ledgerStore, models.Payment{ID: 2, RestaurantID: 2, Amount: 20.00}, 1)
+
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		// Both restaurants are run by the same owner.
+		setPayoutAccount(t, payoutAccountStore, models.RESTAURANT_ACCOUNT, 1, "Chicken Shack", "DE89370400440532013000")
+		setPayoutAccount(t, payoutAccountStore, models.RESTAURANT_ACCOUNT, 2, "Chicken Shack", "DE89370400440532013000")
+		setPayoutAccount(t, payoutAccountStore, models.COURIER_ACCOUNT, 1, "Alice", "BG80BNBG96611020345678")
+
+		config := payout.Config{OutputDir: t.TempDir(), Debtor: debtor}
+		job := payout.NewJob(ledgerStore, payoutAccountStore, models.NewInMemoryPayoutBatchStore(), config)
+
+		batch, err := job.CreatePayoutBatch(now)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, len(batch.Items), 2)
+		testutil.AssertEqual(t, batch.Items[0].IBAN, "BG80BNBG96611020345678")
+		testutil.AssertEqual(t, batch.Items[0].Amount, 325)
+		testutil.AssertEqual(t, batch.Items[1].IBAN, "DE89370400440532013000")
+		testutil.AssertEqual(t, batch.Items[1].Amount, 2600)
+
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1}, 0)
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 2}, 0)
+		assertBalance(t, ledgerStore, models.Account{Type: models.COURIER_ACCOUNT, OwnerID: 1}, 0)
+		assertBalance(t, ledgerStore, models.Account{Type: models.PLATFORM_ACCOUNT}, 325)
+	})
+
+	t.Run("keeps balances of accounts without payout account", func(t *testing.T) {
+		ledgerStore := models.NewInMemoryLedgerStore()
+		captureOrder(t, ledgerStore, models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}, 1)
+
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		setPayoutAccount(t, payoutAccountStore, models.COURIER_ACCOUNT, 1, "Alice", "BG80BNBG96611020345678")
+
+		config := payout.Config{OutputDir: t.TempDir(), Debtor: debtor}
+		job := payout.NewJob(ledgerStore, payoutAccountStore, models.NewInMemoryPayoutBatchStore(), config)
+
+		batch, err := job.CreatePayoutBatch(now)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, len(batch.Items), 1)
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1}, 1000)
+	})
+
+	t.Run("returns ErrNothingToPay on empty balances", func(t *testing.T) {
+		config := payout.Config{OutputDir: t.TempDir(), Debtor: debtor}
+		job := payout.NewJob(models.NewInMemoryLedgerStore(), models.NewInMemoryPayoutAccountStore(),
+			models.NewInMemoryPayoutBatchStore(), config)
+
+		_, err := job.CreatePayoutBatch(now)
+
+		testutil.AssertError(t, err, payout.ErrNothingToPay)
+	})
+
+	t.Run("writes batch as pain.001 file", func(t *testing.T) {
+		ledgerStore := models.NewInMemoryLedgerStore()
+		captureOrder(t, ledgerStore, models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}, 1)
+
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		setPayoutAccount(t, payoutAccountStore, models.RESTAURANT_ACCOUNT, 1, "Chicken Shack", "DE89370400440532013000")
+		setPayoutAccount(t, payoutAccountStore, models.COURIER_ACCOUNT, 1, "Alice", "BG80BNBG96611020345678")
+
+		config := payout.Config{OutputDir: t.TempDir(), Debtor: debtor}
+		job := payout.NewJob(ledgerStore, payoutAccountStore, models.NewInMemoryPayoutBatchStore(), config)
+
+		batch, err := job.CreatePayoutBatch(now)
+		testutil.AssertNoErr(t, err)
+
+		content, err := os.ReadFile(filepath.Join(config.OutputDir, "payout-1.xml"))
+		testutil.AssertNoErr(t, err)
+
+		var document struct {
+			XMLName  xml.Name
+			CtrlSum  string   `xml:"CstmrCdtTrfInitn>GrpHdr>CtrlSum"`
+			NbOfTxs  int      `xml:"CstmrCdtTrfInitn>GrpHdr>NbOfTxs"`
+			DbtrIBAN string   `xml:"CstmrCdtTrfInitn>PmtInf>DbtrAcct>Id>IBAN"`
+			Amounts  []string `xml:"CstmrCdtTrfInitn>PmtInf>CdtTrfTxInf>Amt>InstdAmt"`
+			IBANs    []string `xml:"CstmrCdtTrfInitn>PmtInf>CdtTrfTxInf>CdtrAcct>Id>IBAN"`
+		}
+		err = xml.Unmarshal(content, &document)
+		testutil.AssertNoErr(t, err)
+
+		testutil.AssertEqual(t, document.XMLName.Space, "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03")
+		testutil.AssertEqual(t, document.NbOfTxs, len(batch.Items))
+		testutil.AssertEqual(t, document.CtrlSum, "11.25")
+		testutil.AssertEqual(t, document.DbtrIBAN, debtor.IBAN)
+		testutil.AssertEqual(t, document.Amounts, []string{"1.25", "10.00"})
+		testutil.AssertEqual(t, document.IBANs, []string{"BG80BNBG96611020345678", "DE89370400440532013000"})
+	})
+	t.Run("books batch whose file couldn't be written and exports it again", func(t *testing.T) {
+		ledgerStore := models.NewInMemoryLedgerStore()
+		captureOrder(t, ledgerStore, models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}, 1)
+
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		setPayoutAccount(t, payoutAccountStore, models.RESTAURANT_ACCOUNT, 1, "Chicken Shack", "DE89370400440532013000")
+
+		config := payout.Config{OutputDir: filepath.Join(t.TempDir(), "missing"), Debtor: debtor}
+		job := payout.NewJob(ledgerStore, payoutAccountStore, models.NewInMemoryPayoutBatchStore(), config)
+
+		_, err := job.CreatePayoutBatch(now)
+		if err == nil {
+			t.Fatalf("expected error but didn't get one")
+		}
+
+		assertBalance(t, ledgerStore, models.Account{Type: models.RESTAURANT_ACCOUNT, OwnerID: 1}, 0)
+
+		err = os.Mkdir(config.OutputDir, 0o755)
+		testutil.AssertNoErr(t, err)
+
+		err = job.ExportPayoutBatch(1)
+		testutil.AssertNoErr(t, err)
+
+		_, err = os.Stat(filepath.Join(config.OutputDir, "payout-1.xml"))
+		testutil.AssertNoErr(t, err)
+	})
+
+	t.Run("exports batches left unexported by an earlier job", func(t *testing.T) {
+		ledgerStore := models.NewInMemoryLedgerStore()
+		captureOrder(t, ledgerStore, models.Payment{ID: 1, RestaurantID: 1, Amount: 12.50}, 1)
+
+		payoutAccountStore := models.NewInMemoryPayoutAccountStore()
+		setPayoutAccount(t, payoutAccountStore, models.RESTAURANT_ACCOUNT, 1, "Chicken Shack", "DE89370400440532013000")
+
+		payoutBatchStore := models.NewInMemoryPayoutBatchStore()
+
+		config := payout.Config{OutputDir: filepath.Join(t.TempDir(), "missing"), Debtor: debtor}
+		job := payout.NewJob(ledgerStore, payoutAccountStore, payoutBatchStore, config)
+
+		_, err := job.CreatePayoutBatch(now)
+		if err == nil {
+			t.Fatalf("expected error but didn't get one")
+		}
+
+		err = os.Mkdir(config.OutputDir, 0o755)
+		testutil.AssertNoErr(t, err)
+
+		restartedJob := payout.NewJob(ledgerStore, payoutAccountStore, payoutBatchStore, config)
+		restartedJob.ExportUnexportedPayoutBatches()
+
+		_, err = os.Stat(filepath.Join(config.OutputDir, "payout-1.xml"))
+		testutil.AssertNoErr(t, err)
+
+		batchIDs, err := payoutBatchStore.GetUnexportedPayoutBatchIDs()
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, batchIDs, []int{})
+	})
+}
+
+func captureOrder(t testing.TB, ledgerStore models.LedgerStore, payment models.Payment, courierID int) {
+	t.Helper()
+
+	entries := ledger.CaptureEntries(payment, courierID, ledger.DefaultSplitConfig)
+	err := ledgerStore.CreateTransaction(ledger.CaptureTransactionID(payment.ID), entries)
+	testutil.AssertNoErr(t, err)
+}
+
+func setPayoutAccount(t testing.TB, payoutAccountStore models.PayoutAccountStore, accountType models.AccountType,
+	ownerID int, name, iban string) {
+	t.Helper()
+
+	payoutAccount := models.PayoutAccount{AccountType: accountType, OwnerID: ownerID, Name: name, IBAN: iban}
+	err := payoutAccountStore.SetPayoutAccount(&payoutAccount)
+	testutil.AssertNoErr(t, err)
+}
+
+func assertBalance(t testing.TB, ledgerStore models.LedgerStore, account models.Account, want int64) {
+	t.Helper()
+
+	got, err := ledgerStore.GetBalance(account)
+	testutil.AssertNoErr(t, err)
+	testutil.AssertEqual(t, got, want)
+}
@@ -0,0 +1,126 @@
+package payout
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+)
+
+const pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+type Debtor struct {
+	Name string
+	IBAN string
+	BIC  string
+}
+
+type pain001Document struct {
+	XMLName    xml.Name          `xml:"Document"`
+	Xmlns      string            `xml:"xmlns,attr"`
+	Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	GroupHeader pain001GroupHeader `xml:"GrpHdr"`
+	PaymentInfo pain001PaymentInfo `xml:"PmtInf"`
+}
+
+type pain001GroupHeader struct {
+	MessageID            string       `xml:"MsgId"`
+	CreationDateTime     string       `xml:"CreDtTm"`
+	NumberOfTransactions int          `xml:"NbOfTxs"`
+	ControlSum           string       `xml:"CtrlSum"`
+	InitiatingParty      pain001Party `xml:"InitgPty"`
+}
+
+type pain001PaymentInfo struct {
+	PaymentInfoID          string                  `xml:"PmtInfId"`
+	PaymentMethod          string                  `xml:"PmtMtd"`
+	NumberOfTransactions   int                     `xml:"NbOfTxs"`
+	ControlSum             string                  `xml:"CtrlSum"`
+	ServiceLevel           string                  `xml:"PmtTpInf>SvcLvl>Cd"`
+	RequestedExecutionDate string                  `xml:"ReqdExctnDt"`
+	Debtor                 pain001Party            `xml:"Dbtr"`
+	DebtorIBAN             string                  `xml:"DbtrAcct>Id>IBAN"`
+	DebtorBIC              string                  `xml:"DbtrAgt>FinInstnId>BIC"`
+	ChargeBearer           string                  `xml:"ChrgBr"`
+	CreditTransfers        []pain001CreditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type pain001Party struct {
+	Name string `xml:"Nm"`
+}
+
+type pain001CreditTransfer struct {
+	EndToEndID   string        `xml:"PmtId>EndToEndId"`
+	Amount       pain001Amount `xml:"Amt>InstdAmt"`
+	Creditor     pain001Party  `xml:"Cdtr"`
+	CreditorIBAN string        `xml:"CdtrAcct>Id>IBAN"`
+	Remittance   string        `xml:"RmtInf>Ustrd"`
+}
+
+type pain001Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+func formatAmount(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// WritePain001 writes the batch as a SEPA credit transfer initiation, with
+// the platform as the debtor and one credit transfer per payout item.
+func WritePain001(w io.Writer, batch models.PayoutBatch, debtor Debtor) error {
+	messageID := fmt.Sprintf("PAYOUT-%d", batch.ID)
+	controlSum := formatAmount(batch.Total())
+
+	creditTransfers := []pain001CreditTransfer{}
+	for _, item := range batch.Items {
+		creditTransfers = append(creditTransfers, pain001CreditTransfer{
+			EndToEndID:   fmt.Sprintf("PAYOUT-%d-%d", batch.ID, item.ID),
+			Amount:       pain001Amount{Currency: "EUR", Value: formatAmount(item.Amount)},
+			Creditor:     pain001Party{Name: item.Name},
+			CreditorIBAN: item.IBAN,
+			Remittance:   fmt.Sprintf("Payout %d", batch.ID),
+		})
+	}
+
+	document := pain001Document{
+		Xmlns: pain001Namespace,
+		Initiation: pain001Initiation{
+			GroupHeader: pain001GroupHeader{
+				MessageID:            messageID,
+				CreationDateTime:     batch.CreatedAt.UTC().Format(time.RFC3339),
+				NumberOfTransactions: len(batch.Items),
+				ControlSum:           controlSum,
+				InitiatingParty:      pain001Party{Name: debtor.Name},
+			},
+			PaymentInfo: pain001PaymentInfo{
+				PaymentInfoID:          messageID,
+				PaymentMethod:          "TRF",
+				NumberOfTransactions:   len(batch.Items),
+				ControlSum:             controlSum,
+				ServiceLevel:           "SEPA",
+				RequestedExecutionDate: batch.CreatedAt.UTC().Format(time.DateOnly),
+				Debtor:                 pain001Party{Name: debtor.Name},
+				DebtorIBAN:             debtor.IBAN,
+				DebtorBIC:              debtor.BIC,
+				ChargeBearer:           "SLEV",
+				CreditTransfers:        creditTransfers,
+			},
+		},
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	return encoder.Encode(document)
+}
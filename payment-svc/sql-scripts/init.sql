@@ -0,0 +1,65 @@
+DROP TABLE IF EXISTS inbox;
+DROP TABLE IF EXISTS outbox;
+DROP TABLE IF EXISTS payout_items;
+DROP TABLE IF EXISTS payout_batches;
+DROP TABLE IF EXISTS payout_accounts;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS payments;
+
+CREATE TABLE payments (
+    id                 int               PRIMARY KEY,
+    customer_id        int               NOT NULL,
+    restaurant_id      int               NOT NULL,
+    amount             numeric(8, 2)     NOT NULL,
+    state              int               NOT NULL,
+    authorization_id   varchar(64)       NOT NULL
+);
+
+CREATE TABLE ledger_entries (
+    id                 serial            PRIMARY KEY,
+    transaction_id     varchar(64)       NOT NULL,
+    account_type       int               NOT NULL,
+    owner_id           int               NOT NULL,
+    amount             bigint            NOT NULL,
+    created_at         timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ledger_entries_transaction_id_idx ON ledger_entries (transaction_id);
+CREATE INDEX ledger_entries_account_idx ON ledger_entries (account_type, owner_id);
+
+CREATE TABLE payout_accounts (
+    account_type       int               NOT NULL,
+    owner_id           int               NOT NULL,
+    name               varchar(40)       NOT NULL,
+    iban               varchar(34)       NOT NULL,
+    PRIMARY KEY (account_type, owner_id)
+);
+
+CREATE TABLE payout_batches (
+    id                 serial            PRIMARY KEY,
+    created_at         timestamp with time zone NOT NULL,
+    exported_at        timestamp with time zone
+);
+
+CREATE TABLE payout_items (
+    id                 serial            PRIMARY KEY,
+    batch_id           int               NOT NULL      REFERENCES payout_batches(id),
+    name               varchar(40)       NOT NULL,
+    iban               varchar(34)       NOT NULL,
+    amount             bigint            NOT NULL
+);
+
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
+
+CREATE TABLE inbox (
+  key                 varchar(100)         PRIMARY KEY,
+  processed_at        timestamp with time zone NOT NULL DEFAULT now()
+);
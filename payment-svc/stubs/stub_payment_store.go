@@ -0,0 +1,41 @@
+package stubs
+
+import (
+	"github.com/VitoNaychev/food-app/payment-svc/models"
+	"github.com/VitoNaychev/food-app/storeerrors"
+)
+
+type StubPaymentStore struct {
+	Payments       []models.Payment
+	CreatedPayment models.Payment
+	UpdatedPayment models.Payment
+}
+
+func (s *StubPaymentStore) CreatePayment(payment *models.Payment) error {
+	s.CreatedPayment = *payment
+	s.Payments = append(s.Payments, *payment)
+
+	return nil
+}
+
+func (s *StubPaymentStore) GetPaymentByID(id int) (models.Payment, error) {
+	for _, payment := range s.Payments {
+		if payment.ID == id {
+			return payment, nil
+		}
+	}
+
+	return models.Payment{}, storeerrors.ErrNotFound
+}
+
+func (s *StubPaymentStore) UpdatePayment(payment *models.Payment) error {
+	for i, oldPayment := range s.Payments {
+		if oldPayment.ID == payment.ID {
+			s.Payments[i] = *payment
+			s.UpdatedPayment = *payment
+			return nil
+		}
+	}
+
+	return storeerrors.ErrNotFound
+}
@@ -0,0 +1,12 @@
+package testdata
+
+import "github.com/VitoNaychev/food-app/events/svcevents"
+
+var (
+	PeterOrderCreatedEvent = svcevents.OrderCreatedEvent{
+		ID:           1,
+		CustomerID:   1,
+		RestaurantID: 1,
+		Total:        12.50,
+	}
+)
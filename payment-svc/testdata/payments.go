@@ -0,0 +1,13 @@
+package testdata
+
+import "github.com/VitoNaychev/food-app/payment-svc/models"
+
+var (
+	PeterPayment = models.Payment{
+		ID:           1,
+		CustomerID:   1,
+		RestaurantID: 1,
+		Amount:       12.50,
+		State:        models.AUTHORIZED,
+	}
+)
@@ -0,0 +1,73 @@
+package pgconfig
+
+import (
+	"context"
+
+	"github.com/VitoNaychev/food-app/metrics"
+	"github.com/VitoNaychev/food-app/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect opens a connection pool to the database at connString, tracing
+// and recording metrics of the queries executed through it and exporting
+// the pool's stats. The pool is safe to share between goroutines, unlike a
+// single pgx connection.
+func Connect(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	config.ConnConfig.Tracer = queryTracers{tracing.NewPgQueryTracer(), metrics.NewPgQueryTracer()}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pool connects lazily, so ping the database to fail as early as
+	// a single connection would.
+	err = pool.Ping(ctx)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	metrics.RegisterPgPool(pool)
+
+	return pool, nil
+}
+
+// pgx accepts a single tracer, so queryTracers passes every traced query
+// on to each of its tracers.
+type queryTracers []pgx.QueryTracer
+
+func (q queryTracers) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, tracer := range q {
+		ctx = tracer.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (q queryTracers) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for _, tracer := range q {
+		tracer.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+func (q queryTracers) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	for _, tracer := range q {
+		if connectTracer, ok := tracer.(pgx.ConnectTracer); ok {
+			ctx = connectTracer.TraceConnectStart(ctx, data)
+		}
+	}
+	return ctx
+}
+
+func (q queryTracers) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	for _, tracer := range q {
+		if connectTracer, ok := tracer.(pgx.ConnectTracer); ok {
+			connectTracer.TraceConnectEnd(ctx, data)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package pgconfig
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Executor is implemented by both *pgxpool.Pool and pgx.Tx, so a store can
+// run its queries either on its own or as part of a transaction.
+type Executor interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Transactor is implemented by stores that can begin a transaction other
+// stores take part in through WithTx.
+type Transactor interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx that carries tx, so that RunInTx
+// joins tx instead of beginning a transaction of its own.
+func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txKey{}).(pgx.Tx)
+	return tx
+}
+
+// RunInTx runs fn in the transaction carried by ctx or, when there is none,
+// in a new transaction begun through db that is committed if fn succeeds.
+// Stores that can't take part in transactions, such as the in-memory ones,
+// can't begin one either, so fn is run with a nil tx.
+func RunInTx(ctx context.Context, db any, fn func(tx pgx.Tx) error) error {
+	if tx := TxFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
+
+	transactor, ok := db.(Transactor)
+	if !ok {
+		return fn(nil)
+	}
+
+	tx, err := transactor.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// WithTx returns store writing through tx when it supports transactions,
+// and store itself otherwise.
+func WithTx[S any](store S, tx pgx.Tx) S {
+	if tx == nil {
+		return store
+	}
+
+	if txStore, ok := any(store).(interface{ WithTx(pgx.Tx) S }); ok {
+		return txStore.WithTx(tx)
+	}
+
+	return store
+}
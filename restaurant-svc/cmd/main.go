@@ -15,6 +15,8 @@ func main() {
 		SecretKey: []byte(os.Getenv("SECRET")),
 		ExpiresAt: 24 * time.Hour,
 
+		SigningKeyFiles: strings.Split(os.Getenv("SIGNING_KEY_FILES"), ","),
+
 		Dbhost: "restaurant-db",
 		Dbport: "5432",
 		Dbuser: os.Getenv("POSTGRES_USER"),
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
@@ -39,9 +41,20 @@ func (c *AddressServer) updateAddress(w http.ResponseWriter, r *http.Request) {
 
 	address := UpdateAddressRequestToAddress(updateAddressRequest, currentAddress.ID, restaurantID)
 
-	err = c.addressStore.UpdateAddress(&address)
+	err = c.inTx(r.Context(), func(c *AddressServer) error {
+		err := c.addressStore.UpdateAddress(&address)
+		if err != nil {
+			return err
+		}
+
+		payload := NewRestaurantAddressUpdatedEvent(address)
+		event := svcevents.RestaurantAddressUpdated.New(restaurantID, payload).WithTraceContext(r.Context())
+
+		return c.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
+		return
 	}
 
 	json.NewEncoder(w).Encode(address)
@@ -69,16 +82,29 @@ func (c *AddressServer) createAddress(w http.ResponseWriter, r *http.Request) {
 
 	address := CreateAddressRequestToAddress(createAddressRequest, restaurantID)
 
-	err = c.addressStore.CreateAddress(&address)
-	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
-		return
-	}
-
-	restaurant.Status = restaurant.Status | models.ADDRESS_SET
-	err = c.restaurantStore.UpdateRestaurant(&restaurant)
+	err = c.inTx(r.Context(), func(c *AddressServer) error {
+		err := c.addressStore.CreateAddress(&address)
+		if err != nil {
+			return err
+		}
+
+		restaurant.Status = restaurant.Status | models.ADDRESS_SET
+		err = c.restaurantStore.UpdateRestaurant(&restaurant)
+		if err != nil {
+			return err
+		}
+
+		payload := NewRestaurantAddressCreatedEvent(address)
+		event := svcevents.RestaurantAddressCreated.New(restaurantID, payload).WithTraceContext(r.Context())
+		err = c.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+		if err != nil {
+			return err
+		}
+
+		return publishRestaurantStatusUpdatedEvent(c.publisher, restaurant)
+	})
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+)
+
+func NewRestaurantAddressCreatedEvent(address models.Address) events.RestaurantAddressCreatedEvent {
+	event := events.RestaurantAddressCreatedEvent{
+		ID:           address.ID,
+		RestaurantID: address.RestaurantID,
+		Lat:          address.Lat,
+		Lon:          address.Lon,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		City:         address.City,
+		Country:      address.Country,
+	}
+
+	return event
+}
+
+func NewRestaurantAddressUpdatedEvent(address models.Address) events.RestaurantAddressUpdatedEvent {
+	event := events.RestaurantAddressUpdatedEvent{
+		ID:           address.ID,
+		RestaurantID: address.RestaurantID,
+		Lat:          address.Lat,
+		Lon:          address.Lon,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		City:         address.City,
+		Country:      address.Country,
+	}
+
+	return event
+}
@@ -1,25 +1,32 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/jackc/pgx/v5"
 )
 
 type AddressServer struct {
-	secretKey       []byte
+	keys            auth.KeySet
 	addressStore    models.AddressStore
 	restaurantStore models.RestaurantStore
+	publisher       events.EventPublisher
 	verifier        auth.Verifier
 }
 
-func NewAddressServer(secretKey []byte, addressStore models.AddressStore, restaurantStore models.RestaurantStore) *AddressServer {
+func NewAddressServer(keys auth.KeySet, addressStore models.AddressStore, restaurantStore models.RestaurantStore, publisher events.EventPublisher,
+	tokenStore auth.TokenStore) *AddressServer {
 	customerAddressServer := AddressServer{
-		secretKey:       secretKey,
+		keys:            keys,
 		addressStore:    addressStore,
 		restaurantStore: restaurantStore,
-		verifier:        NewRestaurantVerifier(restaurantStore),
+		publisher:       publisher,
+		verifier:        NewRestaurantVerifier(restaurantStore, tokenStore),
 	}
 
 	return &customerAddressServer
@@ -28,10 +35,24 @@ func NewAddressServer(secretKey []byte, addressStore models.AddressStore, restau
 func (c *AddressServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
-		auth.AuthenticationMW(c.createAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.createAddress, c.verifier, c.keys, auth.RESTAURANT)(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(c.getAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.getAddress, c.verifier, c.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(c.updateAddress, c.verifier, c.secretKey)(w, r)
+		auth.AuthenticationMW(c.updateAddress, c.verifier, c.keys, auth.RESTAURANT)(w, r)
 	}
 }
+
+// inTx runs fn with a copy of the server whose stores and publisher write
+// through a single transaction, so an address, the restaurant status and their
+// events are committed together.
+func (c *AddressServer) inTx(ctx context.Context, fn func(c *AddressServer) error) error {
+	return pgconfig.RunInTx(ctx, c.addressStore, func(tx pgx.Tx) error {
+		txServer := *c
+		txServer.addressStore = pgconfig.WithTx(c.addressStore, tx)
+		txServer.restaurantStore = pgconfig.WithTx(c.restaurantStore, tx)
+		txServer.publisher = pgconfig.WithTx(c.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/restaurant-svc/handlers"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/restaurant-svc/testdata"
@@ -60,7 +61,7 @@ func TestAddressEndpointAuthentication(t *testing.T) {
 	addressStore := &StubAddressStore{}
 	restaurantStore := &StubRestaurantStore{}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
 
 	invalidJWT := "thisIsAnInvalidJWT"
 	cases := map[string]*http.Request{
@@ -78,9 +79,9 @@ func TestAddressRequestValidation(t *testing.T) {
 		restaurants: []models.Restaurant{testdata.DominosRestaurant},
 	}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
 
-	dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+	dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 	cases := map[string]*http.Request{
 		"create address": tabletests.NewDummyRequest(http.MethodPost, "/restaurant/address/", dominosJWT),
 		"update address": tabletests.NewDummyRequest(http.MethodPut, "/restaurant/address/", dominosJWT),
@@ -97,10 +98,10 @@ func TestAddressResponseValidity(t *testing.T) {
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
 
-	shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
-	dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosAddress.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
+	dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosAddress.ID, auth.RESTAURANT)
 	cases := []tabletests.ResponseValidationTestcase{
 		{
 			Name:    "get address",
@@ -139,13 +140,14 @@ func TestUpdateRestaurantAddress(t *testing.T) {
 		restaurants: []models.Restaurant{td.ShackRestaurant, td.DominosRestaurant},
 	}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	publisher := &StubEventPublisher{}
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("updates address on valid body and credentials", func(t *testing.T) {
 		updatedAddress := td.DominosAddress
 		updatedAddress.City = "Varna"
 
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 
 		request := handlers.NewUpdateAddressRequest(dominosJWT, updatedAddress)
 		response := httptest.NewRecorder()
@@ -156,6 +158,27 @@ func TestUpdateRestaurantAddress(t *testing.T) {
 
 		testutil.AssertEqual(t, addressStore.updatedAddress, updatedAddress)
 	})
+
+	t.Run("sends RESTAURANT_ADDRESS_UPDATED_EVENT on PUT", func(t *testing.T) {
+		updatedAddress := td.DominosAddress
+		updatedAddress.City = "Varna"
+
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
+
+		request := handlers.NewUpdateAddressRequest(dominosJWT, updatedAddress)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		want := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_ADDRESS_UPDATED_EVENT_ID,
+			AggregateID: td.DominosRestaurant.ID,
+			Payload:     handlers.NewRestaurantAddressUpdatedEvent(updatedAddress),
+		}
+
+		testutil.AssertEqual(t, publisher.topic, events.RESTAURANT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.event, want)
+	})
 }
 
 func TestCreateRestaurantAddress(t *testing.T) {
@@ -166,11 +189,12 @@ func TestCreateRestaurantAddress(t *testing.T) {
 		restaurants: []models.Restaurant{td.ShackRestaurant, td.DominosRestaurant},
 	}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	publisher := &StubEventPublisher{}
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, publisher, auth.NewInMemoryTokenStore())
 
-	t.Run("creates Shack address and sets ADDRESS_SET bit in restaurant state", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 
+	t.Run("creates Shack address and sets ADDRESS_SET bit in restaurant state", func(t *testing.T) {
 		request := handlers.NewCreateAddressRequest(shackJWT, td.ShackAddress)
 		response := httptest.NewRecorder()
 
@@ -186,8 +210,52 @@ func TestCreateRestaurantAddress(t *testing.T) {
 		}
 	})
 
+	t.Run("sends RESTAURANT_ADDRESS_CREATED_EVENT on POST", func(t *testing.T) {
+		*publisher = StubEventPublisher{}
+
+		request := handlers.NewCreateAddressRequest(shackJWT, td.ShackAddress)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		want := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_ADDRESS_CREATED_EVENT_ID,
+			AggregateID: td.ShackRestaurant.ID,
+			Payload:     handlers.NewRestaurantAddressCreatedEvent(td.ShackAddress),
+		}
+
+		testutil.AssertEqual(t, publisher.topic, events.RESTAURANT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.events[0], want)
+	})
+
+	t.Run("sends RESTAURANT_STATUS_UPDATED_EVENT on POST", func(t *testing.T) {
+		*publisher = StubEventPublisher{}
+
+		request := handlers.NewCreateAddressRequest(shackJWT, td.ShackAddress)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		payload := events.RestaurantStatusUpdatedEvent{
+			ID:     td.ShackRestaurant.ID,
+			Status: int(td.ShackRestaurant.Status | models.ADDRESS_SET),
+		}
+		want := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_STATUS_UPDATED_EVENT_ID,
+			AggregateID: td.ShackRestaurant.ID,
+			Payload:     payload,
+		}
+
+		testutil.AssertEqual(t, publisher.topic, events.RESTAURANT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.event, want)
+	})
+
 	t.Run("returns Bad Request if address for restaurant is already set", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 
 		request := handlers.NewCreateAddressRequest(dominosJWT, td.DominosAddress)
 		response := httptest.NewRecorder()
@@ -207,10 +275,10 @@ func TestGetRestaurantAddress(t *testing.T) {
 		restaurants: []models.Restaurant{td.ShackRestaurant, td.DominosRestaurant},
 	}
 
-	server := handlers.NewAddressServer(testEnv.SecretKey, addressStore, restaurantStore)
+	server := handlers.NewAddressServer(auth.HMACKey(testEnv.SecretKey), addressStore, restaurantStore, &StubEventPublisher{}, auth.NewInMemoryTokenStore())
 
 	t.Run("returns Chicken Shack's address", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetAddressRequest(shackJWT)
 		response := httptest.NewRecorder()
 
@@ -227,7 +295,7 @@ func TestGetRestaurantAddress(t *testing.T) {
 	})
 
 	t.Run("returns Dominos address", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetAddressRequest(dominosJWT)
 		response := httptest.NewRecorder()
 
@@ -1,27 +1,34 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/jackc/pgx/v5"
 )
 
 type HoursServer struct {
-	secretKey       []byte
+	keys            auth.KeySet
 	hoursStore      models.HoursStore
 	restaurantStore models.RestaurantStore
+	publisher       events.EventPublisher
 	verifier        auth.Verifier
 }
 
-func NewHoursServer(secretKey []byte,
-	hoursStore models.HoursStore, restaurantStore models.RestaurantStore) *HoursServer {
+func NewHoursServer(keys auth.KeySet,
+	hoursStore models.HoursStore, restaurantStore models.RestaurantStore, publisher events.EventPublisher,
+	tokenStore auth.TokenStore) *HoursServer {
 
 	hoursServer := &HoursServer{
-		secretKey:       secretKey,
+		keys:            keys,
 		hoursStore:      hoursStore,
 		restaurantStore: restaurantStore,
-		verifier:        NewRestaurantVerifier(restaurantStore),
+		publisher:       publisher,
+		verifier:        NewRestaurantVerifier(restaurantStore, tokenStore),
 	}
 
 	return hoursServer
@@ -30,10 +37,24 @@ func NewHoursServer(secretKey []byte,
 func (h *HoursServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		auth.AuthenticationMW(h.getHours, h.verifier, h.secretKey)(w, r)
+		auth.AuthenticationMW(h.getHours, h.verifier, h.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPost:
-		auth.AuthenticationMW(h.createHours, h.verifier, h.secretKey)(w, r)
+		auth.AuthenticationMW(h.createHours, h.verifier, h.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(h.updateHours, h.verifier, h.secretKey)(w, r)
+		auth.AuthenticationMW(h.updateHours, h.verifier, h.keys, auth.RESTAURANT)(w, r)
 	}
 }
+
+// inTx runs fn with a copy of the server whose stores and publisher write
+// through a single transaction, so working hours, the restaurant status and
+// their events are committed together.
+func (h *HoursServer) inTx(ctx context.Context, fn func(h *HoursServer) error) error {
+	return pgconfig.RunInTx(ctx, h.hoursStore, func(tx pgx.Tx) error {
+		txServer := *h
+		txServer.hoursStore = pgconfig.WithTx(h.hoursStore, tx)
+		txServer.restaurantStore = pgconfig.WithTx(h.restaurantStore, tx)
+		txServer.publisher = pgconfig.WithTx(h.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/validation"
@@ -49,14 +51,32 @@ func (h *HoursServer) updateHours(w http.ResponseWriter, r *http.Request) {
 
 	setUpdatedHoursKeys(updateHoursArr, currentHoursArr)
 
-	for _, updateHours := range updateHoursArr {
-		err := h.hoursStore.UpdateHours(&updateHours)
-		if err != nil {
-			httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
-			return
+	for i := range currentHoursArr {
+		for _, updateHours := range updateHoursArr {
+			if currentHoursArr[i].Day == updateHours.Day {
+				currentHoursArr[i] = updateHours
+			}
 		}
 	}
 
+	err = h.inTx(r.Context(), func(h *HoursServer) error {
+		for _, updateHours := range updateHoursArr {
+			err := h.hoursStore.UpdateHours(&updateHours)
+			if err != nil {
+				return err
+			}
+		}
+
+		payload := NewRestaurantHoursSetEvent(restaurant, currentHoursArr)
+		event := svcevents.RestaurantHoursSet.New(restaurantID, payload).WithTraceContext(r.Context())
+
+		return h.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
 	updateHoursResponseArr := HoursArrToHoursResponseArr(updateHoursArr)
 	json.NewEncoder(w).Encode(updateHoursResponseArr)
 }
@@ -113,20 +133,33 @@ func (h *HoursServer) createHours(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var hoursArr []models.Hours
-	for _, createHoursRequest := range createHoursRequestArr {
-		hours := HoursRequestToHours(createHoursRequest, restaurantID)
+	err = h.inTx(r.Context(), func(h *HoursServer) error {
+		for _, createHoursRequest := range createHoursRequestArr {
+			hours := HoursRequestToHours(createHoursRequest, restaurantID)
+
+			err := h.hoursStore.CreateHours(&hours)
+			if err != nil {
+				return err
+			}
+
+			hoursArr = append(hoursArr, hours)
+		}
 
-		err = h.hoursStore.CreateHours(&hours)
+		restaurant.Status = restaurant.Status | models.HOURS_SET
+		err := h.restaurantStore.UpdateRestaurant(&restaurant)
 		if err != nil {
-			httperrors.HandleInternalServerError(w, err)
-			return
+			return err
 		}
 
-		hoursArr = append(hoursArr, hours)
-	}
+		payload := NewRestaurantHoursSetEvent(restaurant, hoursArr)
+		event := svcevents.RestaurantHoursSet.New(restaurantID, payload).WithTraceContext(r.Context())
+		err = h.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+		if err != nil {
+			return err
+		}
 
-	restaurant.Status = restaurant.Status | models.HOURS_SET
-	err = h.restaurantStore.UpdateRestaurant(&restaurant)
+		return publishRestaurantStatusUpdatedEvent(h.publisher, restaurant)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 		return
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+)
+
+func NewRestaurantHoursSetEvent(restaurant models.Restaurant, hoursArr []models.Hours) events.RestaurantHoursSetEvent {
+	days := []events.RestaurantHoursSetEventDay{}
+	for _, hours := range hoursArr {
+		day := events.RestaurantHoursSetEventDay{
+			Day:     hours.Day,
+			Opening: hours.Opening.Format("15:04"),
+			Closing: hours.Closing.Format("15:04"),
+		}
+		days = append(days, day)
+	}
+
+	event := events.RestaurantHoursSetEvent{
+		RestaurantID: restaurant.ID,
+		Timezone:     restaurant.Timezone,
+		Hours:        days,
+	}
+
+	return event
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/auth"
+	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/restaurant-svc/handlers"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/restaurant-svc/testdata"
@@ -47,7 +48,7 @@ func (s *StubHoursStore) GetHoursByRestaurantID(restaurantID int) ([]models.Hour
 }
 
 func TestHoursEndpointAuthentication(t *testing.T) {
-	server := handlers.NewHoursServer(testEnv.SecretKey, nil, nil)
+	server := handlers.NewHoursServer(auth.HMACKey(testEnv.SecretKey), nil, nil, nil, auth.NewInMemoryTokenStore())
 
 	cases := map[string]*http.Request{
 		"get hours":    handlers.NewGetHoursRequest(""),
@@ -66,11 +67,13 @@ func TestHoursRequestValidation(t *testing.T) {
 	restaurantStore := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant},
 	}
-	server := handlers.NewHoursServer(testEnv.SecretKey,
+	publisher := &StubEventPublisher{}
+	server := handlers.NewHoursServer(auth.HMACKey(testEnv.SecretKey),
 		hoursStore,
-		restaurantStore)
+		restaurantStore,
+		publisher, auth.NewInMemoryTokenStore())
 
-	shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 
 	cases := map[string]*http.Request{
 		"create hours": handlers.NewCreateHoursRequest(shackJWT, []models.Hours{}),
@@ -88,12 +91,14 @@ func TestUpdateHours(t *testing.T) {
 	restaurantStore := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
-	server := handlers.NewHoursServer(testEnv.SecretKey,
+	publisher := &StubEventPublisher{}
+	server := handlers.NewHoursServer(auth.HMACKey(testEnv.SecretKey),
 		hoursStore,
-		restaurantStore)
+		restaurantStore,
+		publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("updates hours on PUT", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 
 		updatedHours := make([]models.Hours, 2)
 		copy(updatedHours, testdata.DominosHours[:2])
@@ -111,8 +116,39 @@ func TestUpdateHours(t *testing.T) {
 		assertHoursResponseBody(t, response.Body, updatedHours)
 	})
 
+	t.Run("publishes RESTAURANT_HOURS_SET_EVENT with the whole week on PUT", func(t *testing.T) {
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
+
+		updatedHours := make([]models.Hours, 1)
+		copy(updatedHours, testdata.DominosHours[:1])
+		updatedHours[0].Closing, _ = time.Parse("15:04", "02:00")
+
+		request := handlers.NewUpdateHoursRequest(dominosJWT, updatedHours)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		wantWeek := make([]models.Hours, len(testdata.DominosHours))
+		copy(wantWeek, testdata.DominosHours)
+		wantWeek[0] = updatedHours[0]
+
+		payload := handlers.NewRestaurantHoursSetEvent(testdata.DominosRestaurant, wantWeek)
+		testutil.AssertEqual(t, payload.Timezone, testdata.DominosRestaurant.Timezone)
+
+		want := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_HOURS_SET_EVENT_ID,
+			AggregateID: testdata.DominosRestaurant.ID,
+			Payload:     payload,
+		}
+
+		testutil.AssertEqual(t, publisher.topic, events.RESTAURANT_EVENTS_TOPIC)
+		testutil.AssertEvent(t, publisher.event, want)
+	})
+
 	t.Run("returns Bad Request on update of a restaurant with HOURS_SET bit off", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 
 		updatedHours := make([]models.Hours, 2)
 		copy(updatedHours, testdata.ShackHours[:2])
@@ -129,7 +165,7 @@ func TestUpdateHours(t *testing.T) {
 	})
 
 	t.Run("returns Bad Request on duplicate days", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 
 		updatedHours := make([]models.Hours, 2)
 		copy(updatedHours, testdata.DominosHours[:1])
@@ -154,12 +190,14 @@ func TestCreateHours(t *testing.T) {
 	restaurantStore := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
-	server := handlers.NewHoursServer(testEnv.SecretKey,
+	publisher := &StubEventPublisher{}
+	server := handlers.NewHoursServer(auth.HMACKey(testEnv.SecretKey),
 		hoursStore,
-		restaurantStore)
+		restaurantStore,
+		publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("returns Bad Request if working hours already set", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewCreateHoursRequest(dominosJWT, testdata.DominosHours)
 		response := httptest.NewRecorder()
 
@@ -172,7 +210,7 @@ func TestCreateHours(t *testing.T) {
 	t.Run("returns Bad Request if there is a missing day in request", func(t *testing.T) {
 		incompleteHours := testdata.ShackHours[1:6]
 
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewCreateHoursRequest(shackJWT, incompleteHours)
 		response := httptest.NewRecorder()
 
@@ -190,7 +228,7 @@ func TestCreateHours(t *testing.T) {
 		copy(duplicateHours, testdata.ShackHours)
 		duplicateHours[7] = duplicateHours[3]
 
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewCreateHoursRequest(shackJWT, duplicateHours)
 		response := httptest.NewRecorder()
 
@@ -204,7 +242,7 @@ func TestCreateHours(t *testing.T) {
 	})
 
 	t.Run("creates working hours for Shack and sets HOURS_SET bit", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewCreateHoursRequest(shackJWT, testdata.ShackHours)
 		response := httptest.NewRecorder()
 
@@ -223,6 +261,31 @@ func TestCreateHours(t *testing.T) {
 
 		assertHoursResponseBody(t, response.Body, testdata.ShackHours)
 	})
+
+	t.Run("publishes RESTAURANT_HOURS_SET_EVENT and RESTAURANT_STATUS_UPDATED_EVENT on create", func(t *testing.T) {
+		if len(publisher.events) != 2 {
+			t.Fatalf("got %d published events, want %d", len(publisher.events), 2)
+		}
+
+		hoursPayload := handlers.NewRestaurantHoursSetEvent(testdata.ShackRestaurant, testdata.ShackHours)
+		wantHoursEvent := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_HOURS_SET_EVENT_ID,
+			AggregateID: testdata.ShackRestaurant.ID,
+			Payload:     hoursPayload,
+		}
+		testutil.AssertEvent(t, publisher.events[0], wantHoursEvent)
+
+		statusPayload := events.RestaurantStatusUpdatedEvent{
+			ID:     testdata.ShackRestaurant.ID,
+			Status: int(models.HOURS_SET | models.CREATED),
+		}
+		wantStatusEvent := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_STATUS_UPDATED_EVENT_ID,
+			AggregateID: testdata.ShackRestaurant.ID,
+			Payload:     statusPayload,
+		}
+		testutil.AssertEvent(t, publisher.events[1], wantStatusEvent)
+	})
 }
 
 func assertRestaurantStatus(t testing.TB, restaurant models.Restaurant, status models.Status) {
@@ -245,12 +308,14 @@ func TestGetHours(t *testing.T) {
 	restaurantStore := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.DominosRestaurant, testdata.ShackRestaurant},
 	}
-	server := handlers.NewHoursServer(testEnv.SecretKey,
+	publisher := &StubEventPublisher{}
+	server := handlers.NewHoursServer(auth.HMACKey(testEnv.SecretKey),
 		hoursStore,
-		restaurantStore)
+		restaurantStore,
+		publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("returns working hours on Chicken Shack", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetHoursRequest(shackJWT)
 		response := httptest.NewRecorder()
 
@@ -262,7 +327,7 @@ func TestGetHours(t *testing.T) {
 	})
 
 	t.Run("returns working hours for Dominos", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetHoursRequest(dominosJWT)
 		response := httptest.NewRecorder()
 
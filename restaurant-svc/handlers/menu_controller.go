@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
@@ -43,14 +44,20 @@ func (m *MenuServer) deleteMenuItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = m.menuStore.DeleteMenuItem(deleteMenuItemRequest.ID)
+	err = m.inTx(r.Context(), func(m *MenuServer) error {
+		err := m.menuStore.DeleteMenuItem(deleteMenuItemRequest.ID)
+		if err != nil {
+			return err
+		}
+
+		payload := events.MenuItemDeletedEvent{ID: deleteMenuItemRequest.ID}
+		event := svcevents.MenuItemDeleted.New(restaurantID, payload).WithTraceContext(r.Context())
+
+		return m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 	}
-
-	payload := events.MenuItemDeletedEvent{ID: deleteMenuItemRequest.ID}
-	event := events.NewEvent(events.MENU_ITEM_DELETED_EVENT_ID, restaurantID, payload)
-	m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
 }
 
 func (m *MenuServer) updateMenuItem(w http.ResponseWriter, r *http.Request) {
@@ -84,16 +91,23 @@ func (m *MenuServer) updateMenuItem(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updateMenuItem := UpdateMenuItemRequestToMenuItem(updateMenuItemRequest, restaurantID)
-	err = m.menuStore.UpdateMenuItem(&updateMenuItem)
+	err = m.inTx(r.Context(), func(m *MenuServer) error {
+		err := m.menuStore.UpdateMenuItem(&updateMenuItem)
+		if err != nil {
+			return err
+		}
+
+		payload := NewMenuItemUpdatedEvent(updateMenuItem)
+		event := svcevents.MenuItemUpdated.New(restaurantID, payload).WithTraceContext(r.Context())
+
+		return m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
+		return
 	}
 
 	json.NewEncoder(w).Encode(updateMenuItem)
-
-	payload := NewMenuItemUpdatedEvent(updateMenuItem)
-	event := events.NewEvent(events.MENU_ITEM_UPDATED_EVENT_ID, restaurantID, payload)
-	m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
 }
 
 func (m *MenuServer) createMenuItem(w http.ResponseWriter, r *http.Request) {
@@ -113,16 +127,22 @@ func (m *MenuServer) createMenuItem(w http.ResponseWriter, r *http.Request) {
 
 	menuItem := CreateMenuItemRequestToMenuItem(createMenuItemRequest, restaurantID)
 
-	err = m.menuStore.CreateMenuItem(&menuItem)
+	err = m.inTx(r.Context(), func(m *MenuServer) error {
+		err := m.menuStore.CreateMenuItem(&menuItem)
+		if err != nil {
+			return err
+		}
+
+		event := svcevents.MenuItemCreated.New(restaurantID, NewMenuItemCreatedEvent(menuItem)).WithTraceContext(r.Context())
+
+		return m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
 	json.NewEncoder(w).Encode(menuItem)
-
-	event := events.NewEvent(events.MENU_ITEM_CREATED_EVENT_ID, restaurantID, NewMenuItemCreatedEvent(menuItem))
-	m.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
 }
 
 func (m *MenuServer) getMenu(w http.ResponseWriter, r *http.Request) {
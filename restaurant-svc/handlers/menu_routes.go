@@ -1,27 +1,31 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/jackc/pgx/v5"
 )
 
 type MenuServer struct {
-	secretKey       []byte
+	keys            auth.KeySet
 	menuStore       models.MenuStore
 	restaurantStore models.RestaurantStore
 	verifier        auth.Verifier
 	publisher       events.EventPublisher
 }
 
-func NewMenuServer(secretKey []byte, menuStore models.MenuStore, restaurantStore models.RestaurantStore, publisher events.EventPublisher) *MenuServer {
+func NewMenuServer(keys auth.KeySet, menuStore models.MenuStore, restaurantStore models.RestaurantStore, publisher events.EventPublisher,
+	tokenStore auth.TokenStore) *MenuServer {
 	return &MenuServer{
-		secretKey:       secretKey,
+		keys:            keys,
 		menuStore:       menuStore,
 		restaurantStore: restaurantStore,
-		verifier:        NewRestaurantVerifier(restaurantStore),
+		verifier:        NewRestaurantVerifier(restaurantStore, tokenStore),
 		publisher:       publisher,
 	}
 }
@@ -29,12 +33,25 @@ func NewMenuServer(secretKey []byte, menuStore models.MenuStore, restaurantStore
 func (m *MenuServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		auth.AuthenticationMW(m.getMenu, m.verifier, m.secretKey)(w, r)
+		auth.AuthenticationMW(m.getMenu, m.verifier, m.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPost:
-		auth.AuthenticationMW(m.createMenuItem, m.verifier, m.secretKey)(w, r)
+		auth.AuthenticationMW(m.createMenuItem, m.verifier, m.keys, auth.RESTAURANT)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(m.updateMenuItem, m.verifier, m.secretKey)(w, r)
+		auth.AuthenticationMW(m.updateMenuItem, m.verifier, m.keys, auth.RESTAURANT)(w, r)
 	case http.MethodDelete:
-		auth.AuthenticationMW(m.deleteMenuItem, m.verifier, m.secretKey)(w, r)
+		auth.AuthenticationMW(m.deleteMenuItem, m.verifier, m.keys, auth.RESTAURANT)(w, r)
 	}
 }
+
+// inTx runs fn with a copy of the server whose store and publisher write
+// through a single transaction, so a menu item and its events are committed
+// together.
+func (m *MenuServer) inTx(ctx context.Context, fn func(m *MenuServer) error) error {
+	return pgconfig.RunInTx(ctx, m.menuStore, func(tx pgx.Tx) error {
+		txServer := *m
+		txServer.menuStore = pgconfig.WithTx(m.menuStore, tx)
+		txServer.publisher = pgconfig.WithTx(m.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
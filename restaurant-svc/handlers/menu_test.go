@@ -77,7 +77,7 @@ func TestMenuEndpointAuthentication(t *testing.T) {
 
 	menuStore := &StubMenuStore{}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, nil)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, nil, auth.NewInMemoryTokenStore())
 	invalidJWT := "invalidJWT"
 
 	cases := map[string]*http.Request{
@@ -97,8 +97,8 @@ func TestMenuRequestValdiation(t *testing.T) {
 
 	menuStore := &StubMenuStore{}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, nil)
-	dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, nil, auth.NewInMemoryTokenStore())
+	dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 
 	cases := map[string]*http.Request{
 		"create menu item": handlers.NewCreateMenuItemRequest(dominosJWT, models.MenuItem{}),
@@ -120,10 +120,10 @@ func TestDeleteMenuItem(t *testing.T) {
 
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, publisher)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("deletes menu item on DELETE", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		deleteMenuItemID := td.DominosMenu[1].ID
 
 		request := handlers.NewDeleteMenuItemRequest(dominosJWT, handlers.DeleteMenuItemRequest{deleteMenuItemID})
@@ -136,7 +136,7 @@ func TestDeleteMenuItem(t *testing.T) {
 	})
 
 	t.Run("sends MENU_ITEM_DELETED_EVENT on DELETE", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		deleteMenuItemID := td.DominosMenu[1].ID
 
 		request := handlers.NewDeleteMenuItemRequest(dominosJWT, handlers.DeleteMenuItemRequest{deleteMenuItemID})
@@ -155,7 +155,7 @@ func TestDeleteMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Not Found on attempt to delete menu item that doesn't exist", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		deleteMenuItemID := 10
 
 		request := handlers.NewDeleteMenuItemRequest(dominosJWT, handlers.DeleteMenuItemRequest{deleteMenuItemID})
@@ -168,7 +168,7 @@ func TestDeleteMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Unauthorized on attempt to delete menu item of another restaurant", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		deleteMenuItemID := td.ForeignMenuItem.ID
 
 		request := handlers.NewDeleteMenuItemRequest(dominosJWT, handlers.DeleteMenuItemRequest{deleteMenuItemID})
@@ -181,7 +181,7 @@ func TestDeleteMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Bad Request on restaurant with not VALID state", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewDeleteMenuItemRequest(shackJWT, handlers.DeleteMenuItemRequest{ID: 1})
 		response := httptest.NewRecorder()
 
@@ -203,10 +203,10 @@ func TestUpdateMenuItem(t *testing.T) {
 
 	publisher := &StubEventPublisher{}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, publisher)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("updates menu item on PUT", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := td.DominosMenu[0]
 		menuItem.Name = "Master Burger Pizza"
 
@@ -226,7 +226,7 @@ func TestUpdateMenuItem(t *testing.T) {
 	})
 
 	t.Run("sends MENU_ITEM_UPDATED_EVENT on PUT", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := td.DominosMenu[0]
 		menuItem.Name = "Master Burger Pizza"
 
@@ -246,7 +246,7 @@ func TestUpdateMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Not Found on attempt to update menu item that doesn't exist", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := models.MenuItem{
 			ID:           10,
 			Name:         "New Pizza",
@@ -265,7 +265,7 @@ func TestUpdateMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Unauthorized on attempt to update menu item of another restaurant", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := td.ForeignMenuItem
 		menuItem.Name = "Master Burger Pizza"
 
@@ -279,7 +279,7 @@ func TestUpdateMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Bad Request on restaurant with not VALID state", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewUpdateMenuItemRequest(shackJWT, models.MenuItem{})
 		response := httptest.NewRecorder()
 
@@ -301,10 +301,10 @@ func TestCreateMenuItem(t *testing.T) {
 		menus: td.DominosMenu,
 	}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, publisher)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("creates menu item on POST", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := models.MenuItem{
 			Name:    "New Pizza",
 			Price:   19.99,
@@ -331,7 +331,7 @@ func TestCreateMenuItem(t *testing.T) {
 	})
 
 	t.Run("sends MENU_ITEM_CREATED_EVENT in POST", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		menuItem := models.MenuItem{
 			Name:    "New Pizza",
 			Price:   19.99,
@@ -357,7 +357,7 @@ func TestCreateMenuItem(t *testing.T) {
 	})
 
 	t.Run("returns Bad Request on restaurant with not VALID state", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 		menuItem := models.MenuItem{
 			Name:    "Duner",
 			Price:   8.00,
@@ -382,10 +382,10 @@ func TestGetMenu(t *testing.T) {
 		menus: td.DominosMenu,
 	}
 
-	server := handlers.NewMenuServer(testEnv.SecretKey, menuStore, restaurantStore, nil)
+	server := handlers.NewMenuServer(auth.HMACKey(testEnv.SecretKey), menuStore, restaurantStore, nil, auth.NewInMemoryTokenStore())
 
 	t.Run("gets menu on GET", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.DominosRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetMenuRequest(dominosJWT)
 		response := httptest.NewRecorder()
 
@@ -400,7 +400,7 @@ func TestGetMenu(t *testing.T) {
 	})
 
 	t.Run("returns Bad Request on restaurant with not VALID state", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, td.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, td.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetMenuRequest(shackJWT)
 		response := httptest.NewRecorder()
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
@@ -32,28 +33,103 @@ func (s *RestaurantServer) LoginHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	if restaurant.Password != loginRestaurantRequest.Password {
+	needsRehash, err := auth.VerifyPassword(restaurant.Password, loginRestaurantRequest.Password)
+	if err != nil {
 		httperrors.HandleUnauthorized(w, ErrInvalidCredentials)
 		return
 	}
 
-	jwtToken, _ := auth.GenerateJWT(s.secretKey, s.expiresAt, restaurant.ID)
-	jwtResponse := JWTResponse{Token: jwtToken}
+	// A failed rehash doesn't fail the login, the password is rehashed again
+	// on the next successful one.
+	if needsRehash {
+		restaurant.Password, err = auth.HashPassword(loginRestaurantRequest.Password)
+		if err == nil {
+			s.store.UpdateRestaurant(&restaurant)
+		}
+	}
+
+	jwtResponse, err := s.generateTokens(restaurant.ID)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
 
 	json.NewEncoder(w).Encode(jwtResponse)
 }
 
+func (s *RestaurantServer) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	refreshTokenRequest, err := validation.ValidateBody[RefreshTokenRequest](r.Body)
+	if err != nil {
+		httperrors.HandleBadRequest(w, err)
+		return
+	}
+
+	restaurantID, refreshToken, err := auth.RotateRefreshToken(s.tokenStore, refreshTokenRequest.RefreshToken)
+	if errors.Is(err, auth.ErrInvalidRefreshToken) {
+		httperrors.HandleUnauthorized(w, err)
+		return
+	} else if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	_, err = s.store.GetRestaurantByID(restaurantID)
+	if errors.Is(err, storeerrors.ErrNotFound) {
+		s.tokenStore.DeleteRefreshTokensBySubject(restaurantID)
+		httperrors.HandleUnauthorized(w, ErrRestaurantNotFound)
+		return
+	} else if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	jwtToken, _ := auth.GenerateJWT(s.signer, s.expiresAt, restaurantID, auth.RESTAURANT)
+
+	json.NewEncoder(w).Encode(JWTResponse{Token: jwtToken, RefreshToken: refreshToken})
+}
+
+func (s *RestaurantServer) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	// The token was already verified by the authentication middleware.
+	token, _ := auth.VerifyJWT(r.Header.Get("Token"), s.signer, auth.RESTAURANT)
+
+	err := auth.RevokeJWT(s.tokenStore, token)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+}
+
+func (s *RestaurantServer) generateTokens(restaurantID int) (JWTResponse, error) {
+	jwtToken, err := auth.GenerateJWT(s.signer, s.expiresAt, restaurantID, auth.RESTAURANT)
+	if err != nil {
+		return JWTResponse{}, err
+	}
+
+	refreshToken, err := auth.GenerateRefreshToken(s.tokenStore, restaurantID)
+	if err != nil {
+		return JWTResponse{}, err
+	}
+
+	return JWTResponse{Token: jwtToken, RefreshToken: refreshToken}, nil
+}
+
 func (s *RestaurantServer) deleteRestaurant(w http.ResponseWriter, r *http.Request) {
 	restaurantID, _ := strconv.Atoi(r.Header.Get("Subject"))
 
-	err := s.store.DeleteRestaurant(restaurantID)
+	err := s.inTx(r.Context(), func(s *RestaurantServer) error {
+		err := s.store.DeleteRestaurant(restaurantID)
+		if err != nil {
+			return err
+		}
+
+		payload := events.RestaurantDeletedEvent{ID: restaurantID}
+		event := svcevents.RestaurantDeleted.New(restaurantID, payload).WithTraceContext(r.Context())
+
+		return s.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
 		httperrors.HandleInternalServerError(w, err)
 	}
-
-	payload := events.RestaurantDeletedEvent{ID: restaurantID}
-	event := events.NewEvent(events.RESTAURANT_DELETED_EVENT_ID, restaurantID, payload)
-	s.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
 }
 
 func (s *RestaurantServer) updateRestaurant(w http.ResponseWriter, r *http.Request) {
@@ -74,10 +150,34 @@ func (s *RestaurantServer) updateRestaurant(w http.ResponseWriter, r *http.Reque
 	newRestaurant := UpdateRestaurantRequestToRestaurant(updateRestaurantRequest, restaurantID, oldRestaurant.Status)
 	newRestaurant.ID = restaurantID
 	newRestaurant.Status = oldRestaurant.Status
+	if newRestaurant.Timezone == "" {
+		newRestaurant.Timezone = oldRestaurant.Timezone
+	}
 
-	err = s.store.UpdateRestaurant(&newRestaurant)
+	newRestaurant.Password, err = auth.HashPassword(newRestaurant.Password)
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
+	err = s.inTx(r.Context(), func(s *RestaurantServer) error {
+		err := s.store.UpdateRestaurant(&newRestaurant)
+		if err != nil {
+			return err
+		}
+
+		payload := events.RestaurantUpdatedEvent{
+			ID:       newRestaurant.ID,
+			Name:     newRestaurant.Name,
+			IBAN:     newRestaurant.IBAN,
+			Timezone: newRestaurant.Timezone,
+		}
+		event := svcevents.RestaurantUpdated.New(newRestaurant.ID, payload).WithTraceContext(r.Context())
+
+		return s.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
@@ -110,22 +210,42 @@ func (s *RestaurantServer) createRestaurant(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	restaurant.Password, err = auth.HashPassword(restaurant.Password)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
+
 	restaurant.Status = models.CREATED
-	err = s.store.CreateRestaurant(&restaurant)
+	err = s.inTx(r.Context(), func(s *RestaurantServer) error {
+		err := s.store.CreateRestaurant(&restaurant)
+		if err != nil {
+			return err
+		}
+
+		payload := events.RestaurantCreatedEvent{
+			ID:   restaurant.ID,
+			Name: restaurant.Name,
+			IBAN: restaurant.IBAN,
+		}
+		event := svcevents.RestaurantCreated.New(restaurant.ID, payload).WithTraceContext(r.Context())
+
+		return s.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+	})
 	if err != nil {
-		httperrors.WriteJSONError(w, http.StatusInternalServerError, err)
+		httperrors.HandleInternalServerError(w, err)
 		return
 	}
 
-	jwtToken, _ := auth.GenerateJWT(s.secretKey, s.expiresAt, restaurant.ID)
+	jwtResponse, err := s.generateTokens(restaurant.ID)
+	if err != nil {
+		httperrors.HandleInternalServerError(w, err)
+		return
+	}
 
 	response := CreateRestaurantResponse{
-		JWT:        JWTResponse{jwtToken},
+		JWT:        jwtResponse,
 		Restaurant: RestaurantToRestaurantResponse(restaurant),
 	}
 	json.NewEncoder(w).Encode(response)
-
-	payload := events.RestaurantCreatedEvent{ID: restaurant.ID}
-	event := events.NewEvent(events.RESTAURANT_CREATED_EVENT_ID, restaurant.ID, payload)
-	s.publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
 }
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/events/svcevents"
+	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+)
+
+func NewRestaurantStatusUpdatedEvent(restaurant models.Restaurant) events.RestaurantStatusUpdatedEvent {
+	event := events.RestaurantStatusUpdatedEvent{
+		ID:     restaurant.ID,
+		Status: int(restaurant.Status),
+	}
+
+	return event
+}
+
+func publishRestaurantStatusUpdatedEvent(publisher events.EventPublisher, restaurant models.Restaurant) error {
+	payload := NewRestaurantStatusUpdatedEvent(restaurant)
+	event := svcevents.RestaurantStatusUpdated.New(restaurant.ID, payload)
+
+	return publisher.Publish(events.RESTAURANT_EVENTS_TOPIC, event)
+}
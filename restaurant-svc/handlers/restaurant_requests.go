@@ -50,3 +50,25 @@ func NewGetRestaurantRequest(jwt string) *http.Request {
 
 	return request
 }
+
+func NewRefreshRestaurantRequest(refreshToken string) *http.Request {
+	requestBody := RefreshTokenRequest{RefreshToken: refreshToken}
+	request := reqbuilder.NewRequestWithBody[RefreshTokenRequest](
+		http.MethodPost, "/restaurant/login/refresh/", requestBody)
+
+	return request
+}
+
+func NewLogoutRestaurantRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/restaurant/logout/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
+
+func NewAuthRestaurantRequest(jwt string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "/restaurant/auth/", nil)
+	request.Header.Add("Token", jwt)
+
+	return request
+}
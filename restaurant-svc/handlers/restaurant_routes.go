@@ -1,37 +1,47 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/jackc/pgx/v5"
 )
 
 type RestaurantServer struct {
-	secretKey []byte
-	expiresAt time.Duration
-	store     models.RestaurantStore
-	verifier  auth.Verifier
-	publisher events.EventPublisher
-	http.Handler
+	signer     auth.Signer
+	expiresAt  time.Duration
+	store      models.RestaurantStore
+	tokenStore auth.TokenStore
+	verifier   auth.Verifier
+	publisher  events.EventPublisher
+	*http.ServeMux
 }
 
-func NewRestaurantServer(secretKey []byte, expiresAt time.Duration, store models.RestaurantStore, publisher events.EventPublisher) *RestaurantServer {
+func NewRestaurantServer(signer auth.Signer, expiresAt time.Duration, store models.RestaurantStore, publisher events.EventPublisher,
+	tokenStore auth.TokenStore) *RestaurantServer {
 	s := RestaurantServer{
-		secretKey: secretKey,
-		expiresAt: expiresAt,
-		store:     store,
-		verifier:  NewRestaurantVerifier(store),
-		publisher: publisher,
+		signer:     signer,
+		expiresAt:  expiresAt,
+		store:      store,
+		tokenStore: tokenStore,
+		verifier:   NewRestaurantVerifier(store, tokenStore),
+		publisher:  publisher,
 	}
 
 	router := http.NewServeMux()
 	router.HandleFunc("/restaurant/", s.RestaurantHandler)
 	router.HandleFunc("/restaurant/login/", s.LoginHandler)
+	router.HandleFunc("/restaurant/login/refresh/", s.RefreshHandler)
+	router.HandleFunc("/restaurant/logout/", auth.AuthenticationMW(s.LogoutHandler, s.verifier, s.signer, auth.RESTAURANT))
+	router.HandleFunc("/restaurant/auth/", auth.AuthHandler(s.verifier, s.signer, auth.RESTAURANT))
+	router.HandleFunc(auth.JWKSPath, auth.JWKSHandler(s.signer))
 
-	s.Handler = router
+	s.ServeMux = router
 
 	return &s
 }
@@ -41,10 +51,23 @@ func (s *RestaurantServer) RestaurantHandler(w http.ResponseWriter, r *http.Requ
 	case http.MethodPost:
 		s.createRestaurant(w, r)
 	case http.MethodGet:
-		auth.AuthenticationMW(s.getRestaurant, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.getRestaurant, s.verifier, s.signer, auth.RESTAURANT)(w, r)
 	case http.MethodPut:
-		auth.AuthenticationMW(s.updateRestaurant, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.updateRestaurant, s.verifier, s.signer, auth.RESTAURANT)(w, r)
 	case http.MethodDelete:
-		auth.AuthenticationMW(s.deleteRestaurant, s.verifier, s.secretKey)(w, r)
+		auth.AuthenticationMW(s.deleteRestaurant, s.verifier, s.signer, auth.RESTAURANT)(w, r)
 	}
 }
+
+// inTx runs fn with a copy of the server whose store and publisher write
+// through a single transaction, so a restaurant and its events are committed
+// together.
+func (s *RestaurantServer) inTx(ctx context.Context, fn func(s *RestaurantServer) error) error {
+	return pgconfig.RunInTx(ctx, s.store, func(tx pgx.Tx) error {
+		txServer := *s
+		txServer.store = pgconfig.WithTx(s.store, tx)
+		txServer.publisher = pgconfig.WithTx(s.publisher, tx)
+
+		return fn(&txServer)
+	})
+}
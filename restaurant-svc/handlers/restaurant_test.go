@@ -4,10 +4,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/restaurant-svc/handlers"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/restaurant-svc/testdata"
@@ -15,16 +17,19 @@ import (
 	"github.com/VitoNaychev/food-app/testutil"
 	"github.com/VitoNaychev/food-app/testutil/tabletests"
 	"github.com/VitoNaychev/food-app/validation"
+	"github.com/VitoNaychev/food-app/workhours"
 )
 
 type StubEventPublisher struct {
-	topic string
-	event events.InterfaceEvent
+	topic  string
+	event  events.InterfaceEvent
+	events []events.InterfaceEvent
 }
 
 func (s *StubEventPublisher) Publish(topic string, event events.InterfaceEvent) error {
 	s.topic = topic
 	s.event = event
+	s.events = append(s.events, event)
 
 	return nil
 }
@@ -76,9 +81,9 @@ func TestRestaurantRequestValidation(t *testing.T) {
 	store := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.DominosRestaurant},
 	}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, nil)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
 
-	dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+	dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 	cases := map[string]*http.Request{
 		"login restaurant":  handlers.NewLoginRestaurantRequest(models.Restaurant{}),
 		"create restaurant": handlers.NewCreateRestaurantRequest(models.Restaurant{}),
@@ -93,9 +98,9 @@ func TestRestaurantResponseValidity(t *testing.T) {
 		restaurants: []models.Restaurant{testdata.DominosRestaurant},
 	}
 	publisher := &StubEventPublisher{}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
-	dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+	dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 	cases := []tabletests.ResponseValidationTestcase{
 		{
 			Name:    "get restaurant",
@@ -127,7 +132,7 @@ func TestRestaurantResponseValidity(t *testing.T) {
 }
 
 func TestRestaurantEnpointAuthentication(t *testing.T) {
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, nil, nil)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, nil, nil, auth.NewInMemoryTokenStore())
 
 	invalidJWT := "invalidJWT"
 	cases := map[string]*http.Request{
@@ -143,7 +148,7 @@ func TestLoginRestaurant(t *testing.T) {
 	store := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, nil)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
 
 	t.Run("returns JWT on correct credentials", func(t *testing.T) {
 		request := handlers.NewLoginRestaurantRequest(testdata.ShackRestaurant)
@@ -156,7 +161,42 @@ func TestLoginRestaurant(t *testing.T) {
 		jwtResponse, err := validation.ValidateBody[handlers.JWTResponse](response.Body)
 		testutil.AssertValidResponse(t, err)
 
-		testutil.AssertJWT(t, jwtResponse.Token, testEnv.SecretKey, testdata.ShackRestaurant.ID)
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), testdata.ShackRestaurant.ID, auth.RESTAURANT)
+	})
+
+	t.Run("rehashes legacy plaintext password on login", func(t *testing.T) {
+		store := &StubRestaurantStore{
+			restaurants: []models.Restaurant{testdata.ShackRestaurant},
+		}
+		server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginRestaurantRequest(testdata.ShackRestaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		assertHashedPassword(t, &store.updatedRestaurant, testdata.ShackRestaurant.Password)
+		testutil.AssertEqual(t, store.updatedRestaurant, testdata.ShackRestaurant)
+	})
+
+	t.Run("returns JWT on hashed password", func(t *testing.T) {
+		hashedRestaurant := testdata.ShackRestaurant
+		hashedRestaurant.Password, _ = auth.HashPassword(testdata.ShackRestaurant.Password)
+
+		store := &StubRestaurantStore{
+			restaurants: []models.Restaurant{hashedRestaurant},
+		}
+		server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
+
+		request := handlers.NewLoginRestaurantRequest(testdata.ShackRestaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, store.updatedRestaurant, models.Restaurant{})
 	})
 
 	t.Run("returns Unauthorized on incorrect email", func(t *testing.T) {
@@ -186,15 +226,172 @@ func TestLoginRestaurant(t *testing.T) {
 	})
 }
 
+func TestRefreshRestaurantToken(t *testing.T) {
+	store := &StubRestaurantStore{
+		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
+	}
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
+
+	t.Run("returns new JWT and refresh token on valid refresh token", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		request := handlers.NewRefreshRestaurantRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		jwtResponse, err := validation.ValidateBody[handlers.JWTResponse](response.Body)
+		testutil.AssertValidResponse(t, err)
+
+		testutil.AssertJWT(t, jwtResponse.Token, auth.HMACKey(testEnv.SecretKey), testdata.ShackRestaurant.ID, auth.RESTAURANT)
+		if jwtResponse.RefreshToken == loginResponse.RefreshToken {
+			t.Errorf("expected new refresh token, got the old one")
+		}
+	})
+
+	t.Run("returns Unauthorized on reused refresh token", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		request := handlers.NewRefreshRestaurantRequest(loginResponse.RefreshToken)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshRestaurantRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns Unauthorized on refresh token of deleted restaurant", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.DominosRestaurant)
+		store.restaurants = []models.Restaurant{testdata.ShackRestaurant}
+
+		request := handlers.NewRefreshRestaurantRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, handlers.ErrRestaurantNotFound)
+	})
+}
+
+func TestLogoutRestaurant(t *testing.T) {
+	store := &StubRestaurantStore{
+		restaurants: []models.Restaurant{testdata.ShackRestaurant},
+	}
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
+
+	t.Run("revokes JWT on logout", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		request := handlers.NewLogoutRestaurantRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		request = handlers.NewGetRestaurantRequest(loginResponse.Token)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrRevokedToken)
+	})
+
+	t.Run("invalidates refresh token on logout", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		request := handlers.NewLogoutRestaurantRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewRefreshRestaurantRequest(loginResponse.RefreshToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusUnauthorized)
+		testutil.AssertErrorResponse(t, response.Body, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("returns REVOKED status on revoked JWT", func(t *testing.T) {
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		request := handlers.NewLogoutRestaurantRequest(loginResponse.Token)
+		server.ServeHTTP(httptest.NewRecorder(), request)
+
+		request = handlers.NewAuthRestaurantRequest(loginResponse.Token)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		got, err := validation.ValidateBody[msgtypes.AuthResponse](response.Body)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got.Status, msgtypes.REVOKED)
+	})
+}
+
+func loginRestaurant(server http.Handler, restaurant models.Restaurant) handlers.JWTResponse {
+	request := handlers.NewLoginRestaurantRequest(restaurant)
+	response := httptest.NewRecorder()
+
+	server.ServeHTTP(response, request)
+
+	jwtResponse, _ := validation.ValidateBody[handlers.JWTResponse](response.Body)
+	return jwtResponse
+}
+
+func TestRestaurantJWKS(t *testing.T) {
+	store := &StubRestaurantStore{
+		restaurants: []models.Restaurant{testdata.ShackRestaurant},
+	}
+	signingKey, _ := auth.GenerateEd25519SigningKey()
+	keyRing := auth.NewKeyRing(signingKey, testEnv.ExpiresAt)
+	server := handlers.NewRestaurantServer(keyRing, testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
+
+	t.Run("returns public keys on GET to JWKS endpoint", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, auth.JWKSPath, nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+
+		got, err := validation.ValidateBody[auth.JWKS](response.Body)
+		testutil.AssertValidResponse(t, err)
+
+		testutil.AssertEqual(t, got, keyRing.JWKS())
+	})
+
+	t.Run("issues JWT verifiable with published keys", func(t *testing.T) {
+		jwksServer := httptest.NewServer(server)
+		defer jwksServer.Close()
+
+		config := auth.DefaultJWKSVerifierConfig
+		config.URL = jwksServer.URL + auth.JWKSPath
+		verifier := auth.NewJWKSVerifier(config)
+
+		loginResponse := loginRestaurant(server, testdata.ShackRestaurant)
+
+		testutil.AssertJWT(t, loginResponse.Token, verifier, testdata.ShackRestaurant.ID, auth.RESTAURANT)
+	})
+}
+
 func TestDeleteRestaurant(t *testing.T) {
 	store := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
 	publisher := &StubEventPublisher{}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("deletes restaurant on DELETE", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 
 		request := handlers.NewDeleteRestaurantRequest(dominosJWT)
 		response := httptest.NewRecorder()
@@ -207,7 +404,7 @@ func TestDeleteRestaurant(t *testing.T) {
 	})
 
 	t.Run("generates a RESTAURANT_DELETED_EVENT on DELETE", func(t *testing.T) {
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 
 		request := handlers.NewDeleteRestaurantRequest(dominosJWT)
 		response := httptest.NewRecorder()
@@ -231,13 +428,14 @@ func TestUpdateRestaurant(t *testing.T) {
 	store := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant, testdata.DominosRestaurant},
 	}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, nil)
+	publisher := &StubEventPublisher{}
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("updates restaurant on PUT", func(t *testing.T) {
 		updatedRestaurant := testdata.DominosRestaurant
 		updatedRestaurant.Email = "dominos@gmail.com"
 
-		dominosJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.DominosRestaurant.ID)
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
 
 		request := handlers.NewUpdateRestaurantRequest(dominosJWT, updatedRestaurant)
 		response := httptest.NewRecorder()
@@ -246,18 +444,61 @@ func TestUpdateRestaurant(t *testing.T) {
 
 		testutil.AssertStatus(t, response.Code, http.StatusOK)
 
+		assertHashedPassword(t, &store.updatedRestaurant, updatedRestaurant.Password)
 		testutil.AssertEqual(t, store.updatedRestaurant, updatedRestaurant)
 	})
+
+	t.Run("generates a RESTAURANT_UPDATED_EVENT on PUT", func(t *testing.T) {
+		updatedRestaurant := testdata.DominosRestaurant
+		updatedRestaurant.IBAN = "BG80BNBG96611020345678"
+
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
+
+		request := handlers.NewUpdateRestaurantRequest(dominosJWT, updatedRestaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertEqual(t, publisher.topic, events.RESTAURANT_EVENTS_TOPIC)
+
+		want := events.InterfaceEvent{
+			EventID:     events.RESTAURANT_UPDATED_EVENT_ID,
+			AggregateID: testdata.DominosRestaurant.ID,
+			Payload: events.RestaurantUpdatedEvent{
+				ID:       updatedRestaurant.ID,
+				Name:     updatedRestaurant.Name,
+				IBAN:     updatedRestaurant.IBAN,
+				Timezone: updatedRestaurant.Timezone,
+			},
+		}
+
+		testutil.AssertEvent(t, publisher.event, want)
+	})
+
+	t.Run("keeps timezone on PUT without timezone", func(t *testing.T) {
+		updatedRestaurant := testdata.DominosRestaurant
+		updatedRestaurant.Timezone = ""
+
+		dominosJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.DominosRestaurant.ID, auth.RESTAURANT)
+
+		request := handlers.NewUpdateRestaurantRequest(dominosJWT, updatedRestaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, store.updatedRestaurant.Timezone, testdata.DominosRestaurant.Timezone)
+	})
 }
 
 func TestGetRestaurant(t *testing.T) {
 	store := &StubRestaurantStore{
 		restaurants: []models.Restaurant{testdata.ShackRestaurant},
 	}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, nil)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, nil, auth.NewInMemoryTokenStore())
 
 	t.Run("resturns restaurant on GET", func(t *testing.T) {
-		shackJWT, _ := auth.GenerateJWT(testEnv.SecretKey, testEnv.ExpiresAt, testdata.ShackRestaurant.ID)
+		shackJWT, _ := auth.GenerateJWT(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, testdata.ShackRestaurant.ID, auth.RESTAURANT)
 		request := handlers.NewGetRestaurantRequest(shackJWT)
 		response := httptest.NewRecorder()
 
@@ -279,7 +520,7 @@ func TestCreateRestaurant(t *testing.T) {
 		restaurants: []models.Restaurant{testdata.DominosRestaurant},
 	}
 	publisher := &StubEventPublisher{}
-	server := handlers.NewRestaurantServer(testEnv.SecretKey, testEnv.ExpiresAt, store, publisher)
+	server := handlers.NewRestaurantServer(auth.HMACKey(testEnv.SecretKey), testEnv.ExpiresAt, store, publisher, auth.NewInMemoryTokenStore())
 
 	t.Run("creates restaurant on POST", func(t *testing.T) {
 		request := handlers.NewCreateRestaurantRequest(testdata.ShackRestaurant)
@@ -289,6 +530,7 @@ func TestCreateRestaurant(t *testing.T) {
 
 		testutil.AssertStatus(t, response.Code, http.StatusOK)
 
+		assertHashedPassword(t, &store.createdRestaurant, testdata.ShackRestaurant.Password)
 		testutil.AssertEqual(t, store.createdRestaurant, testdata.ShackRestaurant)
 	})
 
@@ -302,7 +544,7 @@ func TestCreateRestaurant(t *testing.T) {
 		testutil.AssertValidResponse(t, err)
 
 		token := createRestaurantResponse.JWT.Token
-		testutil.AssertJWT(t, token, testEnv.SecretKey, testdata.ShackRestaurant.ID)
+		testutil.AssertJWT(t, token, auth.HMACKey(testEnv.SecretKey), testdata.ShackRestaurant.ID, auth.RESTAURANT)
 	})
 
 	t.Run("returns the created restaurant on POST", func(t *testing.T) {
@@ -331,12 +573,53 @@ func TestCreateRestaurant(t *testing.T) {
 		want := events.InterfaceEvent{
 			EventID:     events.RESTAURANT_CREATED_EVENT_ID,
 			AggregateID: testdata.ShackRestaurant.ID,
-			Payload:     events.RestaurantCreatedEvent{ID: testdata.ShackAddress.ID},
+			Payload: events.RestaurantCreatedEvent{
+				ID:   testdata.ShackRestaurant.ID,
+				Name: testdata.ShackRestaurant.Name,
+				IBAN: testdata.ShackRestaurant.IBAN,
+			},
 		}
 
 		testutil.AssertEvent(t, got, want)
 	})
 
+	t.Run("creates restaurant in the default timezone on POST without timezone", func(t *testing.T) {
+		restaurant := testdata.ShackRestaurant
+		restaurant.Timezone = ""
+
+		request := handlers.NewCreateRestaurantRequest(restaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusOK)
+		testutil.AssertEqual(t, store.createdRestaurant.Timezone, workhours.DefaultTimezone)
+	})
+
+	t.Run("returns Bad Request on unknown timezone", func(t *testing.T) {
+		restaurant := testdata.ShackRestaurant
+		restaurant.Timezone = "Europe/Atlantis"
+
+		request := handlers.NewCreateRestaurantRequest(restaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("returns Bad Request on password longer than 72 bytes", func(t *testing.T) {
+		restaurant := testdata.ShackRestaurant
+		restaurant.Password = strings.Repeat("ж", 37)
+
+		request := handlers.NewCreateRestaurantRequest(restaurant)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		testutil.AssertStatus(t, response.Code, http.StatusBadRequest)
+	})
+
 	t.Run("returns Bad Request on restaurant with same email", func(t *testing.T) {
 		request := handlers.NewCreateRestaurantRequest(testdata.DominosRestaurant)
 		response := httptest.NewRecorder()
@@ -347,3 +630,17 @@ func TestCreateRestaurant(t *testing.T) {
 		testutil.AssertErrorResponse(t, response.Body, handlers.ErrExistingRestaurant)
 	})
 }
+
+// Restaurants are stored with a hashed password, so after verifying it
+// against the expected plaintext one it's replaced with the plaintext
+// password to allow comparing the rest of the restaurant.
+func assertHashedPassword(t testing.TB, stored *models.Restaurant, password string) {
+	t.Helper()
+
+	needsRehash, err := auth.VerifyPassword(stored.Password, password)
+	if err != nil || needsRehash {
+		t.Errorf("stored password %q isn't a hash of %q", stored.Password, password)
+	}
+
+	stored.Password = password
+}
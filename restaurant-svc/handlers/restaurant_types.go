@@ -1,18 +1,22 @@
 package handlers
 
-import "github.com/VitoNaychev/food-app/restaurant-svc/models"
+import (
+	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/VitoNaychev/food-app/workhours"
+)
 
 type LoginRestaurantRequest struct {
 	Email    string `validate:"required,email,max=60"       json:"email"`
-	Password string `valdiate:"required,max=72"             json:"password"`
+	Password string `validate:"required,password"           json:"password"`
 }
 
 type UpdateRestaurantRequest struct {
 	Name        string `validate:"required,max=40"             json:"name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `valdiate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 	IBAN        string `validate:"required"                    json:"iban"`
+	Timezone    string `validate:"omitempty,timezone,max=64"   json:"timezone"`
 }
 
 func UpdateRestaurantRequestToRestaurant(request UpdateRestaurantRequest, id int, status models.Status) models.Restaurant {
@@ -24,6 +28,7 @@ func UpdateRestaurantRequestToRestaurant(request UpdateRestaurantRequest, id int
 		Password:    request.Password,
 		IBAN:        request.IBAN,
 		Status:      status,
+		Timezone:    request.Timezone,
 	}
 
 	return restaurant
@@ -36,13 +41,19 @@ func RestaurantToUpdateRestaurantRequest(restaurant models.Restaurant) UpdateRes
 		Email:       restaurant.Email,
 		Password:    restaurant.Password,
 		IBAN:        restaurant.IBAN,
+		Timezone:    restaurant.Timezone,
 	}
 
 	return updateRestaurantRequest
 }
 
 type JWTResponse struct {
-	Token string `validate:"required" json:"token"`
+	Token        string `validate:"required" json:"token"`
+	RefreshToken string `validate:"required" json:"refresh_token"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `validate:"required" json:"refresh_token"`
 }
 
 type CreateRestaurantResponse struct {
@@ -56,6 +67,7 @@ type RestaurantResponse struct {
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
 	IBAN        string `validate:"required"                    json:"iban"`
+	Timezone    string `validate:"required,timezone"           json:"timezone"`
 }
 
 func RestaurantToRestaurantResponse(restaurant models.Restaurant) RestaurantResponse {
@@ -65,6 +77,7 @@ func RestaurantToRestaurantResponse(restaurant models.Restaurant) RestaurantResp
 		PhoneNumber: restaurant.PhoneNumber,
 		Email:       restaurant.Email,
 		IBAN:        restaurant.IBAN,
+		Timezone:    restaurant.Timezone,
 	}
 
 	return restaurantResponse
@@ -74,8 +87,9 @@ type CreateRestaurantRequest struct {
 	Name        string `validate:"required,max=40"             json:"name"`
 	PhoneNumber string `validate:"required,phonenumber,max=20" json:"phone_number"`
 	Email       string `validate:"required,email,max=60"       json:"email"`
-	Password    string `valdiate:"required,max=72"             json:"password"`
+	Password    string `validate:"required,password"           json:"password"`
 	IBAN        string `validate:"required"                    json:"iban"`
+	Timezone    string `validate:"omitempty,timezone,max=64"   json:"timezone"`
 }
 
 func RestaurantToCreateRestaurantRequest(restaurant models.Restaurant) CreateRestaurantRequest {
@@ -85,6 +99,7 @@ func RestaurantToCreateRestaurantRequest(restaurant models.Restaurant) CreateRes
 		Email:       restaurant.Email,
 		Password:    restaurant.Password,
 		IBAN:        restaurant.IBAN,
+		Timezone:    restaurant.Timezone,
 	}
 
 	return createRestaurantRequest
@@ -97,6 +112,11 @@ func CreateRestaurantRequestToRestaurant(createRestaurantRequest CreateRestauran
 		Email:       createRestaurantRequest.Email,
 		Password:    createRestaurantRequest.Password,
 		IBAN:        createRestaurantRequest.IBAN,
+		Timezone:    createRestaurantRequest.Timezone,
+	}
+
+	if restaurant.Timezone == "" {
+		restaurant.Timezone = workhours.DefaultTimezone
 	}
 
 	return restaurant
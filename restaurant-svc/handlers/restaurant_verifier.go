@@ -3,16 +3,19 @@ package handlers
 import (
 	"errors"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
 	"github.com/VitoNaychev/food-app/storeerrors"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type RestaurantVerifier struct {
-	store models.RestaurantStore
+	store      models.RestaurantStore
+	tokenStore auth.TokenStore
 }
 
-func NewRestaurantVerifier(store models.RestaurantStore) *RestaurantVerifier {
-	return &RestaurantVerifier{store}
+func NewRestaurantVerifier(store models.RestaurantStore, tokenStore auth.TokenStore) *RestaurantVerifier {
+	return &RestaurantVerifier{store, tokenStore}
 }
 
 func (c *RestaurantVerifier) DoesSubjectExist(id int) (bool, error) {
@@ -24,3 +27,7 @@ func (c *RestaurantVerifier) DoesSubjectExist(id int) (bool, error) {
 	}
 	return true, nil
 }
+
+func (c *RestaurantVerifier) IsJWTRevoked(token *jwt.Token) (bool, error) {
+	return auth.IsJWTRevoked(c.tokenStore, token)
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"net/http"
+
+	"github.com/VitoNaychev/food-app/auth"
 )
 
 type RouterServer struct {
-	http.Handler
+	*http.ServeMux
 }
 
 func NewRouterServer(restaurantServer, addressServer, hoursServer, menuServer http.Handler) *RouterServer {
@@ -13,11 +15,12 @@ func NewRouterServer(restaurantServer, addressServer, hoursServer, menuServer ht
 
 	router := http.NewServeMux()
 	router.Handle("/restaurant/", restaurantServer)
+	router.Handle(auth.JWKSPath, restaurantServer)
 	router.Handle("/restaurant/address/", addressServer)
 	router.Handle("/restaurant/hours/", hoursServer)
 	router.Handle("/restaurant/menu/", menuServer)
 
-	routerServer.Handler = router
+	routerServer.ServeMux = router
 
 	return routerServer
 }
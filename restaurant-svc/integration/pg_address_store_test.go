@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/parser"
 	"github.com/VitoNaychev/food-app/pgconfig"
@@ -33,8 +34,8 @@ func TestAddressServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	restaurantServer := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{})
-	addressServer := handlers.NewAddressServer(env.SecretKey, &addressStore, &restaurantStore)
+	restaurantServer := handlers.NewRestaurantServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
+	addressServer := handlers.NewAddressServer(auth.HMACKey(env.SecretKey), &addressStore, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
 
 	server := handlers.NewRouterServer(restaurantServer, addressServer, DummyHandler, DummyHandler)
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/parser"
 	"github.com/VitoNaychev/food-app/pgconfig"
@@ -33,8 +34,8 @@ func TestHoursServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	restaurantServer := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{})
-	hoursServer := handlers.NewHoursServer(env.SecretKey, &hoursStore, &restaurantStore)
+	restaurantServer := handlers.NewRestaurantServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
+	hoursServer := handlers.NewHoursServer(auth.HMACKey(env.SecretKey), &hoursStore, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
 
 	server := handlers.NewRouterServer(restaurantServer, DummyHandler, hoursServer, DummyHandler)
 
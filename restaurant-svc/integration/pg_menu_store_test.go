@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/parser"
 	"github.com/VitoNaychev/food-app/pgconfig"
@@ -43,10 +44,10 @@ func TestMenuServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	restaurantServer := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{})
-	addressServer := handlers.NewAddressServer(env.SecretKey, &addressStore, &restaurantStore)
-	hoursServer := handlers.NewHoursServer(env.SecretKey, &hoursStore, &restaurantStore)
-	menuServer := handlers.NewMenuServer(env.SecretKey, &menuStore, &restaurantStore, &dummies.DummyPublisher{})
+	restaurantServer := handlers.NewRestaurantServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
+	addressServer := handlers.NewAddressServer(auth.HMACKey(env.SecretKey), &addressStore, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
+	hoursServer := handlers.NewHoursServer(auth.HMACKey(env.SecretKey), &hoursStore, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
+	menuServer := handlers.NewMenuServer(auth.HMACKey(env.SecretKey), &menuStore, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
 
 	server := handlers.NewRouterServer(restaurantServer, addressServer, hoursServer, menuServer)
 
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/parser"
 	"github.com/VitoNaychev/food-app/pgconfig"
@@ -27,7 +28,7 @@ func TestCustomerServerOperations(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	restaurantServer := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{})
+	restaurantServer := handlers.NewRestaurantServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, &restaurantStore, &dummies.DummyPublisher{}, auth.NewInMemoryTokenStore())
 
 	server := handlers.NewRouterServer(restaurantServer,
 		http.HandlerFunc(DummyHandler),
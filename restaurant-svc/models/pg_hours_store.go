@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgHoursStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgHoursStore(ctx context.Context, connString string) (PgHoursStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgHoursStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -23,6 +24,14 @@ func NewPgHoursStore(ctx context.Context, connString string) (PgHoursStore, erro
 	return pgHoursStore, nil
 }
 
+func (p *PgHoursStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgHoursStore) WithTx(tx pgx.Tx) HoursStore {
+	return &PgHoursStore{tx}
+}
+
 func (p *PgHoursStore) CreateHours(hours *Hours) error {
 	query := `insert into working_hours(day, opening, closing, restaurant_id) 
 	values (@day, @opening, @closing, @restaurant_id) returning id`
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgMenuStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgMenuStore(ctx context.Context, connString string) (PgMenuStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgMenuStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -23,6 +24,14 @@ func NewPgMenuStore(ctx context.Context, connString string) (PgMenuStore, error)
 	return pgMenuStore, nil
 }
 
+func (p *PgMenuStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgMenuStore) WithTx(tx pgx.Tx) MenuStore {
+	return &PgMenuStore{tx}
+}
+
 func (p *PgMenuStore) CreateMenuItem(menuItem *MenuItem) error {
 	query := `insert into menu_items(name, price, details, restaurant_id) 
 	values (@name, @price, @details, @restaurant_id) returning id`
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/storeerrors"
 	"github.com/jackc/pgx/v5"
 )
 
 type PgRestaurantStore struct {
-	conn *pgx.Conn
+	conn pgconfig.Executor
 }
 
 func NewPgRestaurantStore(ctx context.Context, connString string) (PgRestaurantStore, error) {
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgconfig.Connect(ctx, connString)
 	if err != nil {
 		return PgRestaurantStore{}, fmt.Errorf("unable to connect to database: %w", err)
 	}
@@ -23,6 +24,14 @@ func NewPgRestaurantStore(ctx context.Context, connString string) (PgRestaurantS
 	return pgRestaurantStore, nil
 }
 
+func (p *PgRestaurantStore) Begin(ctx context.Context) (pgx.Tx, error) {
+	return p.conn.Begin(ctx)
+}
+
+func (p *PgRestaurantStore) WithTx(tx pgx.Tx) RestaurantStore {
+	return &PgRestaurantStore{tx}
+}
+
 func (p *PgRestaurantStore) GetRestaurantByEmail(email string) (Restaurant, error) {
 	query := `select * from restaurants where email=@email`
 	args := pgx.NamedArgs{
@@ -56,8 +65,8 @@ func (p *PgRestaurantStore) GetRestaurantByID(id int) (Restaurant, error) {
 }
 
 func (p *PgRestaurantStore) CreateRestaurant(restaurant *Restaurant) error {
-	query := `insert into restaurants(name, phone_number, email, password, IBAN, status) 
-		values (@name, @phone_number, @email, @password, @iban, @status) returning id`
+	query := `insert into restaurants(name, phone_number, email, password, IBAN, status, timezone) 
+		values (@name, @phone_number, @email, @password, @iban, @status, @timezone) returning id`
 	args := pgx.NamedArgs{
 		"name":         restaurant.Name,
 		"phone_number": restaurant.PhoneNumber,
@@ -65,6 +74,7 @@ func (p *PgRestaurantStore) CreateRestaurant(restaurant *Restaurant) error {
 		"password":     restaurant.Password,
 		"iban":         restaurant.IBAN,
 		"status":       CREATED,
+		"timezone":     restaurant.Timezone,
 	}
 
 	err := p.conn.QueryRow(context.Background(), query, args).Scan(&restaurant.ID)
@@ -83,7 +93,7 @@ func (p *PgRestaurantStore) DeleteRestaurant(id int) error {
 
 func (p *PgRestaurantStore) UpdateRestaurant(restaurant *Restaurant) error {
 	query := `update restaurants set name=@name, phone_number=@phone_number, 
-	email=@email, password=@password, IBAN=@iban, status=@status where id=@id`
+	email=@email, password=@password, IBAN=@iban, status=@status, timezone=@timezone where id=@id`
 	args := pgx.NamedArgs{
 		"id":           restaurant.ID,
 		"name":         restaurant.Name,
@@ -92,6 +102,7 @@ func (p *PgRestaurantStore) UpdateRestaurant(restaurant *Restaurant) error {
 		"password":     restaurant.Password,
 		"iban":         restaurant.IBAN,
 		"status":       restaurant.Status,
+		"timezone":     restaurant.Timezone,
 	}
 
 	_, err := p.conn.Exec(context.Background(), query, args)
@@ -8,4 +8,5 @@ type Restaurant struct {
 	Password    string
 	IBAN        string
 	Status      Status
+	Timezone    string
 }
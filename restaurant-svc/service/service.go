@@ -8,13 +8,22 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
+	"github.com/VitoNaychev/food-app/metrics"
 	"github.com/VitoNaychev/food-app/pgconfig"
 	"github.com/VitoNaychev/food-app/restaurant-svc/handlers"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
+	"github.com/VitoNaychev/food-app/tracing"
 )
 
 func Run(ctx context.Context, env appenv.Enviornment, port string) {
+	shutdownTracing, err := tracing.Setup(context.Background(), "restaurant-svc")
+	if err != nil {
+		log.Fatalf("Tracing error: %v\n", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	dbConfig := pgconfig.GetConfigFromEnv(env)
 	connStr := dbConfig.GetConnectionString()
 
@@ -38,22 +47,50 @@ func Run(ctx context.Context, env appenv.Enviornment, port string) {
 		log.Fatalf("Menu Store error: %v\n", err)
 	}
 
-	eventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	kafkaEventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
 	if err != nil {
 		log.Fatalf("Event Publisher error: %v\n", err)
 	}
-	defer eventPublisher.Close()
+	kafkaEventPublisher.SetProducer("restaurant-svc")
+	defer kafkaEventPublisher.Close()
+
+	outboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Store error: %v\n", err)
+	}
+
+	// The relay polls on its own pool so it never competes with request
+	// handling for connections.
+	relayOutboxStore, err := events.NewPgOutboxStore(context.Background(), connStr)
+	if err != nil {
+		log.Fatalf("Outbox Relay Store error: %v\n", err)
+	}
+
+	outboxRelay := events.NewOutboxRelay(relayOutboxStore, kafkaEventPublisher, events.DefaultOutboxRelayConfig)
+	go outboxRelay.Run(ctx)
+
+	eventPublisher := events.NewOutboxPublisher(outboxStore)
+
+	tokenStore, err := auth.NewPgTokenStore(ctx, connStr)
+	if err != nil {
+		log.Fatalf("Token Store error: %v\n", err)
+	}
+
+	signer, err := auth.NewSigner(env.SecretKey, env.SigningKeyFiles, env.ExpiresAt)
+	if err != nil {
+		log.Fatalf("Signer error: %v\n", err)
+	}
 
-	restaurantServer := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, &restaurantStore, eventPublisher)
-	addressServer := handlers.NewAddressServer(env.SecretKey, &addressStore, &restaurantStore)
-	hoursServer := handlers.NewHoursServer(env.SecretKey, &hoursStore, &restaurantStore)
-	menuServer := handlers.NewMenuServer(env.SecretKey, &menuStore, &restaurantStore, eventPublisher)
+	restaurantServer := handlers.NewRestaurantServer(signer, env.ExpiresAt, &restaurantStore, eventPublisher, tokenStore)
+	addressServer := handlers.NewAddressServer(signer, &addressStore, &restaurantStore, eventPublisher, tokenStore)
+	hoursServer := handlers.NewHoursServer(signer, &hoursStore, &restaurantStore, eventPublisher, tokenStore)
+	menuServer := handlers.NewMenuServer(signer, &menuStore, &restaurantStore, eventPublisher, tokenStore)
 
 	router := handlers.NewRouterServer(restaurantServer, addressServer, hoursServer, menuServer)
 
 	server := http.Server{
 		Addr:    port,
-		Handler: router,
+		Handler: tracing.Middleware(metrics.Middleware(router), "restaurant-svc"),
 	}
 
 	fmt.Printf("Restaurant service listening on %s\n", port)
@@ -1,3 +1,7 @@
+DROP TABLE IF EXISTS revoked_subjects;
+DROP TABLE IF EXISTS revoked_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS outbox;
 DROP TABLE IF EXISTS working_hours;
 DROP TABLE IF EXISTS menu_items;
 DROP TABLE IF EXISTS restaurants;
@@ -12,7 +16,8 @@ CREATE TABLE restaurants (
   email               varchar(60)          UNIQUE NOT NULL,
   password            varchar(72)          NOT NULL,
   IBAN                varchar(34)          UNIQUE NOT NULL,
-  status              int                  NOT NULL
+  status              int                  NOT NULL,
+  timezone            varchar(64)          NOT NULL
   );
 
 CREATE TABLE addresses (
@@ -41,4 +46,30 @@ CREATE TABLE menu_items (
   name                varchar(20)          NOT NULL,
   price               numeric(6, 2)        NOT NULL,
   details             text                 
-  );
\ No newline at end of file
+  );
+
+CREATE TABLE outbox (
+  id                  serial               PRIMARY KEY,
+  topic               varchar(100)         NOT NULL,
+  aggregate_id        int                  NOT NULL,
+  payload             bytea                NOT NULL,
+  headers             bytea                NOT NULL      DEFAULT '{}',
+  attempts            int                  NOT NULL      DEFAULT 0,
+  sent                boolean              NOT NULL      DEFAULT false
+);
+
+CREATE TABLE refresh_tokens (
+  hash                varchar(64)          PRIMARY KEY,
+  subject             int                  NOT NULL,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_tokens (
+  id                  varchar(64)          PRIMARY KEY,
+  expires_at          timestamp with time zone NOT NULL
+);
+
+CREATE TABLE revoked_subjects (
+  subject             int                  PRIMARY KEY,
+  revoked_before      timestamp with time zone NOT NULL
+);
@@ -11,6 +11,7 @@ var (
 		Password:    "samplepassword",
 		IBAN:        "DE89370400440532013000",
 		Status:      models.CREATED,
+		Timezone:    "Europe/Sofia",
 	}
 
 	DominosRestaurant = models.Restaurant{
@@ -21,5 +22,6 @@ var (
 		Password:    "samplepassword",
 		IBAN:        "DE89370400440532013000",
 		Status:      models.VALID,
+		Timezone:    "Europe/Berlin",
 	}
 )
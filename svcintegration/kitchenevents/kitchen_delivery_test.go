@@ -43,7 +43,7 @@ func TestKitchenDomainEvents(t *testing.T) {
 	initKitchenServiceTables(t, kitchenService)
 	initDeliveryServiceTables(t, deliveryService)
 
-	shackJWT, _ := auth.GenerateJWT(kitchenEnv.SecretKey, kitchenEnv.ExpiresAt, shackRestaurant.ID)
+	shackJWT, _ := auth.GenerateJWT(auth.HMACKey(kitchenEnv.SecretKey), kitchenEnv.ExpiresAt, shackRestaurant.ID, auth.RESTAURANT)
 
 	t.Run("delivery-svc updates delivery state to IN_PROGRESS when kitchen begins preparing ticket", func(t *testing.T) {
 		readyByStr := "23:59"
@@ -35,6 +35,8 @@ func TestDeliveryServiceOrderEventHandlers(t *testing.T) {
 	orderService.Run()
 	defer orderService.Stop()
 
+	initOrderServiceTables(t, orderService)
+
 	deliveryService := services.SetupDeliveryService(t, deliveryEnv, ":8080")
 	deliveryService.Run()
 	defer deliveryService.Stop()
@@ -42,7 +44,7 @@ func TestDeliveryServiceOrderEventHandlers(t *testing.T) {
 	initDeliveryServiceTables(t, deliveryService)
 
 	t.Run("delivery-svc creates coresponding delivery on ORDER_CREATED_EVENT", func(t *testing.T) {
-		createOrderRequestBody := handlers.NewCeateOrderRequestBody(peterCreatedOrder, peterCreatedOrderItems, chickenShackAddress, peterAddress1)
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(peterCreatedOrder, peterCreatedOrderItems, peterAddress1)
 		request := handlers.NewCreateOrderRequest(strconv.Itoa(peterCustomerID), createOrderRequestBody)
 		response := httptest.NewRecorder()
 
@@ -54,7 +56,14 @@ func TestDeliveryServiceOrderEventHandlers(t *testing.T) {
 
 		gotDelivery, err := deliveryService.DeliveryStore.GetDeliveryByID(peterCreatedOrder.ID)
 		testutil.AssertNoErr(t, err)
-		testutil.AssertEqual(t, gotDelivery, volenDelivery)
+
+		if gotDelivery.CorrelationID == "" {
+			t.Errorf("got delivery without the correlation of the order event")
+		}
+
+		wantDelivery := volenDelivery
+		wantDelivery.CorrelationID = gotDelivery.CorrelationID
+		testutil.AssertEqual(t, gotDelivery, wantDelivery)
 
 		gotPickupAddress, err := deliveryService.AddressStore.GetAddressByID(gotDelivery.PickupAddressID)
 		testutil.AssertNoErr(t, err)
@@ -2,6 +2,7 @@ package orderevents
 
 import (
 	"github.com/VitoNaychev/food-app/order-svc/models"
+	"github.com/VitoNaychev/food-app/workhours"
 )
 
 var (
@@ -48,8 +49,26 @@ var (
 		Country:      "Bulgaria",
 	}
 
-	chickenShackAddress = models.Address{
+	chickenShackRestaurant = models.Restaurant{
+		ID:       1,
+		Status:   models.RESTAURANT_VALID,
+		Timezone: workhours.DefaultTimezone,
+	}
+
+	// Zero opening and closing times mean the restaurant is open all day.
+	chickenShackRestaurantHours = []models.RestaurantHours{
+		{RestaurantID: 1, Day: 1},
+		{RestaurantID: 1, Day: 2},
+		{RestaurantID: 1, Day: 3},
+		{RestaurantID: 1, Day: 4},
+		{RestaurantID: 1, Day: 5},
+		{RestaurantID: 1, Day: 6},
+		{RestaurantID: 1, Day: 7},
+	}
+
+	chickenShackRestaurantAddress = models.RestaurantAddress{
 		ID:           1,
+		RestaurantID: 1,
 		Lat:          42.635934305,
 		Lon:          23.380761684,
 		AddressLine1: "ul. Filip Avramov 411, gk Mladost 4",
@@ -57,4 +76,25 @@ var (
 		City:         "Sofia",
 		Country:      "Bulgaria",
 	}
+
+	chickenShackMenuItems = []models.MenuItem{
+		{
+			ID:           1,
+			RestaurantID: 1,
+			Name:         "Chicken Burger",
+			Price:        5.02,
+		},
+		{
+			ID:           2,
+			RestaurantID: 1,
+			Name:         "Fries",
+			Price:        4.60,
+		},
+		{
+			ID:           3,
+			RestaurantID: 1,
+			Name:         "Coleslaw",
+			Price:        3.50,
+		},
+	}
 )
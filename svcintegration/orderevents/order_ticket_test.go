@@ -10,6 +10,7 @@ import (
 	"github.com/VitoNaychev/food-app/appenv"
 	"github.com/VitoNaychev/food-app/integrationutil"
 	"github.com/VitoNaychev/food-app/order-svc/handlers"
+	"github.com/VitoNaychev/food-app/order-svc/models"
 	"github.com/VitoNaychev/food-app/svcintegration/services"
 	"github.com/VitoNaychev/food-app/testutil"
 )
@@ -35,12 +36,16 @@ func TestKitchenServiceOrderEventHandlers(t *testing.T) {
 	orderService.Run()
 	defer orderService.Stop()
 
+	initOrderServiceTables(t, orderService)
+
 	kitchenService := services.SetupKitchenService(t, kitchenEnv, ":8080")
 	kitchenService.Run()
 	defer kitchenService.Stop()
 
+	initKitchenServiceTables(t, kitchenService)
+
 	t.Run("kitchen-svc creates coresponding ticket on ORDER_CREATED_EVENT", func(t *testing.T) {
-		createOrderRequestBody := handlers.NewCeateOrderRequestBody(peterCreatedOrder, peterCreatedOrderItems, chickenShackAddress, peterAddress1)
+		createOrderRequestBody := handlers.NewCeateOrderRequestBody(peterCreatedOrder, peterCreatedOrderItems, peterAddress1)
 		request := handlers.NewCreateOrderRequest(strconv.Itoa(peterCustomerID), createOrderRequestBody)
 		response := httptest.NewRecorder()
 
@@ -52,10 +57,39 @@ func TestKitchenServiceOrderEventHandlers(t *testing.T) {
 
 		gotTicket, err := kitchenService.TicketStore.GetTicketByID(peterCreatedOrder.ID)
 		testutil.AssertNoErr(t, err)
-		testutil.AssertEqual(t, gotTicket, shackTicket)
+
+		if gotTicket.CorrelationID == "" {
+			t.Errorf("got ticket without the correlation of the order event")
+		}
+
+		wantTicket := shackTicket
+		wantTicket.CorrelationID = gotTicket.CorrelationID
+		testutil.AssertEqual(t, gotTicket, wantTicket)
 
 		gotTicketItems, err := kitchenService.TicketItemStore.GetTicketItemsByTicketID(peterCreatedOrder.ID)
 		testutil.AssertNoErr(t, err)
 		testutil.AssertEqual(t, gotTicketItems, shackTicketItems)
 	})
+
+	t.Run("order-svc approves order on TICKET_APPROVED_EVENT", func(t *testing.T) {
+		gotOrder, err := orderService.OrderStore.GetOrderByID(peterCreatedOrder.ID)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, gotOrder.Status, models.APPROVED)
+	})
+}
+
+func initOrderServiceTables(t testing.TB, svc services.OrderService) {
+	testutil.AssertNoErr(t, svc.RestaurantStore.CreateRestaurant(&chickenShackRestaurant))
+	testutil.AssertNoErr(t, svc.RestaurantAddressStore.CreateRestaurantAddress(&chickenShackRestaurantAddress))
+	testutil.AssertNoErr(t, svc.RestaurantHoursStore.SetRestaurantHours(chickenShackRestaurant.ID, chickenShackRestaurantHours))
+	for i := range chickenShackMenuItems {
+		testutil.AssertNoErr(t, svc.MenuItemStore.CreateMenuItem(&chickenShackMenuItems[i]))
+	}
+}
+
+func initKitchenServiceTables(t testing.TB, svc services.KitchenService) {
+	testutil.AssertNoErr(t, svc.RestaurantStore.CreateRestaurant(&shackRestaurant))
+	for i := range shackMenuItems {
+		testutil.AssertNoErr(t, svc.MenuItemStore.CreateMenuItem(&shackMenuItems[i]))
+	}
 }
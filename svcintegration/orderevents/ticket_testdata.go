@@ -3,6 +3,31 @@ package orderevents
 import "github.com/VitoNaychev/food-app/kitchen-svc/models"
 
 var (
+	shackRestaurant = models.Restaurant{
+		ID: 1,
+	}
+
+	shackMenuItems = []models.MenuItem{
+		{
+			ID:           1,
+			RestaurantID: 1,
+			Name:         "Chicken Burger",
+			Price:        5.02,
+		},
+		{
+			ID:           2,
+			RestaurantID: 1,
+			Name:         "Fries",
+			Price:        4.60,
+		},
+		{
+			ID:           3,
+			RestaurantID: 1,
+			Name:         "Coleslaw",
+			Price:        3.50,
+		},
+	}
+
 	shackTicket = models.Ticket{
 		ID:           1,
 		RestaurantID: 1,
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/courier-svc/handlers"
 	"github.com/VitoNaychev/food-app/courier-svc/models"
 	"github.com/VitoNaychev/food-app/events"
@@ -21,6 +22,11 @@ type CourierService struct {
 	Server *http.Server
 
 	EventPublisher *events.KafkaEventPublisher
+
+	OutboxStore       *events.InMemoryOutboxStore
+	OutboxRelay       *events.OutboxRelay
+	OutboxRelayCtx    context.Context
+	OutboxRelayCancel context.CancelFunc
 }
 
 func SetupCourierService(t testing.TB, env appenv.Enviornment, port string) CourierService {
@@ -28,10 +34,17 @@ func SetupCourierService(t testing.TB, env appenv.Enviornment, port string) Cour
 	if err != nil {
 		t.Fatalf("Event Publisher error: %v\n", err)
 	}
+	eventPublisher.SetProducer("courier-svc")
+
+	outboxStore := events.NewInMemoryOutboxStore()
+	outboxRelay := events.NewOutboxRelay(outboxStore, eventPublisher, outboxRelayConfig)
+	outboxRelayCtx, outboxRelayCancel := context.WithCancel(context.Background())
+	outboxPublisher := events.NewOutboxPublisher(outboxStore)
 
 	courierStore := models.NewInMemoryCourierStore()
+	tokenStore := auth.NewInMemoryTokenStore()
 
-	courierHandler := handlers.NewCourierServer(env.SecretKey, env.ExpiresAt, courierStore, eventPublisher)
+	courierHandler := handlers.NewCourierServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, courierStore, outboxPublisher, tokenStore)
 
 	server := &http.Server{
 		Addr:    port,
@@ -46,12 +59,19 @@ func SetupCourierService(t testing.TB, env appenv.Enviornment, port string) Cour
 		Server: server,
 
 		EventPublisher: eventPublisher,
+
+		OutboxStore:       outboxStore,
+		OutboxRelay:       outboxRelay,
+		OutboxRelayCtx:    outboxRelayCtx,
+		OutboxRelayCancel: outboxRelayCancel,
 	}
 
 	return courierService
 }
 
 func (c *CourierService) Run() {
+	go c.OutboxRelay.Run(c.OutboxRelayCtx)
+
 	log.Printf("Courier service listening on %s\n", c.Server.Addr)
 
 	go func() {
@@ -63,6 +83,7 @@ func (c *CourierService) Run() {
 }
 
 func (c *CourierService) Stop() {
+	c.OutboxRelayCancel()
 	c.EventPublisher.Close()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
@@ -2,9 +2,11 @@ package services
 
 import (
 	"context"
+	"log"
 	"testing"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/delivery-svc/dispatch"
 	"github.com/VitoNaychev/food-app/delivery-svc/handlers"
 	"github.com/VitoNaychev/food-app/delivery-svc/models"
 	"github.com/VitoNaychev/food-app/events"
@@ -15,10 +17,19 @@ type DeliveryService struct {
 	LocationStore *models.InMemoryLocationStore
 	DeliveryStore *models.InMemoryDeliveryStore
 	AddressStore  *models.InMemoryAddressStore
+	OfferStore    *models.InMemoryOfferStore
 
-	CourierEventHandler *handlers.CourierEventHandler
-	KitchenEventHandler *handlers.KitchenEventHandler
-	OrderEventHandler   *handlers.OrderEventHandler
+	CourierEventHandler  *handlers.CourierEventHandler
+	KitchenEventHandler  *handlers.KitchenEventHandler
+	OrderEventHandler    *handlers.OrderEventHandler
+	DispatchEventHandler *handlers.DispatchEventHandler
+
+	EventPublisher *events.KafkaEventPublisher
+
+	OutboxStore       *events.InMemoryOutboxStore
+	OutboxRelay       *events.OutboxRelay
+	OutboxRelayCtx    context.Context
+	OutboxRelayCancel context.CancelFunc
 
 	EventConsumer       *events.KafkaEventConsumer
 	EventConsumerCtx    context.Context
@@ -26,19 +37,37 @@ type DeliveryService struct {
 }
 
 func SetupDeliveryService(t testing.TB, env appenv.Enviornment, port string) DeliveryService {
+	eventPublisher, err := events.NewKafkaEventPublisher(env.KafkaBrokers)
+	if err != nil {
+		t.Fatalf("Kafka Event Publisher error: %v\n", err)
+	}
+	eventPublisher.SetProducer("delivery-svc")
+
+	outboxStore := events.NewInMemoryOutboxStore()
+	outboxRelay := events.NewOutboxRelay(outboxStore, eventPublisher, outboxRelayConfig)
+	outboxRelayCtx, outboxRelayCancel := context.WithCancel(context.Background())
+	outboxPublisher := events.NewOutboxPublisher(outboxStore)
+
 	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "delivery-grp")
 	if err != nil {
 		t.Fatalf("Kafka Event Consumer error: %v\n", err)
 	}
+	eventConsumer.SetInboxStore(events.NewInMemoryInboxStore())
+	eventConsumer.SetDeadLetterPublisher(eventPublisher)
 
 	courierStore := models.NewInMemoryCourierStore()
 	locationStore := models.NewInMemoryLocationStore()
 	deliveryStore := models.NewInMemoryDeliveryStore()
 	addressStore := models.NewInMemoryAddressStore()
+	offerStore := models.NewInMemoryOfferStore()
+
+	dispatcher := dispatch.NewDispatcher(courierStore, deliveryStore, addressStore, offerStore,
+		outboxPublisher, dispatch.DefaultDispatcherConfig)
 
 	courierEventHandler := handlers.NewCourierEventHandler(courierStore, locationStore)
-	kitchenEventHandler := handlers.NewKitchenEventHandler(deliveryStore)
-	orderEventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore)
+	kitchenEventHandler := handlers.NewKitchenEventHandler(deliveryStore, offerStore, outboxPublisher)
+	orderEventHandler := handlers.NewOrderEventHandler(deliveryStore, addressStore, dispatcher)
+	dispatchEventHandler := handlers.NewDispatchEventHandler(dispatcher)
 	eventConsumerCtx, eventConsumerCancel := context.WithCancel(context.Background())
 
 	deliveryService := DeliveryService{
@@ -46,10 +75,19 @@ func SetupDeliveryService(t testing.TB, env appenv.Enviornment, port string) Del
 		LocationStore: locationStore,
 		DeliveryStore: deliveryStore,
 		AddressStore:  addressStore,
+		OfferStore:    offerStore,
+
+		CourierEventHandler:  courierEventHandler,
+		KitchenEventHandler:  kitchenEventHandler,
+		OrderEventHandler:    orderEventHandler,
+		DispatchEventHandler: dispatchEventHandler,
+
+		EventPublisher: eventPublisher,
 
-		CourierEventHandler: courierEventHandler,
-		KitchenEventHandler: kitchenEventHandler,
-		OrderEventHandler:   orderEventHandler,
+		OutboxStore:       outboxStore,
+		OutboxRelay:       outboxRelay,
+		OutboxRelayCtx:    outboxRelayCtx,
+		OutboxRelayCancel: outboxRelayCancel,
 
 		EventConsumer:       eventConsumer,
 		EventConsumerCtx:    eventConsumerCtx,
@@ -60,15 +98,36 @@ func SetupDeliveryService(t testing.TB, env appenv.Enviornment, port string) Del
 }
 
 func (d *DeliveryService) Run() {
-	handlers.RegisterCourierEventHandlers(d.EventConsumer, d.CourierEventHandler)
-	handlers.RegisterKitchenEventHandlers(d.EventConsumer, d.KitchenEventHandler)
-	handlers.RegisterOrderEventHandlers(d.EventConsumer, d.OrderEventHandler)
+	go d.OutboxRelay.Run(d.OutboxRelayCtx)
+
+	err := handlers.RegisterCourierEventHandlers(d.EventConsumer, d.CourierEventHandler)
+	if err != nil {
+		log.Fatalf("Courier Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterKitchenEventHandlers(d.EventConsumer, d.KitchenEventHandler)
+	if err != nil {
+		log.Fatalf("Kitchen Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterOrderEventHandlers(d.EventConsumer, d.OrderEventHandler)
+	if err != nil {
+		log.Fatalf("Order Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterDispatchEventHandlers(d.EventConsumer, d.DispatchEventHandler)
+	if err != nil {
+		log.Fatalf("Dispatch Event Handlers error: %v\n", err)
+	}
 
 	go d.EventConsumer.Run(d.EventConsumerCtx)
 	go events.LogEventConsumerErrors(d.EventConsumerCtx, d.EventConsumer)
 }
 
 func (d *DeliveryService) Stop() {
+	d.OutboxRelayCancel()
+	d.EventPublisher.Close()
+
 	d.EventConsumerCancel()
 	d.EventConsumer.Close()
 }
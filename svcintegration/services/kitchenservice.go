@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/kitchen-svc/handlers"
 	"github.com/VitoNaychev/food-app/kitchen-svc/models"
@@ -23,9 +24,15 @@ type KitchenService struct {
 
 	RestaurantEventHandler *handlers.RestaurantEventHandler
 	OrderEventHandler      *handlers.OrderEventHandler
+	DeliveryEventHandler   *handlers.DeliveryEventHandler
 
 	EventPublisher *events.KafkaEventPublisher
 
+	OutboxStore       *events.InMemoryOutboxStore
+	OutboxRelay       *events.OutboxRelay
+	OutboxRelayCtx    context.Context
+	OutboxRelayCancel context.CancelFunc
+
 	EventConsumer       *events.KafkaEventConsumer
 	EventConsumerCtx    context.Context
 	EventConsumerCancel context.CancelFunc
@@ -36,25 +43,34 @@ func SetupKitchenService(t testing.TB, env appenv.Enviornment, port string) Kitc
 	if err != nil {
 		t.Fatalf("Kafka Event Publisher error: %v\n", err)
 	}
+	eventPublisher.SetProducer("kitchen-svc")
+
+	outboxStore := events.NewInMemoryOutboxStore()
+	outboxRelay := events.NewOutboxRelay(outboxStore, eventPublisher, outboxRelayConfig)
+	outboxRelayCtx, outboxRelayCancel := context.WithCancel(context.Background())
+	outboxPublisher := events.NewOutboxPublisher(outboxStore)
 
 	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "kitchen-grp")
 	if err != nil {
 		t.Fatalf("Kafka Event Consumer error: %v\n", err)
 	}
+	eventConsumer.SetInboxStore(events.NewInMemoryInboxStore())
+	eventConsumer.SetDeadLetterPublisher(eventPublisher)
 
 	restaurantStore := models.NewInMemoryRestaurantStore()
 	menuItemStore := models.NewInMemoryMenuItemStore()
 	ticketStore := models.NewInMemoryTicketStore()
 	ticketItemStore := models.NewInMemoryTicketItemStore()
 
-	ticketHandler := handlers.NewTicketServer(env.SecretKey, ticketStore, ticketItemStore, menuItemStore, restaurantStore, eventPublisher)
+	ticketHandler := handlers.NewTicketServer(auth.HMACKey(env.SecretKey), ticketStore, ticketItemStore, menuItemStore, restaurantStore, outboxPublisher, dummyVerifyJWT)
 	server := &http.Server{
 		Addr:    port,
 		Handler: ticketHandler,
 	}
 
 	restaurantEventHandler := handlers.NewRestaurantEventHandler(restaurantStore, menuItemStore)
-	orderEventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore)
+	orderEventHandler := handlers.NewOrderEventHandler(ticketStore, ticketItemStore, menuItemStore, restaurantStore, outboxPublisher)
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(ticketStore)
 
 	eventConsumerCtx, eventConsumerCancel := context.WithCancel(context.Background())
 
@@ -68,9 +84,15 @@ func SetupKitchenService(t testing.TB, env appenv.Enviornment, port string) Kitc
 
 		RestaurantEventHandler: restaurantEventHandler,
 		OrderEventHandler:      orderEventHandler,
+		DeliveryEventHandler:   deliveryEventHandler,
 
 		EventPublisher: eventPublisher,
 
+		OutboxStore:       outboxStore,
+		OutboxRelay:       outboxRelay,
+		OutboxRelayCtx:    outboxRelayCtx,
+		OutboxRelayCancel: outboxRelayCancel,
+
 		EventConsumer:       eventConsumer,
 		EventConsumerCtx:    eventConsumerCtx,
 		EventConsumerCancel: eventConsumerCancel,
@@ -80,8 +102,22 @@ func SetupKitchenService(t testing.TB, env appenv.Enviornment, port string) Kitc
 }
 
 func (k *KitchenService) Run() {
-	handlers.RegisterRestaurantEventHandlers(k.EventConsumer, k.RestaurantEventHandler)
-	handlers.RegisterOrderEventHandlers(k.EventConsumer, k.OrderEventHandler)
+	go k.OutboxRelay.Run(k.OutboxRelayCtx)
+
+	err := handlers.RegisterRestaurantEventHandlers(k.EventConsumer, k.RestaurantEventHandler)
+	if err != nil {
+		log.Fatalf("Restaurant Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterOrderEventHandlers(k.EventConsumer, k.OrderEventHandler)
+	if err != nil {
+		log.Fatalf("Order Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterDeliveryEventHandlers(k.EventConsumer, k.DeliveryEventHandler)
+	if err != nil {
+		log.Fatalf("Delivery Event Handlers error: %v\n", err)
+	}
 
 	go k.EventConsumer.Run(k.EventConsumerCtx)
 
@@ -96,6 +132,7 @@ func (k *KitchenService) Run() {
 }
 
 func (k *KitchenService) Stop() {
+	k.OutboxRelayCancel()
 	k.EventPublisher.Close()
 
 	k.EventConsumerCancel()
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/msgtypes"
 	"github.com/VitoNaychev/food-app/order-svc/handlers"
@@ -17,7 +18,7 @@ import (
 
 func dummyVerifyJWT(jwt string) (msgtypes.AuthResponse, error) {
 	id, _ := strconv.Atoi(jwt)
-	return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id}, nil
+	return msgtypes.AuthResponse{Status: msgtypes.OK, ID: id, Role: string(auth.CUSTOMER)}, nil
 }
 
 type OrderService struct {
@@ -25,11 +26,30 @@ type OrderService struct {
 	OrderItemStore *models.InMemoryOrderItemStore
 	AddressStore   *models.InMemoryAddressStore
 
+	RestaurantStore        *models.InMemoryRestaurantStore
+	MenuItemStore          *models.InMemoryMenuItemStore
+	RestaurantAddressStore *models.InMemoryRestaurantAddressStore
+	RestaurantHoursStore   *models.InMemoryRestaurantHoursStore
+
 	OrderHandler handlers.OrderServer
 
+	KitchenEventHandler    *handlers.KitchenEventHandler
+	DeliveryEventHandler   *handlers.DeliveryEventHandler
+	PaymentEventHandler    *handlers.PaymentEventHandler
+	RestaurantEventHandler *handlers.RestaurantEventHandler
+
 	Server *http.Server
 
 	EventPublisher *events.KafkaEventPublisher
+
+	OutboxStore       *events.InMemoryOutboxStore
+	OutboxRelay       *events.OutboxRelay
+	OutboxRelayCtx    context.Context
+	OutboxRelayCancel context.CancelFunc
+
+	EventConsumer       *events.KafkaEventConsumer
+	EventConsumerCtx    context.Context
+	EventConsumerCancel context.CancelFunc
 }
 
 func SetupOrderService(t testing.TB, env appenv.Enviornment, port string) OrderService {
@@ -37,12 +57,37 @@ func SetupOrderService(t testing.TB, env appenv.Enviornment, port string) OrderS
 	if err != nil {
 		t.Fatalf("Kafka Event Publisher error: %v\n", err)
 	}
+	eventPublisher.SetProducer("order-svc")
+
+	outboxStore := events.NewInMemoryOutboxStore()
+	outboxRelay := events.NewOutboxRelay(outboxStore, eventPublisher, outboxRelayConfig)
+	outboxRelayCtx, outboxRelayCancel := context.WithCancel(context.Background())
+	outboxPublisher := events.NewOutboxPublisher(outboxStore)
+
+	eventConsumer, err := events.NewKafkaEventConsumer(env.KafkaBrokers, "order-grp")
+	if err != nil {
+		t.Fatalf("Kafka Event Consumer error: %v\n", err)
+	}
+	eventConsumer.SetInboxStore(events.NewInMemoryInboxStore())
+	eventConsumer.SetDeadLetterPublisher(eventPublisher)
 
 	orderStore := models.NewInMemoryOrderStore()
 	orderItemStore := models.NewInMemoryOrderItemStore()
 	addressStore := models.NewInMemoryAddressStore()
 
-	orderHandler := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, eventPublisher, dummyVerifyJWT)
+	restaurantStore := models.NewInMemoryRestaurantStore()
+	menuItemStore := models.NewInMemoryMenuItemStore()
+	restaurantAddressStore := models.NewInMemoryRestaurantAddressStore()
+	restaurantHoursStore := models.NewInMemoryRestaurantHoursStore()
+
+	orderHandler := handlers.NewOrderServer(orderStore, orderItemStore, addressStore, restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore, outboxPublisher, dummyVerifyJWT)
+
+	kitchenEventHandler := handlers.NewKitchenEventHandler(orderStore, outboxPublisher)
+	deliveryEventHandler := handlers.NewDeliveryEventHandler(orderStore)
+	paymentEventHandler := handlers.NewPaymentEventHandler(orderStore, outboxPublisher)
+	restaurantEventHandler := handlers.NewRestaurantEventHandler(restaurantStore, menuItemStore, restaurantAddressStore, restaurantHoursStore)
+
+	eventConsumerCtx, eventConsumerCancel := context.WithCancel(context.Background())
 
 	server := &http.Server{
 		Addr:    port,
@@ -54,17 +99,60 @@ func SetupOrderService(t testing.TB, env appenv.Enviornment, port string) OrderS
 		OrderItemStore: orderItemStore,
 		AddressStore:   addressStore,
 
+		RestaurantStore:        restaurantStore,
+		MenuItemStore:          menuItemStore,
+		RestaurantAddressStore: restaurantAddressStore,
+		RestaurantHoursStore:   restaurantHoursStore,
+
 		OrderHandler: orderHandler,
 
+		KitchenEventHandler:    kitchenEventHandler,
+		DeliveryEventHandler:   deliveryEventHandler,
+		PaymentEventHandler:    paymentEventHandler,
+		RestaurantEventHandler: restaurantEventHandler,
+
 		Server: server,
 
 		EventPublisher: eventPublisher,
+
+		OutboxStore:       outboxStore,
+		OutboxRelay:       outboxRelay,
+		OutboxRelayCtx:    outboxRelayCtx,
+		OutboxRelayCancel: outboxRelayCancel,
+
+		EventConsumer:       eventConsumer,
+		EventConsumerCtx:    eventConsumerCtx,
+		EventConsumerCancel: eventConsumerCancel,
 	}
 
 	return orderService
 }
 
 func (o *OrderService) Run() {
+	go o.OutboxRelay.Run(o.OutboxRelayCtx)
+
+	err := handlers.RegisterKitchenEventHandlers(o.EventConsumer, o.KitchenEventHandler)
+	if err != nil {
+		log.Fatalf("Kitchen Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterDeliveryEventHandlers(o.EventConsumer, o.DeliveryEventHandler)
+	if err != nil {
+		log.Fatalf("Delivery Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterPaymentEventHandlers(o.EventConsumer, o.PaymentEventHandler)
+	if err != nil {
+		log.Fatalf("Payment Event Handlers error: %v\n", err)
+	}
+
+	err = handlers.RegisterRestaurantEventHandlers(o.EventConsumer, o.RestaurantEventHandler)
+	if err != nil {
+		log.Fatalf("Restaurant Event Handlers error: %v\n", err)
+	}
+
+	go o.EventConsumer.Run(o.EventConsumerCtx)
+
 	log.Printf("Order service listening on %s\n", o.Server.Addr)
 
 	go func() {
@@ -76,8 +164,12 @@ func (o *OrderService) Run() {
 }
 
 func (o *OrderService) Stop() {
+	o.OutboxRelayCancel()
 	o.EventPublisher.Close()
 
+	o.EventConsumerCancel()
+	o.EventConsumer.Close()
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
 	defer shutdownCancel()
 
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"time"
+
+	"github.com/VitoNaychev/food-app/events"
+)
+
+var outboxRelayConfig = events.OutboxRelayConfig{
+	PollInterval: 50 * time.Millisecond,
+	BatchSize:    100,
+	MaxBackoff:   time.Second,
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/VitoNaychev/food-app/appenv"
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/restaurant-svc/handlers"
 	"github.com/VitoNaychev/food-app/restaurant-svc/models"
@@ -29,6 +30,11 @@ type RestaurantService struct {
 	Server *http.Server
 
 	EventPublisher *events.KafkaEventPublisher
+
+	OutboxStore       *events.InMemoryOutboxStore
+	OutboxRelay       *events.OutboxRelay
+	OutboxRelayCtx    context.Context
+	OutboxRelayCancel context.CancelFunc
 }
 
 func SetupRestaurantService(t testing.TB, env appenv.Enviornment, port string) RestaurantService {
@@ -36,16 +42,23 @@ func SetupRestaurantService(t testing.TB, env appenv.Enviornment, port string) R
 	if err != nil {
 		t.Fatalf("Event Publisher error: %v\n", err)
 	}
+	eventPublisher.SetProducer("restaurant-svc")
+
+	outboxStore := events.NewInMemoryOutboxStore()
+	outboxRelay := events.NewOutboxRelay(outboxStore, eventPublisher, outboxRelayConfig)
+	outboxRelayCtx, outboxRelayCancel := context.WithCancel(context.Background())
+	outboxPublisher := events.NewOutboxPublisher(outboxStore)
 
 	restaurantStore := models.NewInMemoryRestaurantStore()
 	addressStore := models.NewInMemoryAddressStore()
 	hoursStore := models.NewInMemoryHoursStore()
 	menuStore := models.NewInMemoryMenuStore()
+	tokenStore := auth.NewInMemoryTokenStore()
 
-	restaurantHandler := handlers.NewRestaurantServer(env.SecretKey, env.ExpiresAt, restaurantStore, eventPublisher)
-	addressHandler := handlers.NewAddressServer(env.SecretKey, addressStore, restaurantStore)
-	hoursHandler := handlers.NewHoursServer(env.SecretKey, hoursStore, restaurantStore)
-	menuHandler := handlers.NewMenuServer(env.SecretKey, menuStore, restaurantStore, eventPublisher)
+	restaurantHandler := handlers.NewRestaurantServer(auth.HMACKey(env.SecretKey), env.ExpiresAt, restaurantStore, outboxPublisher, tokenStore)
+	addressHandler := handlers.NewAddressServer(auth.HMACKey(env.SecretKey), addressStore, restaurantStore, outboxPublisher, tokenStore)
+	hoursHandler := handlers.NewHoursServer(auth.HMACKey(env.SecretKey), hoursStore, restaurantStore, outboxPublisher, tokenStore)
+	menuHandler := handlers.NewMenuServer(auth.HMACKey(env.SecretKey), menuStore, restaurantStore, outboxPublisher, tokenStore)
 
 	router := handlers.NewRouterServer(restaurantHandler, addressHandler, hoursHandler, menuHandler)
 
@@ -70,12 +83,19 @@ func SetupRestaurantService(t testing.TB, env appenv.Enviornment, port string) R
 		Server: server,
 
 		EventPublisher: eventPublisher,
+
+		OutboxStore:       outboxStore,
+		OutboxRelay:       outboxRelay,
+		OutboxRelayCtx:    outboxRelayCtx,
+		OutboxRelayCancel: outboxRelayCancel,
 	}
 
 	return restaurantService
 }
 
 func (r *RestaurantService) Run() {
+	go r.OutboxRelay.Run(r.OutboxRelayCtx)
+
 	log.Printf("Restaurant service listening on %s\n", r.Server.Addr)
 
 	go func() {
@@ -87,6 +107,7 @@ func (r *RestaurantService) Run() {
 }
 
 func (r *RestaurantService) Stop() {
+	r.OutboxRelayCancel()
 	r.EventPublisher.Close()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
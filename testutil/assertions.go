@@ -8,10 +8,10 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/VitoNaychev/food-app/auth"
 	"github.com/VitoNaychev/food-app/events"
 	"github.com/VitoNaychev/food-app/httperrors"
 	"github.com/VitoNaychev/food-app/validation"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type GenericTypeToResponseFunction func(interface{}) interface{}
@@ -124,12 +124,10 @@ func AssertValidResponse(t testing.TB, err error) {
 	}
 }
 
-func AssertJWT(t testing.TB, jwtString string, secretKey []byte, wantId int) {
+func AssertJWT(t testing.TB, jwtString string, keys auth.KeySet, wantId int, role auth.Role) {
 	t.Helper()
 
-	token, err := jwt.Parse(jwtString, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+	token, err := auth.VerifyJWT(jwtString, keys, role)
 
 	if err != nil {
 		t.Fatalf("error verifying JWT: %v", err)
@@ -0,0 +1,17 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Middleware starts a span for every request handled by handler, continuing
+// the trace of the caller when the request carries a trace context.
+func Middleware(handler http.Handler, serviceName string) http.Handler {
+	return otelhttp.NewHandler(handler, serviceName,
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
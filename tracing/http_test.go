@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VitoNaychev/food-app/testutil"
+	"github.com/VitoNaychev/food-app/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupSpanRecorder(t testing.TB) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	return recorder
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+
+	var requestSpan trace.SpanContext
+	handler := tracing.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestSpan = trace.SpanContextFromContext(r.Context())
+	}), "test-svc")
+
+	t.Run("starts span for request", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/order/all/", nil)
+		response := httptest.NewRecorder()
+
+		handler.ServeHTTP(response, request)
+
+		spans := recorder.Ended()
+		testutil.AssertEqual(t, spans[len(spans)-1].Name(), "GET /order/all/")
+		testutil.AssertEqual(t, spans[len(spans)-1].SpanContext().SpanID(), requestSpan.SpanID())
+	})
+
+	t.Run("continues trace of caller", func(t *testing.T) {
+		traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+		request, _ := http.NewRequest(http.MethodGet, "/order/all/", nil)
+		request.Header.Add("traceparent", traceParent)
+		response := httptest.NewRecorder()
+
+		handler.ServeHTTP(response, request)
+
+		testutil.AssertEqual(t, requestSpan.TraceID().String(), "0af7651916cd43dd8448eb211c80319c")
+	})
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/VitoNaychev/food-app/tracing"
+
+// PgQueryTracer starts a span for every query executed on a pgx connection.
+type PgQueryTracer struct {
+	tracer trace.Tracer
+}
+
+func NewPgQueryTracer() *PgQueryTracer {
+	return &PgQueryTracer{tracer: otel.Tracer(instrumentationName)}
+}
+
+func (p *PgQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	attributes := []attribute.KeyValue{
+		semconv.DBSystemPostgreSQL,
+		semconv.DBStatement(data.SQL),
+	}
+	if conn != nil {
+		attributes = append(attributes, semconv.DBName(conn.Config().Database))
+	}
+
+	ctx, _ = p.tracer.Start(ctx, "pgx.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+
+	return ctx
+}
+
+func (p *PgQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+const (
+	OTLP_EXPORTER   = "otlp"
+	STDOUT_EXPORTER = "stdout"
+	NONE_EXPORTER   = "none"
+)
+
+var ErrUnsupportedExporter = errors.New("unsupported trace exporter")
+
+// Setup installs the global tracer provider of serviceName and the W3C
+// trace context propagator. Spans are exported to the exporter named in
+// TRACES_EXPORTER, the OTLP exporter is configured with the standard
+// OTEL_EXPORTER_OTLP_* variables. Tracing is disabled when TRACES_EXPORTER
+// is unset.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch os.Getenv("TRACES_EXPORTER") {
+	case OTLP_EXPORTER:
+		exporter, err = otlptracehttp.New(ctx)
+	case STDOUT_EXPORTER:
+		exporter, err = stdouttrace.New()
+	case NONE_EXPORTER, "":
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, ErrUnsupportedExporter
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serviceResource := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
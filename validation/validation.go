@@ -35,12 +35,19 @@ func validatePrice(fl validator.FieldLevel) bool {
 	return matched
 }
 
+// bcrypt only hashes the first 72 bytes of a password, so the limit is on
+// bytes rather than on the runes counted by max.
+func validatePassword(fl validator.FieldLevel) bool {
+	return len([]byte(fl.Field().String())) <= 72
+}
+
 func InitValidate() {
 	validate = validator.New(validator.WithRequiredStructEnabled())
 
 	validate.RegisterValidation("phonenumber", validatePhoneNumber)
 	validate.RegisterValidation("workinghours", validateWorkingHours)
 	validate.RegisterValidation("price", validatePrice)
+	validate.RegisterValidation("password", validatePassword)
 }
 
 func ValidateBody[T ValidationObject](body io.Reader) (T, error) {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/VitoNaychev/food-app/testutil"
@@ -41,6 +42,10 @@ type PriceRequest struct {
 	Price string `validate:"required,price"`
 }
 
+type PasswordRequest struct {
+	Password string `validate:"required,password"`
+}
+
 func TestValidateBody(t *testing.T) {
 	t.Run("returns ErrNoBody on no body", func(t *testing.T) {
 		_, err := validation.ValidateBody[DummyRequest](nil)
@@ -225,6 +230,39 @@ func TestValidateBody(t *testing.T) {
 		}
 	})
 
+	t.Run("returns ErrInvalidRequestField on password longer than 72 bytes", func(t *testing.T) {
+		passwordRequest := PasswordRequest{
+			Password: strings.Repeat("ж", 37),
+		}
+
+		body := newRequestBody(passwordRequest)
+
+		_, err := validation.ValidateBody[PasswordRequest](body)
+
+		errInvalidRequestField := validation.NewErrInvalidRequestField("")
+		if !errors.As(err, &errInvalidRequestField) {
+			t.Errorf("didn't get error with type ErrInvalidRequestField")
+		}
+	})
+
+	t.Run("parses password of 72 bytes", func(t *testing.T) {
+		passwordRequest := PasswordRequest{
+			Password: strings.Repeat("ж", 36),
+		}
+
+		body := newRequestBody(passwordRequest)
+
+		gotPasswordRequest, err := validation.ValidateBody[PasswordRequest](body)
+
+		if err != nil {
+			t.Errorf("did not expect error, got %v", err)
+		}
+
+		if !reflect.DeepEqual(gotPasswordRequest, passwordRequest) {
+			t.Errorf("got %v want %v", gotPasswordRequest, passwordRequest)
+		}
+	})
+
 	t.Run("returns ErrInvalidField in invalid object in array", func(t *testing.T) {
 		dummyRequest := DummyRequest{
 			S: "Hello, World!",
@@ -0,0 +1,71 @@
+package workhours
+
+import "time"
+
+// DefaultTimezone is the timezone restaurants' working hours are expressed in.
+const DefaultTimezone = "Europe/Sofia"
+
+// Hours describes the working hours of a single day of the week. Day is in the
+// range 1 (Monday) to 7 (Sunday). Only the clock part of Opening and Closing is
+// used. A Closing before Opening spans midnight into the next day, while equal
+// Opening and Closing mean the restaurant is open all day.
+type Hours struct {
+	Day     int
+	Opening time.Time
+	Closing time.Time
+}
+
+func Weekday(t time.Time) int {
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		return 7
+	}
+
+	return weekday
+}
+
+func IsOpen(week []Hours, t time.Time) bool {
+	today := Weekday(t)
+	yesterday := today - 1
+	if yesterday == 0 {
+		yesterday = 7
+	}
+
+	now := minutesOfDay(t)
+	for _, hours := range week {
+		opening := minutesOfDay(hours.Opening)
+		closing := minutesOfDay(hours.Closing)
+
+		switch hours.Day {
+		case today:
+			if opening == closing {
+				return true
+			}
+			if opening < closing && now >= opening && now < closing {
+				return true
+			}
+			if closing < opening && now >= opening {
+				return true
+			}
+		case yesterday:
+			if closing < opening && now < closing {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func IsOpenIn(week []Hours, timezone string, t time.Time) (bool, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return false, err
+	}
+
+	return IsOpen(week, t.In(location)), nil
+}
+
+func minutesOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
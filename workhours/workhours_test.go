@@ -0,0 +1,84 @@
+package workhours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VitoNaychev/food-app/testutil"
+)
+
+func clock(s string) time.Time {
+	t, _ := time.Parse("15:04", s)
+	return t
+}
+
+func week(opening, closing string) []Hours {
+	hours := []Hours{}
+	for day := 1; day <= 7; day++ {
+		hours = append(hours, Hours{Day: day, Opening: clock(opening), Closing: clock(closing)})
+	}
+
+	return hours
+}
+
+func TestIsOpen(t *testing.T) {
+	// 2024-01-01 is a Monday
+	monday := func(s string) time.Time {
+		c := clock(s)
+		return time.Date(2024, 1, 1, c.Hour(), c.Minute(), 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		Name string
+		Week []Hours
+		Time time.Time
+		Want bool
+	}{
+		{"open during daytime range", week("09:00", "22:00"), monday("12:30"), true},
+		{"open at opening time", week("09:00", "22:00"), monday("09:00"), true},
+		{"closed at closing time", week("09:00", "22:00"), monday("22:00"), false},
+		{"closed before opening", week("09:00", "22:00"), monday("08:59"), false},
+		{"open after opening of overnight range", week("18:00", "02:00"), monday("23:00"), true},
+		{"open after midnight of overnight range", week("18:00", "02:00"), monday("01:30"), true},
+		{"closed after overnight range ends", week("18:00", "02:00"), monday("02:00"), false},
+		{"open all day on equal opening and closing", week("00:00", "00:00"), monday("03:00"), true},
+		{"closed without working hours", []Hours{}, monday("12:00"), false},
+		{
+			"overnight range of previous day carries over",
+			[]Hours{{Day: 7, Opening: clock("20:00"), Closing: clock("03:00")}},
+			monday("01:00"),
+			true,
+		},
+		{
+			"overnight range of today doesn't apply before opening",
+			[]Hours{{Day: 1, Opening: clock("20:00"), Closing: clock("03:00")}},
+			monday("01:00"),
+			false,
+		},
+	}
+
+	for _, test := range cases {
+		t.Run(test.Name, func(t *testing.T) {
+			got := IsOpen(test.Week, test.Time)
+			testutil.AssertEqual(t, got, test.Want)
+		})
+	}
+}
+
+func TestIsOpenIn(t *testing.T) {
+	// 10:30 UTC on a Monday in January is 12:30 in Sofia
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("evaluates hours in the given timezone", func(t *testing.T) {
+		got, err := IsOpenIn(week("12:00", "13:00"), DefaultTimezone, now)
+		testutil.AssertNoErr(t, err)
+		testutil.AssertEqual(t, got, true)
+	})
+
+	t.Run("returns error on unknown timezone", func(t *testing.T) {
+		_, err := IsOpenIn(week("12:00", "13:00"), "Mars/Olympus_Mons", now)
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}